@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var (
@@ -57,6 +58,10 @@ var (
 		ResourceUtilization: util.Pointer[int32](90),
 		ResourceName:        util.Pointer("cpu"),
 	}
+	apicastDefaultDeploymentStrategy defaultDeploymentRollingStrategySpec = defaultDeploymentRollingStrategySpec{
+		MaxUnavailable: util.Pointer(intstr.FromInt(0)),
+		MaxSurge:       util.Pointer(intstr.FromInt(1)),
+	}
 	apicastDefaultLivenessProbe defaultProbeSpec = defaultProbeSpec{
 		InitialDelaySeconds: util.Pointer[int32](5),
 		TimeoutSeconds:      util.Pointer[int32](5),
@@ -134,6 +139,10 @@ func (spec *ApicastSpec) ResolveCanarySpec(canary *Canary) (*ApicastSpec, error)
 
 // ApicastEnvironmentSpec is the configuration for an Apicast environment
 type ApicastEnvironmentSpec struct {
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	// +patchStrategy=retainKeys
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
 	// Image specification for the component
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -193,6 +202,7 @@ type ApicastEnvironmentSpec struct {
 func (spec *ApicastEnvironmentSpec) Default() {
 
 	spec.Image = InitializeImageSpec(spec.Image, apicastDefaultImage)
+	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, apicastDefaultDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, apicastDefaultHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &apicastDefaultReplicas)
 	spec.PDB = InitializePodDisruptionBudgetSpec(spec.PDB, apicastDefaultPDB)
@@ -204,6 +214,11 @@ func (spec *ApicastEnvironmentSpec) Default() {
 	spec.Config.Default()
 }
 
+// Validate checks that the canary is compatible with the deployment strategy
+func (spec *ApicastEnvironmentSpec) Validate(fldPath *field.Path) field.ErrorList {
+	return validateCanaryStrategy(spec.DeploymentStrategy, spec.Canary, fldPath)
+}
+
 // ApicastConfig configures app behavior for Apicast
 type ApicastConfig struct {
 	// Apicast configurations cache TTL
@@ -232,10 +247,18 @@ func (cfg *ApicastConfig) Default() {
 }
 
 // ApicastStatus defines the observed state of Apicast
-type ApicastStatus struct{}
+type ApicastStatus struct {
+	WorkloadsStatus `json:",inline"`
+}
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Ready")].status`,name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Progressing")].status`,name=Progressing,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,name=Degraded,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.workloads[0].deployment.image`,name=Image,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name=Age,type=date
 
 // Apicast is the Schema for the apicasts API
 type Apicast struct {
@@ -251,6 +274,21 @@ func (a *Apicast) Default() {
 	a.Spec.Default()
 }
 
+// GetWorkloadsStatus returns the status of the workloads of the Apicast resource
+func (a *Apicast) GetWorkloadsStatus() *WorkloadsStatus {
+	return &a.Status.WorkloadsStatus
+}
+
+// CanarySpecDiff returns the changes that the given canary
+// applies to the spec, as a JSON merge patch
+func (a *Apicast) CanarySpecDiff(canary *Canary) (string, error) {
+	canarySpec, err := a.Spec.ResolveCanarySpec(canary)
+	if err != nil {
+		return "", err
+	}
+	return canarySpecDiff(a.Spec, canarySpec)
+}
+
 // +kubebuilder:object:root=true
 
 // ApicastList contains a list of Apicast
@@ -0,0 +1,50 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of Apicast with the manager
+func (a *Apicast) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(a).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-apicast,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=apicasts,verbs=create;update,versions=v1alpha1,name=mapicast.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Apicast{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-apicast,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=apicasts,verbs=create;update,versions=v1alpha1,name=vapicast.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Apicast{}
+
+// ValidateCreate implements webhook.Validator
+func (a *Apicast) ValidateCreate() error {
+	return a.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (a *Apicast) ValidateUpdate(old runtime.Object) error {
+	return a.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (a *Apicast) ValidateDelete() error {
+	return nil
+}
+
+func (a *Apicast) validate() error {
+	o := a.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	errs = append(errs, validateCanaries(&o.Spec, o.Spec.ResolveCanarySpec,
+		canaryField{field.NewPath("spec", "staging", "canary"), o.Spec.Staging.Canary},
+		canaryField{field.NewPath("spec", "production", "canary"), o.Spec.Production.Canary},
+	)...)
+	return invalid("Apicast", a.GetName(), errs)
+}
@@ -57,6 +57,10 @@ var (
 		ResourceUtilization: util.Pointer[int32](90),
 		ResourceName:        util.Pointer("cpu"),
 	}
+	autosslDefaultDeploymentStrategy defaultDeploymentRollingStrategySpec = defaultDeploymentRollingStrategySpec{
+		MaxUnavailable: util.Pointer(intstr.FromInt(0)),
+		MaxSurge:       util.Pointer(intstr.FromInt(1)),
+	}
 	autosslDefaultProbe defaultProbeSpec = defaultProbeSpec{
 		InitialDelaySeconds: util.Pointer[int32](25),
 		TimeoutSeconds:      util.Pointer[int32](1),
@@ -79,6 +83,10 @@ var (
 
 // AutoSSLSpec defines the desired state of AutoSSL
 type AutoSSLSpec struct {
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	// +patchStrategy=retainKeys
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
 	// Image specification for the component
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -138,6 +146,7 @@ type AutoSSLSpec struct {
 func (spec *AutoSSLSpec) Default() {
 
 	spec.Image = InitializeImageSpec(spec.Image, autosslDefaultImage)
+	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, autosslDefaultDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, autosslDefaultHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &autosslDefaultReplicas)
 	spec.PDB = InitializePodDisruptionBudgetSpec(spec.PDB, autosslDefaultPDB)
@@ -220,12 +229,17 @@ func (cfg *AutoSSLConfig) Default() {
 
 // AutoSSLStatus defines the observed state of AutoSSL
 type AutoSSLStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	WorkloadsStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Ready")].status`,name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Progressing")].status`,name=Progressing,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,name=Degraded,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.workloads[0].deployment.image`,name=Image,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name=Age,type=date
 
 // AutoSSL is the Schema for the autossls API
 type AutoSSL struct {
@@ -241,6 +255,21 @@ func (a *AutoSSL) Default() {
 	a.Spec.Default()
 }
 
+// GetWorkloadsStatus returns the status of the workloads of the AutoSSL resource
+func (a *AutoSSL) GetWorkloadsStatus() *WorkloadsStatus {
+	return &a.Status.WorkloadsStatus
+}
+
+// CanarySpecDiff returns the changes that the given canary
+// applies to the spec, as a JSON merge patch
+func (a *AutoSSL) CanarySpecDiff(canary *Canary) (string, error) {
+	canarySpec, err := a.Spec.ResolveCanarySpec(canary)
+	if err != nil {
+		return "", err
+	}
+	return canarySpecDiff(a.Spec, canarySpec)
+}
+
 // +kubebuilder:object:root=true
 
 // AutoSSLList contains a list of AutoSSL
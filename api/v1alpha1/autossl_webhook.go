@@ -0,0 +1,49 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of AutoSSL with the manager
+func (a *AutoSSL) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(a).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-autossl,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=autossls,verbs=create;update,versions=v1alpha1,name=mautossl.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &AutoSSL{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-autossl,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=autossls,verbs=create;update,versions=v1alpha1,name=vautossl.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &AutoSSL{}
+
+// ValidateCreate implements webhook.Validator
+func (a *AutoSSL) ValidateCreate() error {
+	return a.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (a *AutoSSL) ValidateUpdate(old runtime.Object) error {
+	return a.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (a *AutoSSL) ValidateDelete() error {
+	return nil
+}
+
+func (a *AutoSSL) validate() error {
+	o := a.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	errs = append(errs, validateCanaries(&o.Spec, o.Spec.ResolveCanarySpec,
+		canaryField{field.NewPath("spec", "canary"), o.Spec.Canary},
+	)...)
+	return invalid("AutoSSL", a.GetName(), errs)
+}
@@ -18,10 +18,13 @@ package v1alpha1
 
 import (
 	"github.com/3scale-ops/basereconciler/util"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var (
@@ -42,6 +45,10 @@ var (
 		ResourceUtilization: util.Pointer[int32](90),
 		ResourceName:        util.Pointer("cpu"),
 	}
+	backendDefaultListenerDeploymentStrategy defaultDeploymentRollingStrategySpec = defaultDeploymentRollingStrategySpec{
+		MaxUnavailable: util.Pointer(intstr.FromInt(0)),
+		MaxSurge:       util.Pointer(intstr.FromInt(1)),
+	}
 	backendDefaultListenerPDB defaultPodDisruptionBudgetSpec = defaultPodDisruptionBudgetSpec{
 		MaxUnavailable: util.Pointer(intstr.FromInt(1)),
 	}
@@ -85,6 +92,23 @@ var (
 		ResourceUtilization: util.Pointer[int32](90),
 		ResourceName:        util.Pointer("cpu"),
 	}
+	// scale on the rate of jobs processed per second by each worker pod. The
+	// metrics adapter needs to expose the rate of the apisonator_worker_job_count
+	// counter as a pods metric (eg "sum(rate(<<.Series>>{<<.LabelMatchers>>}[1m])) by (<<.GroupBy>>)")
+	backendWorkerSuggestedMetrics []autoscalingv2.MetricSpec = []autoscalingv2.MetricSpec{{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "apisonator_worker_job_rate"},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: util.Pointer(resource.MustParse("100")),
+			},
+		},
+	}}
+	backendDefaultWorkerDeploymentStrategy defaultDeploymentRollingStrategySpec = defaultDeploymentRollingStrategySpec{
+		MaxUnavailable: util.Pointer(intstr.FromInt(0)),
+		MaxSurge:       util.Pointer(intstr.FromInt(1)),
+	}
 	backendDefaultWorkerPDB defaultPodDisruptionBudgetSpec = defaultPodDisruptionBudgetSpec{
 		MaxUnavailable: util.Pointer(intstr.FromInt(1)),
 	}
@@ -204,6 +228,10 @@ func (spec *BackendSpec) ResolveCanarySpec(canary *Canary) (*BackendSpec, error)
 
 // ListenerSpec is the configuration for Backend Listener
 type ListenerSpec struct {
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	// +patchStrategy=retainKeys
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
 	// Listener specific configuration options for the component element
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -256,11 +284,15 @@ type ListenerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Canary *Canary `json:"canary,omitempty"`
+	// Additional and overridden environment variables for the containers
+	// +optional
+	EnvironmentSpec `json:",inline"`
 }
 
 // Default implements defaulting for the each backend listener
 func (spec *ListenerSpec) Default() {
 
+	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, backendDefaultListenerDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, backendDefaultListenerHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &backendDefaultListenerReplicas)
 	spec.PDB = InitializePodDisruptionBudgetSpec(spec.PDB, backendDefaultListenerPDB)
@@ -275,8 +307,17 @@ func (spec *ListenerSpec) Default() {
 	spec.Config.Default()
 }
 
+// Validate checks that the canary is compatible with the deployment strategy
+func (spec *ListenerSpec) Validate(fldPath *field.Path) field.ErrorList {
+	return validateCanaryStrategy(spec.DeploymentStrategy, spec.Canary, fldPath)
+}
+
 // WorkerSpec is the configuration for Backend Worker
 type WorkerSpec struct {
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	// +patchStrategy=retainKeys
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
 	// Listener specific configuration options for the component element
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -318,11 +359,15 @@ type WorkerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Canary *Canary `json:"canary,omitempty"`
+	// Additional and overridden environment variables for the containers
+	// +optional
+	EnvironmentSpec `json:",inline"`
 }
 
 // Default implements defaulting for the each backend worker
 func (spec *WorkerSpec) Default() {
 
+	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, backendDefaultWorkerDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, backendDefaultWorkerHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &backendDefaultWorkerReplicas)
 	spec.PDB = InitializePodDisruptionBudgetSpec(spec.PDB, backendDefaultWorkerPDB)
@@ -335,6 +380,11 @@ func (spec *WorkerSpec) Default() {
 	spec.Config.Default()
 }
 
+// SuggestedMetrics returns the metrics suggested to autoscale the Backend worker
+func (spec *WorkerSpec) SuggestedMetrics() []autoscalingv2.MetricSpec {
+	return backendWorkerSuggestedMetrics
+}
+
 // CronSpec is the configuration for Backend Cron
 type CronSpec struct {
 	// Number of replicas for the component
@@ -353,6 +403,9 @@ type CronSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty" protobuf:"bytes,22,opt,name=tolerations"`
+	// Additional and overridden environment variables for the containers
+	// +optional
+	EnvironmentSpec `json:",inline"`
 }
 
 // Default implements defaulting for the each backend cron
@@ -372,12 +425,20 @@ type BackendConfig struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	MasterServiceID *int32 `json:"masterServiceID,omitempty"`
-	// Redis Storage DSN
+	// Redis Storage DSN. Required unless sentinelRef is set.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	RedisStorageDSN string `json:"redisStorageDSN"`
-	// Redis Queues DSN
+	// +optional
+	RedisStorageDSN string `json:"redisStorageDSN,omitempty"`
+	// Redis Queues DSN. Required unless sentinelRef is set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RedisQueuesDSN string `json:"redisQueuesDSN,omitempty"`
+	// SentinelRef configures the storage and queues redis connections using
+	// the sentinel endpoints and the master names resolved from the status of
+	// a Sentinel resource. Takes precedence over redisStorageDSN and redisQueuesDSN.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	RedisQueuesDSN string `json:"redisQueuesDSN"`
+	// +optional
+	SentinelRef *BackendSentinelReference `json:"sentinelRef,omitempty"`
 	// External Secret common configuration
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -404,6 +465,50 @@ type BackendConfig struct {
 	ErrorMonitoringKey *SecretReference `json:"errorMonitoringKey,omitempty"`
 }
 
+// Validate checks that either the redis DSNs or the sentinelRef are set
+func (cfg *BackendConfig) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if cfg.SentinelRef != nil {
+		return errs
+	}
+	if cfg.RedisStorageDSN == "" {
+		errs = append(errs, field.Required(fldPath.Child("redisStorageDSN"), "required unless sentinelRef is set"))
+	}
+	if cfg.RedisQueuesDSN == "" {
+		errs = append(errs, field.Required(fldPath.Child("redisQueuesDSN"), "required unless sentinelRef is set"))
+	}
+	return errs
+}
+
+// BackendSentinelReference is a reference to the redis shards, monitored
+// by a Sentinel resource, that Backend uses for storage and queues
+type BackendSentinelReference struct {
+	SentinelReference `json:",inline"`
+	// StorageShard is the name of the shard used for storage,
+	// as monitored by the Sentinel resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StorageShard string `json:"storageShard"`
+	// QueuesShard is the name of the shard used for queues,
+	// as monitored by the Sentinel resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	QueuesShard string `json:"queuesShard"`
+}
+
+// Validate checks that the required fields of the BackendSentinelReference are set
+func (ref *BackendSentinelReference) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if ref.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("name"), ""))
+	}
+	if ref.StorageShard == "" {
+		errs = append(errs, field.Required(fldPath.Child("storageShard"), ""))
+	}
+	if ref.QueuesShard == "" {
+		errs = append(errs, field.Required(fldPath.Child("queuesShard"), ""))
+	}
+	return errs
+}
+
 // Default sets default values for any value not specifically set in the BackendConfig struct
 func (cfg *BackendConfig) Default() {
 	cfg.RackEnv = stringOrDefault(cfg.RackEnv, util.Pointer(backendDefaultConfigRackEnv))
@@ -461,10 +566,18 @@ func (cfg *WorkerConfig) Default() {
 }
 
 // BackendStatus defines the observed state of Backend
-type BackendStatus struct{}
+type BackendStatus struct {
+	WorkloadsStatus `json:",inline"`
+}
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Ready")].status`,name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Progressing")].status`,name=Progressing,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,name=Degraded,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.workloads[0].deployment.image`,name=Image,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name=Age,type=date
 
 // Backend is the Schema for the backends API
 type Backend struct {
@@ -480,6 +593,61 @@ func (b *Backend) Default() {
 	b.Spec.Default()
 }
 
+// GetWorkloadsStatus returns the status of the workloads of the Backend resource
+func (b *Backend) GetWorkloadsStatus() *WorkloadsStatus {
+	return &b.Status.WorkloadsStatus
+}
+
+// CanarySpecDiff returns the changes that the given canary
+// applies to the spec, as a JSON merge patch
+func (b *Backend) CanarySpecDiff(canary *Canary) (string, error) {
+	canarySpec, err := b.Spec.ResolveCanarySpec(canary)
+	if err != nil {
+		return "", err
+	}
+	return canarySpecDiff(b.Spec, canarySpec)
+}
+
+// SentinelKeys returns the keys of the Sentinel resources referenced
+// by the Backend, including the ones referenced by its canaries
+func (b *Backend) SentinelKeys() []types.NamespacedName {
+	specs := []*BackendSpec{&b.Spec}
+	for _, canary := range []*Canary{b.Spec.Listener.Canary, b.workerCanary()} {
+		if canary == nil {
+			continue
+		}
+		if canarySpec, err := b.Spec.ResolveCanarySpec(canary); err == nil {
+			specs = append(specs, canarySpec)
+		}
+	}
+
+	keys := []types.NamespacedName{}
+	for _, spec := range specs {
+		if spec.Config.SentinelRef != nil {
+			keys = appendKeyIfMissing(keys, spec.Config.SentinelRef.ObjectKey(b.GetNamespace()))
+		}
+	}
+	return keys
+}
+
+// UsesSentinel returns true if the Sentinel resource identified by the
+// given key is used to resolve the redis endpoints of the Backend
+func (b *Backend) UsesSentinel(key types.NamespacedName) bool {
+	for _, k := range b.SentinelKeys() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Backend) workerCanary() *Canary {
+	if b.Spec.Worker == nil {
+		return nil
+	}
+	return b.Spec.Worker.Canary
+}
+
 // +kubebuilder:object:root=true
 
 // BackendList contains a list of Backend
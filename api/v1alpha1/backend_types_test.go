@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestBackendConfig_Validate(t *testing.T) {
+	config := func(cfg BackendConfig) BackendConfig {
+		secret := SecretReference{Override: util.Pointer("value")}
+		cfg.SystemEventsHookURL, cfg.SystemEventsHookPassword = secret, secret
+		cfg.InternalAPIUser, cfg.InternalAPIPassword = secret, secret
+		return cfg
+	}
+	tests := []struct {
+		name string
+		cfg  BackendConfig
+		want []string
+	}{
+		{
+			name: "Valid with redis DSNs",
+			cfg:  config(BackendConfig{RedisStorageDSN: "redis://storage:6379", RedisQueuesDSN: "redis://queues:6379"}),
+			want: []string{},
+		},
+		{
+			name: "Valid with sentinelRef",
+			cfg: config(BackendConfig{SentinelRef: &BackendSentinelReference{
+				SentinelReference: SentinelReference{Name: "sentinel"},
+				StorageShard:      "storage",
+				QueuesShard:       "queues",
+			}}),
+			want: []string{},
+		},
+		{
+			name: "Requires the redis DSNs if sentinelRef is not set",
+			cfg:  config(BackendConfig{RedisStorageDSN: "redis://storage:6379"}),
+			want: []string{"spec.config.redisQueuesDSN"},
+		},
+		{
+			name: "Requires the sentinelRef shards",
+			cfg:  config(BackendConfig{SentinelRef: &BackendSentinelReference{SentinelReference: SentinelReference{Name: "sentinel"}}}),
+			want: []string{"spec.config.sentinelRef.storageShard", "spec.config.sentinelRef.queuesShard"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateSpec(&tt.cfg, field.NewPath("spec").Child("config"))
+			got := make([]string, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateSpec() = %v, want errors in fields %v", errs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of Backend with the manager
+func (b *Backend) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(b).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-backend,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=backends,verbs=create;update,versions=v1alpha1,name=mbackend.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Backend{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-backend,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=backends,verbs=create;update,versions=v1alpha1,name=vbackend.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Backend{}
+
+// ValidateCreate implements webhook.Validator
+func (b *Backend) ValidateCreate() error {
+	return b.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (b *Backend) ValidateUpdate(old runtime.Object) error {
+	return b.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (b *Backend) ValidateDelete() error {
+	return nil
+}
+
+func (b *Backend) validate() error {
+	o := b.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	errs = append(errs, validateCanaries(&o.Spec, o.Spec.ResolveCanarySpec,
+		canaryField{field.NewPath("spec", "listener", "canary"), o.Spec.Listener.Canary},
+		canaryField{field.NewPath("spec", "worker", "canary"), o.Spec.Worker.Canary},
+	)...)
+	return invalid("Backend", b.GetName(), errs)
+}
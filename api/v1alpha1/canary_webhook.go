@@ -0,0 +1,50 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// canaryField is a Canary field within a custom resource
+type canaryField struct {
+	path   *field.Path
+	canary *Canary
+}
+
+// validateCanaries checks that the patches of each of the given canaries can be applied
+// to the spec and that the resulting spec is valid for the spec type and passes the same
+// validations as the spec. The spec must be defaulted, as ResolveCanarySpec expects it to be.
+func validateCanaries[T any](spec *T, resolve func(*Canary) (*T, error), canaries ...canaryField) field.ErrorList {
+	errs := field.ErrorList{}
+
+	// errors already present in the spec are not
+	// reported again for each of the canaries
+	existing := map[string]bool{}
+	for _, err := range validateSpec(spec, field.NewPath("spec")) {
+		existing[err.Error()] = true
+	}
+
+	for _, cf := range canaries {
+		if cf.canary == nil {
+			continue
+		}
+		if err := cf.canary.ValidatePatches(spec, new(T)); err != nil {
+			errs = append(errs, field.Invalid(cf.path.Child("patches"), cf.canary.Patches, err.Error()))
+			continue
+		}
+		resolved, err := resolve(cf.canary)
+		if err != nil {
+			errs = append(errs, field.Invalid(cf.path, cf.canary, err.Error()))
+			continue
+		}
+		for _, err := range validateSpec(resolved, field.NewPath("spec")) {
+			if !existing[err.Error()] {
+				errs = append(errs, field.Invalid(cf.path.Child("patches"), cf.canary.Patches,
+					fmt.Sprintf("invalid canary spec: %s", err.Error())))
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBackend_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  *DeploymentStrategySpec
+		listener  *Canary
+		worker    *Canary
+		wantErr   bool
+		wantField string
+	}{
+		{
+			name:     "No canaries",
+			listener: nil,
+			worker:   nil,
+			wantErr:  false,
+		},
+		{
+			name: "Valid canary patches",
+			listener: &Canary{
+				Patches: []string{`[{"op": "replace", "path": "/listener/config/logFormat", "value": "text"}]`},
+			},
+			worker:  &Canary{ImageTag: util.Pointer("canary")},
+			wantErr: false,
+		},
+		{
+			name: "Patch cannot be applied",
+			listener: &Canary{
+				Patches: []string{`[{"op": "replace", "path": "/listener/unknown/field", "value": "json"}]`},
+			},
+			wantErr:   true,
+			wantField: "spec.listener.canary.patches",
+		},
+		{
+			name: "Patch produces an invalid spec",
+			worker: &Canary{
+				Patches: []string{`[{"op": "replace", "path": "/worker/replicas", "value": "two"}]`},
+			},
+			wantErr:   true,
+			wantField: "spec.worker.canary.patches",
+		},
+		{
+			name: "Patch produces a spec that fails validation",
+			listener: &Canary{
+				Patches: []string{`[{"op": "replace", "path": "/config/redisStorageDSN", "value": ""}]`},
+			},
+			wantErr:   true,
+			wantField: "spec.listener.canary.patches",
+		},
+		{
+			name:      "Weighted canary with the blue/green strategy",
+			strategy:  &DeploymentStrategySpec{Type: BlueGreenDeploymentStrategyType},
+			listener:  &Canary{Weight: util.Pointer[int32](10)},
+			wantErr:   true,
+			wantField: "spec.listener.canary",
+		},
+		{
+			name:     "Unweighted canary with the blue/green strategy",
+			strategy: &DeploymentStrategySpec{Type: BlueGreenDeploymentStrategyType},
+			listener: &Canary{ImageTag: util.Pointer("canary")},
+			wantErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: BackendSpec{
+					Config: BackendConfig{
+						RedisStorageDSN:          "storageDSN",
+						RedisQueuesDSN:           "queuesDSN",
+						SystemEventsHookURL:      SecretReference{Override: util.Pointer("override")},
+						SystemEventsHookPassword: SecretReference{Override: util.Pointer("override")},
+						InternalAPIUser:          SecretReference{Override: util.Pointer("override")},
+						InternalAPIPassword:      SecretReference{Override: util.Pointer("override")},
+					},
+					Listener: ListenerSpec{DeploymentStrategy: tt.strategy, Canary: tt.listener},
+					Worker:   &WorkerSpec{Canary: tt.worker},
+				},
+			}
+			err := b.ValidateCreate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Backend.ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				return
+			}
+			if !apierrors.IsInvalid(err) {
+				t.Errorf("Backend.ValidateCreate() error = %v, want an Invalid error", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantField) {
+				t.Errorf("Backend.ValidateCreate() error = %v, want error in field %s", err, tt.wantField)
+			}
+		})
+	}
+}
@@ -17,18 +17,23 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/3scale-ops/basereconciler/util"
 	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/robfig/cron/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const (
@@ -325,6 +330,27 @@ type Endpoint struct {
 	DNS []string `json:"dns"`
 }
 
+// StatefulSetRolloutSpec configures partitioned rolling updates of a StatefulSet
+type StatefulSetRolloutSpec struct {
+	// Partition is the ordinal from which pods are updated when the StatefulSet
+	// changes. Pods with an ordinal lower than the partition keep running the
+	// previous revision. Setting the partition to the number of replicas minus one
+	// rolls out changes to a single pod and holds the rest until the partition is
+	// lowered. Defaults to 0, which updates all the pods.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Partition *int32 `json:"partition,omitempty"`
+}
+
+// GetPartition returns the partition for the rolling updates
+func (spec *StatefulSetRolloutSpec) GetPartition() int32 {
+	if spec == nil || spec.Partition == nil {
+		return 0
+	}
+	return *spec.Partition
+}
+
 // PodDisruptionBudgetSpec defines the PDB for the component
 type PodDisruptionBudgetSpec struct {
 	// An eviction is allowed if at least "minAvailable" pods selected by
@@ -365,6 +391,16 @@ func (spec *PodDisruptionBudgetSpec) IsDeactivated() bool {
 	return reflect.DeepEqual(spec, &PodDisruptionBudgetSpec{})
 }
 
+// Validate checks that only one of MinAvailable and MaxUnavailable is set
+func (spec *PodDisruptionBudgetSpec) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if spec.MinAvailable != nil && spec.MaxUnavailable != nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("maxUnavailable"),
+			"minAvailable and maxUnavailable cannot be both set"))
+	}
+	return errs
+}
+
 // InitializePodDisruptionBudgetSpec initializes a PodDisruptionBudgetSpec struct
 func InitializePodDisruptionBudgetSpec(spec *PodDisruptionBudgetSpec, def defaultPodDisruptionBudgetSpec) *PodDisruptionBudgetSpec {
 	if spec == nil {
@@ -395,20 +431,83 @@ type HorizontalPodAutoscalerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
-	// Target resource used to autoscale (cpu/memory)
+	// Target resource used to autoscale (cpu/memory). Set it to "none" to autoscale
+	// only on the custom and external metrics
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	// +kubebuilder:validation:Enum=cpu;memory
+	// +kubebuilder:validation:Enum=cpu;memory;none
 	// +optional
 	ResourceName *string `json:"resourceName,omitempty"`
 	// A percentage indicating the target resource consumption used to autoscale
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	ResourceUtilization *int32 `json:"resourceUtilization,omitempty"`
+	// Metrics is a list of additional metrics used to autoscale. Only metrics of
+	// type Pods, Object or External are supported, as resource metrics are configured
+	// with resourceName and resourceUtilization. The autoscaler computes the desired
+	// replicas for each of the metrics and uses the highest one.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+	// SuggestedMetrics adds the metrics suggested for the component to the metrics
+	// of the generated autoscaler. Configured metrics with the same type and name
+	// take precedence over the suggested ones. The suggested metrics need to be
+	// exposed through the custom or external metrics APIs (eg using prometheus-adapter).
+	// Only the Backend worker and the System sidekiq components have suggested metrics.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SuggestedMetrics *bool `json:"suggestedMetrics,omitempty"`
 	// Behavior configures the scaling behavior of the target
 	// in both Up and Down directions (scaleUp and scaleDown fields respectively).
 	// If not set, the default HPAScalingRules for scale up and scale down are used.
 	// +optional
 	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+	// Schedules is a list of scaling profiles that override the replica limits of the
+	// autoscaler during periods of time. If several profiles are active at the same time,
+	// the first one in the list is used.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Schedules []HorizontalPodAutoscalerScheduleSpec `json:"schedules,omitempty"`
+}
+
+// HorizontalPodAutoscalerNoResource is the value of resourceName
+// that disables autoscaling on the resource consumption
+const HorizontalPodAutoscalerNoResource string = "none"
+
+// HorizontalPodAutoscalerScheduleSpec is a scaling profile that is
+// activated periodically, following a cron schedule
+type HorizontalPodAutoscalerScheduleSpec struct {
+	// The name of the profile
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// The schedule in cron format at which the profile is activated. The time
+	// zone can be set using the "CRON_TZ=" prefix (eg "CRON_TZ=Europe/Madrid 0 8 * * 1-5")
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Schedule string `json:"schedule"`
+	// The time the profile stays active each time it is activated
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+	// Overrides the minReplicas of the autoscaler while the profile is active
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// Overrides the maxReplicas of the autoscaler while the profile is active
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
+
+// activeUntil returns the time at which the profile stops being active and
+// true if the profile is active at the given time
+func (sched *HorizontalPodAutoscalerScheduleSpec) activeUntil(now time.Time) (time.Time, bool) {
+	cs, err := cron.ParseStandard(sched.Schedule)
+	if err != nil {
+		return time.Time{}, false
+	}
+	var until time.Time
+	for next := cs.Next(now.Add(-sched.Duration.Duration)); !next.After(now); next = cs.Next(next) {
+		until = next.Add(sched.Duration.Duration)
+	}
+	return until, !until.IsZero()
 }
 
 type defaultHorizontalPodAutoscalerSpec struct {
@@ -424,11 +523,121 @@ func (spec *HorizontalPodAutoscalerSpec) Default(def defaultHorizontalPodAutosca
 	spec.ResourceUtilization = intOrDefault(spec.ResourceUtilization, def.ResourceUtilization)
 }
 
+// HasResourceMetric returns true if the autoscaler scales on the consumption of
+// a resource, in addition to the custom and external metrics
+func (spec *HorizontalPodAutoscalerSpec) HasResourceMetric() bool {
+	return spec.ResourceName != nil && *spec.ResourceName != HorizontalPodAutoscalerNoResource
+}
+
 // IsDeactivated true if the field is set with the deactivated value (empty struct)
 func (spec *HorizontalPodAutoscalerSpec) IsDeactivated() bool {
 	return reflect.DeepEqual(spec, &HorizontalPodAutoscalerSpec{})
 }
 
+// Validate checks that MinReplicas is not greater than MaxReplicas, also when the
+// overrides of the scheduled profiles are applied, and that the metrics are supported.
+// At least one metric is required if the resource metric is disabled.
+func (spec *HorizontalPodAutoscalerSpec) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if spec.MinReplicas != nil && spec.MaxReplicas != nil && *spec.MinReplicas > *spec.MaxReplicas {
+		errs = append(errs, field.Invalid(fldPath.Child("minReplicas"), *spec.MinReplicas,
+			"must be less than or equal to maxReplicas"))
+	}
+
+	if spec.ResourceName != nil && *spec.ResourceName == HorizontalPodAutoscalerNoResource &&
+		len(spec.Metrics) == 0 && (spec.SuggestedMetrics == nil || !*spec.SuggestedMetrics) {
+		errs = append(errs, field.Required(fldPath.Child("metrics"),
+			"at least one metric is required when resourceName is \"none\""))
+	}
+
+	for idx, metric := range spec.Metrics {
+		metricPath := fldPath.Child("metrics").Index(idx)
+		var missing bool
+		switch metric.Type {
+		case autoscalingv2.PodsMetricSourceType:
+			missing = metric.Pods == nil
+		case autoscalingv2.ObjectMetricSourceType:
+			missing = metric.Object == nil
+		case autoscalingv2.ExternalMetricSourceType:
+			missing = metric.External == nil
+		default:
+			errs = append(errs, field.NotSupported(metricPath.Child("type"), metric.Type,
+				[]string{string(autoscalingv2.PodsMetricSourceType), string(autoscalingv2.ObjectMetricSourceType),
+					string(autoscalingv2.ExternalMetricSourceType)}))
+			continue
+		}
+		if missing {
+			errs = append(errs, field.Required(metricPath, fmt.Sprintf("the %s metric source must be set", metric.Type)))
+		}
+	}
+
+	names := map[string]bool{}
+	for idx, sched := range spec.Schedules {
+		schedPath := fldPath.Child("schedules").Index(idx)
+		if names[sched.Name] {
+			errs = append(errs, field.Duplicate(schedPath.Child("name"), sched.Name))
+		}
+		names[sched.Name] = true
+		if _, err := cron.ParseStandard(sched.Schedule); err != nil {
+			errs = append(errs, field.Invalid(schedPath.Child("schedule"), sched.Schedule, err.Error()))
+		}
+		if sched.Duration.Duration <= 0 {
+			errs = append(errs, field.Invalid(schedPath.Child("duration"), sched.Duration.String(),
+				"must be greater than zero"))
+		}
+		min, max := intOrDefault(sched.MinReplicas, spec.MinReplicas), intOrDefault(sched.MaxReplicas, spec.MaxReplicas)
+		if min != nil && max != nil && *min > *max {
+			errs = append(errs, field.Invalid(schedPath.Child("minReplicas"), *min,
+				"must be less than or equal to maxReplicas"))
+		}
+	}
+	return errs
+}
+
+// ActiveSchedule returns the scaling profile that is active at the
+// given time, or nil if there is none
+func (spec *HorizontalPodAutoscalerSpec) ActiveSchedule(now time.Time) *HorizontalPodAutoscalerScheduleSpec {
+	for idx := range spec.Schedules {
+		if _, active := spec.Schedules[idx].activeUntil(now); active {
+			return &spec.Schedules[idx]
+		}
+	}
+	return nil
+}
+
+// Scheduled returns a copy of the HorizontalPodAutoscalerSpec with the replica
+// overrides of the scaling profile that is active at the given time applied
+func (spec *HorizontalPodAutoscalerSpec) Scheduled(now time.Time) *HorizontalPodAutoscalerSpec {
+	scheduled := spec.DeepCopy()
+	if sched := spec.ActiveSchedule(now); sched != nil {
+		scheduled.MinReplicas = intOrDefault(sched.MinReplicas, spec.MinReplicas)
+		scheduled.MaxReplicas = intOrDefault(sched.MaxReplicas, spec.MaxReplicas)
+	}
+	return scheduled
+}
+
+// NextScheduleChange returns the time until the next activation or expiration
+// of the scaling profiles. Returns zero if there are no scaling profiles.
+func (spec *HorizontalPodAutoscalerSpec) NextScheduleChange(now time.Time) time.Duration {
+	var next time.Duration
+	for _, sched := range spec.Schedules {
+		cs, err := cron.ParseStandard(sched.Schedule)
+		if err != nil {
+			continue
+		}
+		changes := []time.Time{cs.Next(now)}
+		if until, active := sched.activeUntil(now); active {
+			changes = append(changes, until)
+		}
+		for _, change := range changes {
+			if d := change.Sub(now); d > 0 && (next == 0 || d < next) {
+				next = d
+			}
+		}
+	}
+	return next
+}
+
 // InitializeHorizontalPodAutoscalerSpec initializes a HorizontalPodAutoscalerSpec struct
 func InitializeHorizontalPodAutoscalerSpec(spec *HorizontalPodAutoscalerSpec, def defaultHorizontalPodAutoscalerSpec) *HorizontalPodAutoscalerSpec {
 	if spec == nil {
@@ -444,14 +653,62 @@ func InitializeHorizontalPodAutoscalerSpec(spec *HorizontalPodAutoscalerSpec, de
 	return spec
 }
 
+const (
+	// BlueGreenDeploymentStrategyType keeps two complete Deployments (colors) of the workload
+	// and switches traffic from one to the other once the new color is fully rolled out
+	BlueGreenDeploymentStrategyType appsv1.DeploymentStrategyType = "BlueGreen"
+)
+
 type DeploymentStrategySpec struct {
-	// Type of deployment. Can be "Recreate" or "RollingUpdate". Default is RollingUpdate.
+	// Type of deployment. Can be "Recreate", "RollingUpdate" or "BlueGreen". Default is RollingUpdate.
 	// +optional
 	Type appsv1.DeploymentStrategyType `json:"type,omitempty"`
 	// Rolling update config params. Present only if DeploymentStrategyType =
-	// RollingUpdate.
+	// RollingUpdate. When DeploymentStrategyType = BlueGreen, these params are
+	// used to roll out each of the colors.
 	// +optional
 	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+	// Blue/green config params. Only used if DeploymentStrategyType = BlueGreen.
+	// +optional
+	BlueGreen *BlueGreenStrategySpec `json:"blueGreen,omitempty"`
+}
+
+// BlueGreenStrategySpec configures the blue/green deployment strategy. The Services
+// of a blue/green workload only send traffic to the active color, so canaries do
+// not receive traffic through them.
+type BlueGreenStrategySpec struct {
+	// The time the previous color is kept running after traffic has been switched
+	// to the new color, so traffic can be switched back to it immediately by reverting
+	// the changes in the spec. Once the rollback window expires, the previous color is
+	// scaled down to zero replicas. Defaults to 1h.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RollbackWindow *metav1.Duration `json:"rollbackWindow,omitempty"`
+}
+
+// IsBlueGreen returns true if the blue/green strategy is configured
+func (spec *DeploymentStrategySpec) IsBlueGreen() bool {
+	return spec != nil && spec.Type == BlueGreenDeploymentStrategyType
+}
+
+// GetRollbackWindow returns the rollback window of the blue/green strategy
+func (spec *DeploymentStrategySpec) GetRollbackWindow() time.Duration {
+	if spec == nil || spec.BlueGreen == nil || spec.BlueGreen.RollbackWindow == nil {
+		return time.Hour
+	}
+	return spec.BlueGreen.RollbackWindow.Duration
+}
+
+// DeploymentStrategy returns the strategy of the Deployments. When the blue/green
+// strategy is configured, each color is rolled out using a rolling update.
+func (spec *DeploymentStrategySpec) DeploymentStrategy() appsv1.DeploymentStrategy {
+	if spec.IsBlueGreen() {
+		return appsv1.DeploymentStrategy{
+			Type:          appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: spec.RollingUpdate,
+		}
+	}
+	return appsv1.DeploymentStrategy{Type: spec.Type, RollingUpdate: spec.RollingUpdate}
 }
 
 type defaultDeploymentRollingStrategySpec struct {
@@ -540,6 +797,78 @@ func InitializeResourceRequirementsSpec(spec *ResourceRequirementsSpec, def defa
 	return spec
 }
 
+const (
+	// DatabaseMigrationRetryAnnotation is the annotation of the custom resource that
+	// is used to retry a failed database migration. Each time its value changes a new
+	// migration Job is created.
+	DatabaseMigrationRetryAnnotation string = AnnotationsDomain + "/database-migration-retry"
+)
+
+// DatabaseMigrationSpec configures the Job that runs the database migrations of
+// a component. When enabled, the Deployments of the component are not rolled out
+// to a new image until the migrations have successfully run using that image.
+// A failed migration blocks the rollout until it is retried, by setting the
+// 'saas.3scale.net/database-migration-retry' annotation of the custom resource
+// to a new value, or until the image is changed.
+type DatabaseMigrationSpec struct {
+	// Enables the database migration Job. Defaults to false.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The command that runs the database migrations
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// The number of retries before the migration is considered failed. Defaults to 0.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// The maximum duration of the migration, in seconds. Defaults to 1800.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// Resource requirements for the migration Job
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Resources *ResourceRequirementsSpec `json:"resources,omitempty"`
+}
+
+type defaultDatabaseMigrationSpec struct {
+	Enabled               *bool
+	Command               []string
+	BackoffLimit          *int32
+	ActiveDeadlineSeconds *int64
+	Resources             defaultResourceRequirementsSpec
+}
+
+// Default sets default values for any value not specifically set in the DatabaseMigrationSpec struct
+func (spec *DatabaseMigrationSpec) Default(def defaultDatabaseMigrationSpec) {
+	spec.Enabled = boolOrDefault(spec.Enabled, def.Enabled)
+	spec.Command = stringSliceOrDefault(spec.Command, def.Command)
+	spec.BackoffLimit = intOrDefault(spec.BackoffLimit, def.BackoffLimit)
+	spec.ActiveDeadlineSeconds = int64OrDefault(spec.ActiveDeadlineSeconds, def.ActiveDeadlineSeconds)
+	spec.Resources = InitializeResourceRequirementsSpec(spec.Resources, def.Resources)
+}
+
+// IsEnabled returns true if the database migration Job is enabled
+func (spec *DatabaseMigrationSpec) IsEnabled() bool {
+	return spec != nil && spec.Enabled != nil && *spec.Enabled
+}
+
+// InitializeDatabaseMigrationSpec initializes a DatabaseMigrationSpec struct
+func InitializeDatabaseMigrationSpec(spec *DatabaseMigrationSpec, def defaultDatabaseMigrationSpec) *DatabaseMigrationSpec {
+	if spec == nil {
+		new := &DatabaseMigrationSpec{}
+		new.Default(def)
+		return new
+	}
+	copy := spec.DeepCopy()
+	copy.Default(def)
+	return copy
+}
+
 // ExternalSecret is a reference to the ExternalSecret common configuration
 type ExternalSecret struct {
 	// SecretStoreRef defines which SecretStore to use when fetching the secret data
@@ -550,11 +879,63 @@ type ExternalSecret struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// RolloutOnChange triggers a rollout of the pods of the component whenever
+	// the data of any of the Secrets they read environment variables from
+	// changes. Defaults to true.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RolloutOnChange *bool `json:"rolloutOnChange,omitempty"`
 }
 
 func (spec *ExternalSecret) Default() {
 	spec.SecretStoreRef = InitializeExternalSecretSecretStoreReferenceSpec(spec.SecretStoreRef, defaultExternalSecretSecretStoreReference)
 	spec.RefreshInterval = durationOrDefault(spec.RefreshInterval, &defaultExternalSecretRefreshInterval)
+	spec.RolloutOnChange = boolOrDefault(spec.RolloutOnChange, util.Pointer(true))
+}
+
+// RolloutOnChangeEnabled returns true if the pods should be rolled out
+// when the data of the Secrets they read from changes
+func (spec *ExternalSecret) RolloutOnChangeEnabled() bool {
+	return spec.RolloutOnChange == nil || *spec.RolloutOnChange
+}
+
+// EnvironmentSpec allows to customize the environment
+// variables of the containers of a workload
+type EnvironmentSpec struct {
+	// ExtraEnv is a list of additional environment variables, appended after the ones
+	// generated by the operator so they can reference them using the $(VAR_NAME) syntax.
+	// Variables already generated by the operator are ignored, use envOverrides to
+	// change their values.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+	// EnvOverrides is a list of environment variables that replace the ones generated by
+	// the operator with the same name. Variables not generated by the operator are appended.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	EnvOverrides []corev1.EnvVar `json:"envOverrides,omitempty"`
+}
+
+// Validate checks that environment variables are not
+// repeated within or across the extraEnv and envOverrides lists
+func (spec *EnvironmentSpec) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	names := map[string]bool{}
+	for _, list := range []struct {
+		path *field.Path
+		vars []corev1.EnvVar
+	}{
+		{fldPath.Child("extraEnv"), spec.ExtraEnv},
+		{fldPath.Child("envOverrides"), spec.EnvOverrides},
+	} {
+		for i, envvar := range list.vars {
+			if names[envvar.Name] {
+				errs = append(errs, field.Duplicate(list.path.Index(i).Child("name"), envvar.Name))
+			}
+			names[envvar.Name] = true
+		}
+	}
+	return errs
 }
 
 // SecretReference is a reference to a secret stored in some secrets engine
@@ -563,10 +944,89 @@ type SecretReference struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	FromVault *VaultSecretReference `json:"fromVault,omitempty"`
+	// FromKubernetesSecret is a reference to a key of a Kubernetes Secret in the
+	// same Namespace. No ExternalSecret is generated for these references.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	FromKubernetesSecret *KubernetesSecretReference `json:"fromKubernetesSecret,omitempty"`
+	// FromAWSSecretsManager is a reference to a secret stored in AWS Secrets Manager
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	FromAWSSecretsManager *AWSSecretsManagerSecretReference `json:"fromAWSSecretsManager,omitempty"`
+	// FromExternalSecretsProvider is a reference to a secret stored in any of the
+	// providers supported by the external-secrets operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	FromExternalSecretsProvider *ExternalSecretsProviderSecretReference `json:"fromExternalSecretsProvider,omitempty"`
 	// Override allows to directly specify a string value.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Override *string `json:"override,omitempty"`
+	// MountAsFile delivers the secret value as a file in a read-only volume instead
+	// of as an environment variable. The path of the file is exposed in an environment
+	// variable with the same name and the "_FILE" suffix. Cannot be used with override.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MountAsFile *bool `json:"mountAsFile,omitempty"`
+}
+
+// IsMountedAsFile returns true if the secret value
+// is delivered as a file
+func (spec *SecretReference) IsMountedAsFile() bool {
+	return spec.MountAsFile != nil && *spec.MountAsFile && spec.Override == nil
+}
+
+// Validate checks that the SecretReference points to exactly one source
+func (spec *SecretReference) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	sources := []string{}
+	for _, src := range []struct {
+		name string
+		set  bool
+	}{
+		{"fromVault", spec.FromVault != nil},
+		{"fromKubernetesSecret", spec.FromKubernetesSecret != nil},
+		{"fromAWSSecretsManager", spec.FromAWSSecretsManager != nil},
+		{"fromExternalSecretsProvider", spec.FromExternalSecretsProvider != nil},
+		{"override", spec.Override != nil},
+	} {
+		if src.set {
+			sources = append(sources, src.name)
+		}
+	}
+
+	switch {
+	case len(sources) == 0:
+		return append(errs, field.Required(fldPath, "one of fromVault, fromKubernetesSecret, "+
+			"fromAWSSecretsManager, fromExternalSecretsProvider or override must be set"))
+	case len(sources) > 1:
+		return append(errs, field.Forbidden(fldPath, fmt.Sprintf("only one source can be set, got %s",
+			strings.Join(sources, ", "))))
+	}
+
+	required := func(path *field.Path, value string) {
+		if value == "" {
+			errs = append(errs, field.Required(path, ""))
+		}
+	}
+	switch {
+	case spec.FromVault != nil:
+		required(fldPath.Child("fromVault", "path"), spec.FromVault.Path)
+		required(fldPath.Child("fromVault", "key"), spec.FromVault.Key)
+	case spec.FromKubernetesSecret != nil:
+		required(fldPath.Child("fromKubernetesSecret", "name"), spec.FromKubernetesSecret.Name)
+		required(fldPath.Child("fromKubernetesSecret", "key"), spec.FromKubernetesSecret.Key)
+	case spec.FromAWSSecretsManager != nil:
+		required(fldPath.Child("fromAWSSecretsManager", "name"), spec.FromAWSSecretsManager.Name)
+	case spec.FromExternalSecretsProvider != nil:
+		required(fldPath.Child("fromExternalSecretsProvider", "key"), spec.FromExternalSecretsProvider.Key)
+	case spec.Override != nil:
+		if spec.MountAsFile != nil && *spec.MountAsFile {
+			errs = append(errs, field.Forbidden(fldPath.Child("mountAsFile"), "cannot be used with override"))
+		}
+	}
+	return errs
 }
 
 // VaultSecretReference is a reference to a secret stored in
@@ -583,6 +1043,59 @@ type VaultSecretReference struct {
 func (spec *VaultSecretReference) Default() {
 }
 
+// KubernetesSecretReference is a reference to a key of a Kubernetes Secret
+type KubernetesSecretReference struct {
+	// The name of the Secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// The key of the Secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key"`
+}
+
+// AWSSecretsManagerSecretReference is a reference to a secret
+// stored in AWS Secrets Manager
+type AWSSecretsManagerSecretReference struct {
+	// The name or ARN of the secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// The key within the JSON value of the secret. The whole
+	// value of the secret is used if not set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Key string `json:"key,omitempty"`
+	// The version stage or id of the secret. Defaults to AWSCURRENT.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Version string `json:"version,omitempty"`
+	// SecretStoreRef is the secret store, configured with the AWS Secrets Manager
+	// provider, used to fetch the secret. Defaults to the secret store of the component.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SecretStoreRef *ExternalSecretSecretStoreReferenceSpec `json:"secretStoreRef,omitempty"`
+}
+
+// ExternalSecretsProviderSecretReference is a reference to a secret stored
+// in any of the providers supported by the external-secrets operator
+type ExternalSecretsProviderSecretReference struct {
+	// The key of the secret in the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key"`
+	// The property of the secret to use, if the provider supports it
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Property string `json:"property,omitempty"`
+	// The version of the secret, if the provider supports it
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Version string `json:"version,omitempty"`
+	// SecretStoreRef is the secret store used to fetch the secret.
+	// Defaults to the secret store of the component.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SecretStoreRef *ExternalSecretSecretStoreReferenceSpec `json:"secretStoreRef,omitempty"`
+}
+
 // ExternalSecretSecretStoreReferenceSpec is a reference to a secret store
 type ExternalSecretSecretStoreReferenceSpec struct {
 	// The Vault secret store reference name
@@ -646,6 +1159,37 @@ type AddressSpec struct {
 	Port *int32 `json:"port,omitempty"`
 }
 
+// SentinelReference is a reference to a Sentinel resource
+type SentinelReference struct {
+	// The name of the Sentinel resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// The namespace of the Sentinel resource. Defaults to the
+	// namespace of the resource holding the reference. The namespace
+	// must be one of the namespaces watched by the operator.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// ObjectKey returns the key of the referenced Sentinel resource, using
+// the passed namespace if the reference does not specify one
+func (ref *SentinelReference) ObjectKey(defaultNamespace string) types.NamespacedName {
+	if ref.Namespace != nil && *ref.Namespace != "" {
+		return types.NamespacedName{Name: ref.Name, Namespace: *ref.Namespace}
+	}
+	return types.NamespacedName{Name: ref.Name, Namespace: defaultNamespace}
+}
+
+func appendKeyIfMissing(keys []types.NamespacedName, key types.NamespacedName) []types.NamespacedName {
+	for _, k := range keys {
+		if k == key {
+			return keys
+		}
+	}
+	return append(keys, key)
+}
+
 // Canary allows the definition of a canary Deployment
 type Canary struct {
 	// SendTraffic controls if traffic is sent to the canary
@@ -668,32 +1212,432 @@ type Canary struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// + optional
 	Patches []string `json:"patches,omitempty"`
+	// Weight is the percentage of the traffic that is sent to the canary. When set,
+	// traffic is split between the main and the canary Deployments by the Envoy sidecar,
+	// using the per-Deployment Services generated by the operator, instead of using
+	// the Service selectors. SendTraffic is ignored when weights are in use. Only
+	// supported by workloads with an Envoy sidecar that have a cluster with
+	// 'canaryWeighted' set to true.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+	// Steps progressively shift traffic to the canary. Each step sends the given
+	// percentage of traffic to the canary during the configured duration, starting
+	// from the creation of the canary Deployment. Once all the steps have completed,
+	// Weight is used (or the weight of the last step if Weight is not set).
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Steps []CanaryStep `json:"steps,omitempty"`
+	// Analysis configures the automated analysis of the canary using Prometheus
+	// metrics. If the analysis fails the canary is removed, and if it succeeds the
+	// canary is reported as ready to be promoted in the status of the resource.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Analysis *CanaryAnalysis `json:"analysis,omitempty"`
+}
+
+// CanaryAnalysis configures the automated analysis of a canary
+type CanaryAnalysis struct {
+	// The address of the Prometheus server used to evaluate the metrics
+	// (eg "http://prometheus.monitoring.svc:9090")
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PrometheusAddress string `json:"prometheusAddress"`
+	// How long the canary is analysed before it is considered successful,
+	// starting from the creation of the canary Deployment
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+	// How often the metrics are evaluated. Defaults to 1m.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// The metrics that are evaluated during the analysis. The analysis
+	// fails as soon as any of the metrics is out of its thresholds.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems=1
+	Metrics []CanaryMetric `json:"metrics"`
+}
+
+// GetInterval returns the interval between evaluations of the metrics
+func (ca *CanaryAnalysis) GetInterval() time.Duration {
+	if ca.Interval == nil || ca.Interval.Duration <= 0 {
+		return time.Minute
+	}
+	return ca.Interval.Duration
+}
+
+// CanaryMetric is a metric evaluated during the analysis of a canary
+type CanaryMetric struct {
+	// The name of the metric
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// The PromQL query, which must return a single value. The query is a
+	// Go template where '{{ .Deployment }}' and '{{ .Namespace }}' can be
+	// used to refer to the canary Deployment.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Query string `json:"query"`
+	// The minimum value allowed for the metric
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Min *string `json:"min,omitempty"`
+	// The maximum value allowed for the metric
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Max *string `json:"max,omitempty"`
+}
+
+// CanaryStep is a step of the canary traffic shifting schedule
+type CanaryStep struct {
+	// The percentage of traffic sent to the canary during this step
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+	// The duration of this step
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// IsWeighted returns true if traffic is split between the
+// main and the canary Deployments using weights
+func (c *Canary) IsWeighted() bool {
+	return c != nil && (c.Weight != nil || len(c.Steps) > 0)
+}
+
+// validateCanaryStrategy checks that weighted canaries are not used together with the
+// blue/green deployment strategy, as traffic weights are not supported with it
+func validateCanaryStrategy(strategy *DeploymentStrategySpec, canary *Canary, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if strategy.IsBlueGreen() && canary.IsWeighted() {
+		errs = append(errs, field.Forbidden(fldPath.Child("canary"),
+			"weighted canaries are not supported with the blue/green deployment strategy"))
+	}
+	return errs
+}
+
+// TrafficWeight returns the percentage of traffic that should be sent to the canary
+// given the time elapsed since the canary was created, and the time remaining until
+// the next step of the schedule. The returned remaining time is zero when all the
+// steps have completed.
+func (c *Canary) TrafficWeight(elapsed time.Duration) (int32, time.Duration) {
+	var end time.Duration
+	for _, step := range c.Steps {
+		end += step.Duration.Duration
+		if elapsed < end {
+			return step.Weight, end - elapsed
+		}
+	}
+
+	if c.Weight != nil {
+		return *c.Weight, 0
+	}
+	if len(c.Steps) > 0 {
+		return c.Steps[len(c.Steps)-1].Weight, 0
+	}
+	return 0, 0
 }
 
 // PatchSpec returns a modified spec given the canary configuration
 func (c *Canary) PatchSpec(spec, canarySpec interface{}) error {
+	doc, err := c.patch(spec)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(doc, canarySpec); err != nil {
+		return fmt.Errorf("unable to unmarshal spec: '%s'", err.Error())
+	}
+
+	return nil
+}
+
+// ValidatePatches applies the canary patches to the given spec and checks that the
+// result can be decoded into canarySpec. Unlike PatchSpec, fields that do not exist
+// in the type of canarySpec are reported as an error.
+func (c *Canary) ValidatePatches(spec, canarySpec interface{}) error {
+	doc, err := c.patch(spec)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(canarySpec); err != nil {
+		return fmt.Errorf("invalid canary spec: '%s'", err.Error())
+	}
+
+	return nil
+}
+
+func (c *Canary) patch(spec interface{}) ([]byte, error) {
 	doc, err := json.Marshal(spec)
 	if err != nil {
-		return fmt.Errorf("unable to marshal spec: '%s'", err.Error())
+		return nil, fmt.Errorf("unable to marshal spec: '%s'", err.Error())
 	}
 
 	for _, p := range c.Patches {
 		patch, err := jsonpatch.DecodePatch([]byte(p))
 		if err != nil {
-			return fmt.Errorf("unable to decode canary patch: '%s'", err.Error())
+			return nil, fmt.Errorf("unable to decode canary patch: '%s'", err.Error())
 		}
 
 		doc, err = patch.Apply([]byte(doc))
 		if err != nil {
-			return fmt.Errorf("unable to apply canary patch: '%s'", err.Error())
+			return nil, fmt.Errorf("unable to apply canary patch: '%s'", err.Error())
 		}
 	}
 
-	if err := json.Unmarshal(doc, canarySpec); err != nil {
-		return fmt.Errorf("unable to unmarshal spec: '%s'", err.Error())
+	return doc, nil
+}
+
+// canarySpecDiff returns the differences between a spec and the spec resolved
+// for one of its canaries, as a JSON merge patch (RFC 7386)
+func canarySpecDiff(spec, canarySpec interface{}) (string, error) {
+	original, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal spec: '%s'", err.Error())
 	}
+	modified, err := json.Marshal(canarySpec)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal canary spec: '%s'", err.Error())
+	}
+	diff, err := jsonpatch.CreateMergePatch(original, modified)
+	if err != nil {
+		return "", fmt.Errorf("unable to compute canary spec diff: '%s'", err.Error())
+	}
+	return string(diff), nil
+}
 
-	return nil
+const (
+	// WorkloadReadyCondition is true when all the Deployments of the
+	// resource have been rolled out and all their replicas are ready
+	WorkloadReadyCondition string = "Ready"
+	// WorkloadProgressingCondition is true while any of the Deployments
+	// of the resource is being rolled out
+	WorkloadProgressingCondition string = "Progressing"
+	// WorkloadDegradedCondition is true when the reconcile of the resource
+	// fails or any of its Deployments fails to roll out
+	WorkloadDegradedCondition string = "Degraded"
+)
+
+// WorkloadsStatus is the status of a resource that deploys
+// one or more Deployment based workloads
+type WorkloadsStatus struct {
+	// ObservedGeneration is the most recent generation of the
+	// resource observed by the controller
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions represent the latest observations of the state of the
+	// resource. Known condition types are "Ready", "Progressing" and "Degraded".
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// Workloads is a summary of the status of each of the workloads
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Workloads []WorkloadStatus `json:"workloads,omitempty"`
+	// The status of the analysis of the canaries
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +listType=map
+	// +listMapKey=workload
+	// +optional
+	CanaryAnalyses []CanaryAnalysisStatus `json:"canaryAnalyses,omitempty"`
+}
+
+// CanaryAnalysisPhase is the phase of a canary analysis
+type CanaryAnalysisPhase string
+
+const (
+	// CanaryAnalysisRunning means that the metrics of the canary are being evaluated
+	CanaryAnalysisRunning CanaryAnalysisPhase = "Running"
+	// CanaryAnalysisSucceeded means that the canary can be promoted
+	CanaryAnalysisSucceeded CanaryAnalysisPhase = "Succeeded"
+	// CanaryAnalysisFailed means that the canary has been removed
+	CanaryAnalysisFailed CanaryAnalysisPhase = "Failed"
+)
+
+// CanaryAnalysisStatus is the status of the analysis of a canary
+type CanaryAnalysisStatus struct {
+	// The name of the workload
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Workload string `json:"workload"`
+	// The name of the canary Deployment
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Canary string `json:"canary"`
+	// The hash of the pod template of the canary Deployment under analysis.
+	// The analysis starts again when the pod template of the canary changes.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// The time the analysis started
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	StartTime metav1.Time `json:"startTime"`
+	// The phase of the analysis
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Phase CanaryAnalysisPhase `json:"phase"`
+	// Details about the result of the analysis
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The values of the metrics in the last evaluation
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Metrics []CanaryMetricStatus `json:"metrics,omitempty"`
+	// The time of the last evaluation of the metrics
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastEvaluationTime *metav1.Time `json:"lastEvaluationTime,omitempty"`
+}
+
+// CanaryMetricStatus is the value of a canary metric
+type CanaryMetricStatus struct {
+	// The name of the metric
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// The value of the metric
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Value string `json:"value"`
+}
+
+// DatabaseMigrationPhase is the phase of a database migration
+type DatabaseMigrationPhase string
+
+const (
+	// DatabaseMigrationRunning means that the migration Job is running
+	DatabaseMigrationRunning DatabaseMigrationPhase = "Running"
+	// DatabaseMigrationSucceeded means that the Deployments can be rolled out to the image
+	DatabaseMigrationSucceeded DatabaseMigrationPhase = "Succeeded"
+	// DatabaseMigrationFailed means that the rollout of the image is blocked
+	DatabaseMigrationFailed DatabaseMigrationPhase = "Failed"
+)
+
+// DatabaseMigrationStatus is the status of the database
+// migrations of an image that is pending to be rolled out
+type DatabaseMigrationStatus struct {
+	// The image the migrations are run with
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Image string `json:"image"`
+	// The name of the migration Job
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Job string `json:"job"`
+	// The phase of the migration
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Phase DatabaseMigrationPhase `json:"phase"`
+	// Details about the failure of the migration
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// WorkloadStatus summarizes the status of a workload
+type WorkloadStatus struct {
+	// The name of the workload
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Status of the main Deployment of the workload
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Deployment DeploymentStatus `json:"deployment"`
+	// Status of the canary Deployment of the workload, if any
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Canary *DeploymentStatus `json:"canary,omitempty"`
+	// The percentage of traffic currently sent to the canary, when
+	// traffic is split using weights
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	CanaryWeight *int32 `json:"canaryWeight,omitempty"`
+	// The effective changes that the canary applies to the spec of
+	// the resource, as a JSON merge patch (RFC 7386)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	CanarySpecDiff string `json:"canarySpecDiff,omitempty"`
+	// Status of the blue/green deployment of the workload, when the
+	// blue/green deployment strategy is used. The 'deployment' field
+	// reports the status of the active color.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	BlueGreen *BlueGreenStatus `json:"blueGreen,omitempty"`
+}
+
+// DeploymentColor is one of the colors of a blue/green deployment
+type DeploymentColor string
+
+const (
+	BlueDeploymentColor  DeploymentColor = "blue"
+	GreenDeploymentColor DeploymentColor = "green"
+)
+
+// BlueGreenStatus summarizes the status of a blue/green deployment
+type BlueGreenStatus struct {
+	// The color that currently receives the traffic
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ActiveColor DeploymentColor `json:"activeColor"`
+	// The time at which traffic was switched to the active color
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ActiveSince *metav1.Time `json:"activeSince,omitempty"`
+	// Status of the Deployment of the inactive color
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Inactive *DeploymentStatus `json:"inactive,omitempty"`
+}
+
+// DeploymentStatus summarizes the status of a Deployment
+type DeploymentStatus struct {
+	// The name of the Deployment
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Number of desired replicas
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	// Number of ready replicas
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// Number of replicas running the latest version of the Deployment
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+	// Current number of replicas as reported by the HorizontalPodAutoscaler.
+	// Unset if the Deployment is not autoscaled.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	HPACurrentReplicas *int32 `json:"hpaCurrentReplicas,omitempty"`
+	// The name of the scaling profile of the HorizontalPodAutoscaler
+	// that is currently active
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	HPAActiveSchedule string `json:"hpaActiveSchedule,omitempty"`
+	// The image of the main container of the Deployment
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Image string `json:"image,omitempty"`
+	// The hashes of the Secrets the pods of the Deployment read from,
+	// keyed by Secret name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	SecretHashes map[string]string `json:"secretHashes,omitempty"`
+	// The Secrets whose change caused the last rollout of the Deployment
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastSecretRollout *SecretRolloutStatus `json:"lastSecretRollout,omitempty"`
+}
+
+// SecretRolloutStatus describes a rollout caused by
+// a change in the data of one or more Secrets
+type SecretRolloutStatus struct {
+	// The names of the Secrets that changed
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Secrets []string `json:"secrets"`
+	// The time at which the change was detected
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Time metav1.Time `json:"time"`
 }
 
 func stringOrDefault(value *string, defValue *string) *string {
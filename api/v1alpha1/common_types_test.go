@@ -19,12 +19,16 @@ package v1alpha1
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/3scale-ops/basereconciler/util"
 	"github.com/go-test/deep"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func TestImageSpec_Default(t *testing.T) {
@@ -792,6 +796,29 @@ func TestPodDisruptionBudgetSpec_IsDeactivated(t *testing.T) {
 	}
 }
 
+func TestPodDisruptionBudgetSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *PodDisruptionBudgetSpec
+		wantErr bool
+	}{
+		{"Valid with MinAvailable", &PodDisruptionBudgetSpec{MinAvailable: util.Pointer(intstr.FromInt(1))}, false},
+		{"Valid with MaxUnavailable", &PodDisruptionBudgetSpec{MaxUnavailable: util.Pointer(intstr.FromString("10%"))}, false},
+		{"Valid if deactivated", &PodDisruptionBudgetSpec{}, false},
+		{"Invalid with both", &PodDisruptionBudgetSpec{
+			MinAvailable:   util.Pointer(intstr.FromInt(1)),
+			MaxUnavailable: util.Pointer(intstr.FromInt(1)),
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.Validate(field.NewPath("pdb")); (len(got) > 0) != tt.wantErr {
+				t.Errorf("PodDisruptionBudgetSpec.Validate() = %v, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestInitializePodDisruptionBudgetSpec(t *testing.T) {
 	type args struct {
 		spec *PodDisruptionBudgetSpec
@@ -834,6 +861,8 @@ func TestHorizontalPodAutoscalerSpec_Default(t *testing.T) {
 		MaxReplicas         *int32
 		ResourceName        *string
 		ResourceUtilization *int32
+		Metrics             []autoscalingv2.MetricSpec
+		SuggestedMetrics    *bool
 	}
 	type args struct {
 		def defaultHorizontalPodAutoscalerSpec
@@ -878,6 +907,18 @@ func TestHorizontalPodAutoscalerSpec_Default(t *testing.T) {
 				ResourceName:        util.Pointer("xxxx"),
 			},
 		},
+		{
+			name: "Does not add the suggested metrics to the spec",
+			fields: fields{
+				Metrics:          []autoscalingv2.MetricSpec{testPodsMetric("custom", "1")},
+				SuggestedMetrics: util.Pointer(true),
+			},
+			args: args{def: defaultHorizontalPodAutoscalerSpec{}},
+			want: &HorizontalPodAutoscalerSpec{
+				Metrics:          []autoscalingv2.MetricSpec{testPodsMetric("custom", "1")},
+				SuggestedMetrics: util.Pointer(true),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -886,6 +927,8 @@ func TestHorizontalPodAutoscalerSpec_Default(t *testing.T) {
 				MaxReplicas:         tt.fields.MaxReplicas,
 				ResourceName:        tt.fields.ResourceName,
 				ResourceUtilization: tt.fields.ResourceUtilization,
+				Metrics:             tt.fields.Metrics,
+				SuggestedMetrics:    tt.fields.SuggestedMetrics,
 			}
 			spec.Default(tt.args.def)
 			if !reflect.DeepEqual(spec, tt.want) {
@@ -895,6 +938,19 @@ func TestHorizontalPodAutoscalerSpec_Default(t *testing.T) {
 	}
 }
 
+func testPodsMetric(name, value string) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: name},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: util.Pointer(resource.MustParse(value)),
+			},
+		},
+	}
+}
+
 func TestHorizontalPodAutoscalerSpec_IsDeactivated(t *testing.T) {
 	tests := []struct {
 		name string
@@ -913,6 +969,106 @@ func TestHorizontalPodAutoscalerSpec_IsDeactivated(t *testing.T) {
 	}
 }
 
+func TestHorizontalPodAutoscalerSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *HorizontalPodAutoscalerSpec
+		wantErr bool
+	}{
+		{"Valid", &HorizontalPodAutoscalerSpec{MinReplicas: util.Pointer[int32](1), MaxReplicas: util.Pointer[int32](2)}, false},
+		{"Valid with equal values", &HorizontalPodAutoscalerSpec{MinReplicas: util.Pointer[int32](2), MaxReplicas: util.Pointer[int32](2)}, false},
+		{"Valid if deactivated", &HorizontalPodAutoscalerSpec{}, false},
+		{"Invalid", &HorizontalPodAutoscalerSpec{MinReplicas: util.Pointer[int32](3), MaxReplicas: util.Pointer[int32](2)}, true},
+		{"Valid metrics", &HorizontalPodAutoscalerSpec{Metrics: []autoscalingv2.MetricSpec{testPodsMetric("custom", "1")}}, false},
+		{"Invalid metric type", &HorizontalPodAutoscalerSpec{Metrics: []autoscalingv2.MetricSpec{
+			{Type: autoscalingv2.ResourceMetricSourceType, Resource: &autoscalingv2.ResourceMetricSource{Name: corev1.ResourceCPU}},
+		}}, true},
+		{"Invalid metric without source", &HorizontalPodAutoscalerSpec{Metrics: []autoscalingv2.MetricSpec{
+			{Type: autoscalingv2.ExternalMetricSourceType},
+		}}, true},
+		{"Valid without resource metric", &HorizontalPodAutoscalerSpec{ResourceName: util.Pointer("none"),
+			Metrics: []autoscalingv2.MetricSpec{testPodsMetric("custom", "1")}}, false},
+		{"Valid without resource metric and with suggested metrics", &HorizontalPodAutoscalerSpec{ResourceName: util.Pointer("none"),
+			SuggestedMetrics: util.Pointer(true)}, false},
+		{"Invalid without resource metric and metrics", &HorizontalPodAutoscalerSpec{ResourceName: util.Pointer("none")}, true},
+		{"Valid schedule", &HorizontalPodAutoscalerSpec{MinReplicas: util.Pointer[int32](1), MaxReplicas: util.Pointer[int32](2),
+			Schedules: []HorizontalPodAutoscalerScheduleSpec{
+				{Name: "peak", Schedule: "0 8 * * 1-5", Duration: metav1.Duration{Duration: 10 * time.Hour}, MinReplicas: util.Pointer[int32](2)},
+			}}, false},
+		{"Invalid schedule cron", &HorizontalPodAutoscalerSpec{MinReplicas: util.Pointer[int32](1), MaxReplicas: util.Pointer[int32](2),
+			Schedules: []HorizontalPodAutoscalerScheduleSpec{
+				{Name: "peak", Schedule: "0 8 * *", Duration: metav1.Duration{Duration: time.Hour}},
+			}}, true},
+		{"Invalid schedule duration", &HorizontalPodAutoscalerSpec{MinReplicas: util.Pointer[int32](1), MaxReplicas: util.Pointer[int32](2),
+			Schedules: []HorizontalPodAutoscalerScheduleSpec{
+				{Name: "peak", Schedule: "0 8 * * *"},
+			}}, true},
+		{"Invalid schedule replicas", &HorizontalPodAutoscalerSpec{MinReplicas: util.Pointer[int32](1), MaxReplicas: util.Pointer[int32](2),
+			Schedules: []HorizontalPodAutoscalerScheduleSpec{
+				{Name: "peak", Schedule: "0 8 * * *", Duration: metav1.Duration{Duration: time.Hour}, MinReplicas: util.Pointer[int32](3)},
+			}}, true},
+		{"Invalid duplicated schedule names", &HorizontalPodAutoscalerSpec{MinReplicas: util.Pointer[int32](1), MaxReplicas: util.Pointer[int32](2),
+			Schedules: []HorizontalPodAutoscalerScheduleSpec{
+				{Name: "peak", Schedule: "0 8 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				{Name: "peak", Schedule: "0 20 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+			}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.Validate(field.NewPath("hpa")); (len(got) > 0) != tt.wantErr {
+				t.Errorf("HorizontalPodAutoscalerSpec.Validate() = %v, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHorizontalPodAutoscalerSpec_Scheduled(t *testing.T) {
+	spec := &HorizontalPodAutoscalerSpec{
+		MinReplicas: util.Pointer[int32](2),
+		MaxReplicas: util.Pointer[int32](10),
+		Schedules: []HorizontalPodAutoscalerScheduleSpec{
+			{Name: "peak", Schedule: "0 8 * * *", Duration: metav1.Duration{Duration: 12 * time.Hour},
+				MinReplicas: util.Pointer[int32](5)},
+			{Name: "sales", Schedule: "0 12 * * *", Duration: metav1.Duration{Duration: time.Hour},
+				MinReplicas: util.Pointer[int32](8), MaxReplicas: util.Pointer[int32](20)},
+		},
+	}
+	day := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		now          time.Time
+		wantSchedule string
+		wantMin      int32
+		wantMax      int32
+		wantNext     time.Duration
+	}{
+		{"No active profile", day.Add(7 * time.Hour), "", 2, 10, time.Hour},
+		{"Profile activation time", day.Add(8 * time.Hour), "peak", 5, 10, 4 * time.Hour},
+		{"First matching profile is used", day.Add(12*time.Hour + 30*time.Minute), "peak", 5, 10, 30 * time.Minute},
+		{"Profile expiration time", day.Add(20 * time.Hour), "", 2, 10, 12 * time.Hour},
+		{"Next activation the following day", day.Add(-time.Hour), "", 2, 10, 9 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spec.Scheduled(tt.now)
+			if *got.MinReplicas != tt.wantMin || *got.MaxReplicas != tt.wantMax {
+				t.Errorf("HorizontalPodAutoscalerSpec.Scheduled() = %d/%d, want %d/%d",
+					*got.MinReplicas, *got.MaxReplicas, tt.wantMin, tt.wantMax)
+			}
+			var name string
+			if sched := spec.ActiveSchedule(tt.now); sched != nil {
+				name = sched.Name
+			}
+			if name != tt.wantSchedule {
+				t.Errorf("HorizontalPodAutoscalerSpec.ActiveSchedule() = %q, want %q", name, tt.wantSchedule)
+			}
+			if next := spec.NextScheduleChange(tt.now); next != tt.wantNext {
+				t.Errorf("HorizontalPodAutoscalerSpec.NextScheduleChange() = %v, want %v", next, tt.wantNext)
+			}
+		})
+	}
+}
+
 func TestInitializeHorizontalPodAutoscalerSpec(t *testing.T) {
 	type args struct {
 		spec *HorizontalPodAutoscalerSpec
@@ -1297,6 +1453,230 @@ func TestCanary_CanarySpec(t *testing.T) {
 	}
 }
 
+func TestCanary_ValidatePatches(t *testing.T) {
+	spec := &BackendSpec{
+		Image: &ImageSpec{
+			Name: util.Pointer("old"),
+			Tag:  util.Pointer("tag"),
+		},
+	}
+	tests := []struct {
+		name    string
+		patches []string
+		wantErr bool
+	}{
+		{
+			name:    "Valid patch",
+			patches: []string{`[{"op": "replace", "path": "/image/name", "value": "new"}]`},
+			wantErr: false,
+		},
+		{
+			name:    "Patch cannot be decoded",
+			patches: []string{`{"op": "replace"`},
+			wantErr: true,
+		},
+		{
+			name:    "Patch cannot be applied",
+			patches: []string{`[{"op": "replace", "path": "/image/missing/name", "value": "new"}]`},
+			wantErr: true,
+		},
+		{
+			name:    "Patch adds an unknown field",
+			patches: []string{`[{"op": "add", "path": "/image/unknown", "value": "new"}]`},
+			wantErr: true,
+		},
+		{
+			name:    "Patch sets a value of the wrong type",
+			patches: []string{`[{"op": "replace", "path": "/image/name", "value": 5}]`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Canary{Patches: tt.patches}
+			if err := c.ValidatePatches(spec, &BackendSpec{}); (err != nil) != tt.wantErr {
+				t.Errorf("Canary.ValidatePatches() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_canarySpecDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       interface{}
+		canarySpec interface{}
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "Returns the changed fields",
+			spec:       &ImageSpec{Name: util.Pointer("name"), Tag: util.Pointer("old")},
+			canarySpec: &ImageSpec{Name: util.Pointer("name"), Tag: util.Pointer("new")},
+			want:       `{"tag":"new"}`,
+			wantErr:    false,
+		},
+		{
+			name:       "Returns the removed fields",
+			spec:       &ImageSpec{Name: util.Pointer("name"), Tag: util.Pointer("old")},
+			canarySpec: &ImageSpec{Name: util.Pointer("name")},
+			want:       `{"tag":null}`,
+			wantErr:    false,
+		},
+		{
+			name:       "Returns an empty diff",
+			spec:       &ImageSpec{Name: util.Pointer("name")},
+			canarySpec: &ImageSpec{Name: util.Pointer("name")},
+			want:       `{}`,
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := canarySpecDiff(tt.spec, tt.canarySpec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("canarySpecDiff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("canarySpecDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanary_TrafficWeight(t *testing.T) {
+	steps := []CanaryStep{
+		{Weight: 5, Duration: metav1.Duration{Duration: 10 * time.Minute}},
+		{Weight: 25, Duration: metav1.Duration{Duration: 10 * time.Minute}},
+		{Weight: 50, Duration: metav1.Duration{Duration: 30 * time.Minute}},
+	}
+	tests := []struct {
+		name          string
+		canary        *Canary
+		elapsed       time.Duration
+		wantWeight    int32
+		wantRemaining time.Duration
+	}{
+		{
+			name:          "Returns the weight when there are no steps",
+			canary:        &Canary{Weight: util.Pointer[int32](10)},
+			elapsed:       time.Hour,
+			wantWeight:    10,
+			wantRemaining: 0,
+		},
+		{
+			name:          "Returns the weight of the first step",
+			canary:        &Canary{Weight: util.Pointer[int32](100), Steps: steps},
+			elapsed:       time.Minute,
+			wantWeight:    5,
+			wantRemaining: 9 * time.Minute,
+		},
+		{
+			name:          "Returns the weight of the current step",
+			canary:        &Canary{Weight: util.Pointer[int32](100), Steps: steps},
+			elapsed:       25 * time.Minute,
+			wantWeight:    50,
+			wantRemaining: 25 * time.Minute,
+		},
+		{
+			name:          "Returns the weight once the steps have completed",
+			canary:        &Canary{Weight: util.Pointer[int32](100), Steps: steps},
+			elapsed:       time.Hour,
+			wantWeight:    100,
+			wantRemaining: 0,
+		},
+		{
+			name:          "Returns the weight of the last step if weight is not set",
+			canary:        &Canary{Steps: steps},
+			elapsed:       time.Hour,
+			wantWeight:    50,
+			wantRemaining: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotWeight, gotRemaining := tt.canary.TrafficWeight(tt.elapsed)
+			if gotWeight != tt.wantWeight {
+				t.Errorf("Canary.TrafficWeight() weight = %v, want %v", gotWeight, tt.wantWeight)
+			}
+			if gotRemaining != tt.wantRemaining {
+				t.Errorf("Canary.TrafficWeight() remaining = %v, want %v", gotRemaining, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestSecretReference_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *SecretReference
+		wantErr bool
+	}{
+		{"Valid override", &SecretReference{Override: util.Pointer("value")}, false},
+		{"Valid vault reference", &SecretReference{FromVault: &VaultSecretReference{Path: "path", Key: "key"}}, false},
+		{"Invalid if empty", &SecretReference{}, true},
+		{"Invalid with both", &SecretReference{
+			Override:  util.Pointer("value"),
+			FromVault: &VaultSecretReference{Path: "path", Key: "key"},
+		}, true},
+		{"Invalid vault reference without key", &SecretReference{FromVault: &VaultSecretReference{Path: "path"}}, true},
+		{"Valid kubernetes secret reference", &SecretReference{FromKubernetesSecret: &KubernetesSecretReference{Name: "secret", Key: "key"}}, false},
+		{"Invalid kubernetes secret reference without name", &SecretReference{FromKubernetesSecret: &KubernetesSecretReference{Key: "key"}}, true},
+		{"Valid AWS Secrets Manager reference", &SecretReference{FromAWSSecretsManager: &AWSSecretsManagerSecretReference{Name: "secret"}}, false},
+		{"Invalid AWS Secrets Manager reference without name", &SecretReference{FromAWSSecretsManager: &AWSSecretsManagerSecretReference{Key: "key"}}, true},
+		{"Valid external secrets provider reference", &SecretReference{FromExternalSecretsProvider: &ExternalSecretsProviderSecretReference{Key: "key"}}, false},
+		{"Invalid external secrets provider reference without key", &SecretReference{FromExternalSecretsProvider: &ExternalSecretsProviderSecretReference{}}, true},
+		{"Invalid with several providers", &SecretReference{
+			FromKubernetesSecret:  &KubernetesSecretReference{Name: "secret", Key: "key"},
+			FromAWSSecretsManager: &AWSSecretsManagerSecretReference{Name: "secret"},
+		}, true},
+		{"Valid vault reference mounted as file", &SecretReference{
+			FromVault:   &VaultSecretReference{Path: "path", Key: "key"},
+			MountAsFile: util.Pointer(true),
+		}, false},
+		{"Invalid override mounted as file", &SecretReference{
+			Override:    util.Pointer("value"),
+			MountAsFile: util.Pointer(true),
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.Validate(field.NewPath("secret")); (len(got) > 0) != tt.wantErr {
+				t.Errorf("SecretReference.Validate() = %v, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnvironmentSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *EnvironmentSpec
+		wantErr bool
+	}{
+		{"Valid empty", &EnvironmentSpec{}, false},
+		{"Valid", &EnvironmentSpec{
+			ExtraEnv:     []corev1.EnvVar{{Name: "A"}, {Name: "B"}},
+			EnvOverrides: []corev1.EnvVar{{Name: "C"}},
+		}, false},
+		{"Invalid with duplicated extra variable", &EnvironmentSpec{
+			ExtraEnv: []corev1.EnvVar{{Name: "A"}, {Name: "A"}},
+		}, true},
+		{"Invalid with variable both extra and overridden", &EnvironmentSpec{
+			ExtraEnv:     []corev1.EnvVar{{Name: "A"}},
+			EnvOverrides: []corev1.EnvVar{{Name: "A"}},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.Validate(field.NewPath("spec")); (len(got) > 0) != tt.wantErr {
+				t.Errorf("EnvironmentSpec.Validate() = %v, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestExternalSecretSecretStoreReferenceSpec_Default(t *testing.T) {
 	type fields struct {
 		Name *string
@@ -0,0 +1,57 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// v1alpha1 is the storage version of the API and the hub for the conversion
+// of the rest of the versions. The conversion functions are implemented by
+// each of the spokes.
+
+// Hub marks this type as a conversion hub.
+func (*Apicast) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*AutoSSL) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Backend) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*CORSProxy) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*EchoAPI) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*MappingService) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*RedisShard) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Sentinel) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ShardedRedisBackup) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*System) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*TwemproxyConfig) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Zync) Hub() {}
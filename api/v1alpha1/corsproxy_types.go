@@ -47,6 +47,10 @@ var (
 		ResourceUtilization: util.Pointer[int32](90),
 		ResourceName:        util.Pointer("cpu"),
 	}
+	corsproxyDefaultDeploymentStrategy defaultDeploymentRollingStrategySpec = defaultDeploymentRollingStrategySpec{
+		MaxUnavailable: util.Pointer(intstr.FromInt(0)),
+		MaxSurge:       util.Pointer(intstr.FromInt(1)),
+	}
 	corsproxyDefaultProbe defaultProbeSpec = defaultProbeSpec{
 		InitialDelaySeconds: util.Pointer[int32](3),
 		TimeoutSeconds:      util.Pointer[int32](1),
@@ -66,6 +70,10 @@ var (
 
 // CORSProxySpec defines the desired state of CORSProxy
 type CORSProxySpec struct {
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	// +patchStrategy=retainKeys
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
 	// Image specification for the component
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -113,6 +121,7 @@ type CORSProxySpec struct {
 func (spec *CORSProxySpec) Default() {
 
 	spec.Image = InitializeImageSpec(spec.Image, corsproxyDefaultImage)
+	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, corsproxyDefaultDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, corsproxyDefaultHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &corsproxyDefaultReplicas)
 	spec.PDB = InitializePodDisruptionBudgetSpec(spec.PDB, corsproxyDefaultPDB)
@@ -141,10 +150,18 @@ func (cfg *CORSProxyConfig) Default() {
 }
 
 // CORSProxyStatus defines the observed state of CORSProxy
-type CORSProxyStatus struct{}
+type CORSProxyStatus struct {
+	WorkloadsStatus `json:",inline"`
+}
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Ready")].status`,name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Progressing")].status`,name=Progressing,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,name=Degraded,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.workloads[0].deployment.image`,name=Image,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name=Age,type=date
 
 // CORSProxy is the Schema for the corsproxies API
 type CORSProxy struct {
@@ -160,6 +177,11 @@ func (c *CORSProxy) Default() {
 	c.Spec.Default()
 }
 
+// GetWorkloadsStatus returns the status of the workloads of the CORSProxy resource
+func (c *CORSProxy) GetWorkloadsStatus() *WorkloadsStatus {
+	return &c.Status.WorkloadsStatus
+}
+
 // +kubebuilder:object:root=true
 
 // CORSProxyList contains a list of CORSProxy
@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of CORSProxy with the manager
+func (c *CORSProxy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(c).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-corsproxy,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=corsproxies,verbs=create;update,versions=v1alpha1,name=mcorsproxy.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &CORSProxy{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-corsproxy,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=corsproxies,verbs=create;update,versions=v1alpha1,name=vcorsproxy.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &CORSProxy{}
+
+// ValidateCreate implements webhook.Validator
+func (c *CORSProxy) ValidateCreate() error {
+	return c.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (c *CORSProxy) ValidateUpdate(old runtime.Object) error {
+	return c.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (c *CORSProxy) ValidateDelete() error {
+	return nil
+}
+
+func (c *CORSProxy) validate() error {
+	o := c.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	return invalid("CORSProxy", c.GetName(), errs)
+}
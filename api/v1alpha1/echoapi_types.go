@@ -37,6 +37,10 @@ var (
 		ResourceUtilization: util.Pointer[int32](90),
 		ResourceName:        util.Pointer("cpu"),
 	}
+	echoapiDefaultDeploymentStrategy defaultDeploymentRollingStrategySpec = defaultDeploymentRollingStrategySpec{
+		MaxUnavailable: util.Pointer(intstr.FromInt(0)),
+		MaxSurge:       util.Pointer(intstr.FromInt(1)),
+	}
 	echoapiDefaultPDB defaultPodDisruptionBudgetSpec = defaultPodDisruptionBudgetSpec{
 		MaxUnavailable: util.Pointer(intstr.FromInt(1)),
 	}
@@ -73,6 +77,10 @@ var (
 
 // EchoAPISpec defines the desired state of echoapi
 type EchoAPISpec struct {
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	// +patchStrategy=retainKeys
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
 	// Image specification for the component
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -124,6 +132,7 @@ type EchoAPISpec struct {
 func (spec *EchoAPISpec) Default() {
 
 	spec.Image = InitializeImageSpec(spec.Image, echoapiDefaultImage)
+	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, echoapiDefaultDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, echoapiDefaultHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &echoapiDefaultReplicas)
 	spec.PDB = InitializePodDisruptionBudgetSpec(spec.PDB, echoapiDefaultPDB)
@@ -135,10 +144,18 @@ func (spec *EchoAPISpec) Default() {
 }
 
 // EchoAPIStatus defines the observed state of EchoAPI
-type EchoAPIStatus struct{}
+type EchoAPIStatus struct {
+	WorkloadsStatus `json:",inline"`
+}
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Ready")].status`,name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Progressing")].status`,name=Progressing,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,name=Degraded,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.workloads[0].deployment.image`,name=Image,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name=Age,type=date
 
 // EchoAPI is the Schema for the echoapis API
 type EchoAPI struct {
@@ -154,6 +171,11 @@ func (e *EchoAPI) Default() {
 	e.Spec.Default()
 }
 
+// GetWorkloadsStatus returns the status of the workloads of the EchoAPI resource
+func (e *EchoAPI) GetWorkloadsStatus() *WorkloadsStatus {
+	return &e.Status.WorkloadsStatus
+}
+
 // +kubebuilder:object:root=true
 
 // EchoAPIList contains a list of echoapi
@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of EchoAPI with the manager
+func (e *EchoAPI) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(e).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-echoapi,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=echoapis,verbs=create;update,versions=v1alpha1,name=mechoapi.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &EchoAPI{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-echoapi,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=echoapis,verbs=create;update,versions=v1alpha1,name=vechoapi.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &EchoAPI{}
+
+// ValidateCreate implements webhook.Validator
+func (e *EchoAPI) ValidateCreate() error {
+	return e.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (e *EchoAPI) ValidateUpdate(old runtime.Object) error {
+	return e.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (e *EchoAPI) ValidateDelete() error {
+	return nil
+}
+
+func (e *EchoAPI) validate() error {
+	o := e.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	return invalid("EchoAPI", e.GetName(), errs)
+}
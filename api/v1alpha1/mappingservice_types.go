@@ -47,6 +47,10 @@ var (
 		ResourceUtilization: util.Pointer[int32](90),
 		ResourceName:        util.Pointer("cpu"),
 	}
+	mappingserviceDefaultDeploymentStrategy defaultDeploymentRollingStrategySpec = defaultDeploymentRollingStrategySpec{
+		MaxUnavailable: util.Pointer(intstr.FromInt(0)),
+		MaxSurge:       util.Pointer(intstr.FromInt(1)),
+	}
 	mappingserviceLivenessDefaultProbe defaultProbeSpec = defaultProbeSpec{
 		InitialDelaySeconds: util.Pointer[int32](5),
 		TimeoutSeconds:      util.Pointer[int32](5),
@@ -74,6 +78,10 @@ var (
 
 // MappingServiceSpec defines the desired state of MappingService
 type MappingServiceSpec struct {
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	// +patchStrategy=retainKeys
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
 	// Image specification for the component
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -121,6 +129,7 @@ type MappingServiceSpec struct {
 func (spec *MappingServiceSpec) Default() {
 
 	spec.Image = InitializeImageSpec(spec.Image, mappingserviceDefaultImage)
+	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, mappingserviceDefaultDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, mappingserviceDefaultHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &mappingserviceDefaultReplicas)
 	spec.PDB = InitializePodDisruptionBudgetSpec(spec.PDB, mappingserviceDefaultPDB)
@@ -163,12 +172,17 @@ func (cfg *MappingServiceConfig) Default() {
 
 // MappingServiceStatus defines the observed state of MappingService
 type MappingServiceStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	WorkloadsStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Ready")].status`,name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Progressing")].status`,name=Progressing,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,name=Degraded,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.workloads[0].deployment.image`,name=Image,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name=Age,type=date
 
 // MappingService is the Schema for the mappingservices API
 type MappingService struct {
@@ -184,6 +198,11 @@ func (ms *MappingService) Default() {
 	ms.Spec.Default()
 }
 
+// GetWorkloadsStatus returns the status of the workloads of the MappingService resource
+func (ms *MappingService) GetWorkloadsStatus() *WorkloadsStatus {
+	return &ms.Status.WorkloadsStatus
+}
+
 // +kubebuilder:object:root=true
 
 // MappingServiceList contains a list of MappingService
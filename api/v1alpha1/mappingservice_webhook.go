@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of MappingService with the manager
+func (ms *MappingService) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(ms).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-mappingservice,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=mappingservices,verbs=create;update,versions=v1alpha1,name=mmappingservice.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &MappingService{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-mappingservice,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=mappingservices,verbs=create;update,versions=v1alpha1,name=vmappingservice.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &MappingService{}
+
+// ValidateCreate implements webhook.Validator
+func (ms *MappingService) ValidateCreate() error {
+	return ms.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (ms *MappingService) ValidateUpdate(old runtime.Object) error {
+	return ms.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (ms *MappingService) ValidateDelete() error {
+	return nil
+}
+
+func (ms *MappingService) validate() error {
+	o := ms.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	return invalid("MappingService", ms.GetName(), errs)
+}
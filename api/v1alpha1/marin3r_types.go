@@ -7,6 +7,7 @@ import (
 	envoyconfig "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // SidecarPort defines port for the Marin3r sidecar container
@@ -160,6 +161,11 @@ type EnvoyDynamicConfig struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	RawConfig *RawConfig `json:"rawConfig,omitempty"`
+	// ListenerTcpProxy contains options for a listener that proxies
+	// TCP connections to a cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ListenerTcpProxy *ListenerTcpProxy `json:"listenerTcpProxy,omitempty"`
 }
 
 // AsEnvoyDynamicConfigDescriptor converts the external API type into the internal EnvoyDynamicConfigDescriptor
@@ -189,11 +195,35 @@ func (config *EnvoyDynamicConfig) GetOptions() interface{} {
 		return config.Runtime
 	} else if config.RawConfig != nil {
 		return config.RawConfig
+	} else if config.ListenerTcpProxy != nil {
+		return config.ListenerTcpProxy
 	}
 
 	return nil
 }
 
+// Validate checks that the options of the configuration are supported by its generator version
+func (config *EnvoyDynamicConfig) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if config.ListenerHttp == nil || (config.GeneratorVersion != nil && *config.GeneratorVersion == "v2") {
+		return errs
+	}
+	for _, opt := range []struct {
+		name string
+		set  bool
+	}{
+		{"accessLog", config.ListenerHttp.AccessLog != nil},
+		{"tracing", config.ListenerHttp.Tracing != nil},
+		{"clientValidation", config.ListenerHttp.ClientValidation != nil},
+	} {
+		if opt.set {
+			errs = append(errs, field.Forbidden(fldPath.Child("listenerHttp", opt.name),
+				"only supported by generator version \"v2\""))
+		}
+	}
+	return errs
+}
+
 // ListenerHttp contains options for an HTTP/HTTPS listener
 type ListenerHttp struct {
 	// The port where the listener listens for new connections
@@ -236,6 +266,152 @@ type ListenerHttp struct {
 	// +optional
 	// Max connection duration. If unset no max connection duration will be applied.
 	MaxConnectionDuration *metav1.Duration `json:"maxConnectionDuration,omitempty"`
+	// Access log options. If unset, requests are logged to stdout using
+	// the default JSON format. Only used by generator version "v2".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	AccessLog *AccessLogOptions `json:"accessLog,omitempty"`
+	// Tracing options. If unset, tracing is disabled.
+	// Only used by generator version "v2".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Tracing *TracingOptions `json:"tracing,omitempty"`
+	// Validation of the certificates presented by the clients of an https
+	// listener (mTLS). Only used by generator version "v2".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ClientValidation *ListenerClientValidation `json:"clientValidation,omitempty"`
+}
+
+// Validate checks that client validation is only configured in https listeners
+func (l *ListenerHttp) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if l.ClientValidation != nil && l.CertificateSecretName == nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("clientValidation"),
+			"client validation requires certificateSecretName to be set"))
+	}
+	return errs
+}
+
+// ListenerClientValidation configures the validation of the
+// certificates presented by the clients of a listener
+type ListenerClientValidation struct {
+	// The name of a Secret of type "kubernetes.io/tls" whose "tls.crt" key
+	// holds the CA certificates used to verify the client certificates. It
+	// must be a different Secret than the listener's certificate.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	CASecretName string `json:"caSecretName"`
+	// Whether clients that do not present a certificate are
+	// rejected or not. Defaults to true.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=true
+	// +optional
+	RequireClientCertificate *bool `json:"requireClientCertificate,omitempty"`
+}
+
+// AccessLogOptions contains options for the access logs of
+// the HTTP connection manager
+type AccessLogOptions struct {
+	// The fields of the JSON log format, as a map of field names to Envoy
+	// command operators (eg "%RESPONSE_CODE%"). If unset, the default
+	// fields are used. Not used by the gRPC sinks.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Fields map[string]string `json:"fields,omitempty"`
+	// The sinks where access logs are written. If unset,
+	// access logs are written to stdout.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Sinks []AccessLogSink `json:"sinks,omitempty"`
+}
+
+// AccessLogSink is a destination for access logs.
+// Only one of the fields can be set.
+// +kubebuilder:validation:MinProperties:=1
+// +kubebuilder:validation:MaxProperties:=1
+type AccessLogSink struct {
+	// Writes access logs to the standard output
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Stdout *AccessLogStdoutSink `json:"stdout,omitempty"`
+	// Writes access logs to a file
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	File *AccessLogFileSink `json:"file,omitempty"`
+	// Sends access logs to a gRPC access log service
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Grpc *AccessLogGrpcSink `json:"grpc,omitempty"`
+}
+
+// Validate checks that exactly one sink is set
+func (sink *AccessLogSink) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	set := 0
+	for _, isSet := range []bool{sink.Stdout != nil, sink.File != nil, sink.Grpc != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		errs = append(errs, field.Invalid(fldPath, sink,
+			"exactly one of stdout, file and grpc must be set"))
+	}
+	return errs
+}
+
+// AccessLogStdoutSink writes access logs to the standard output
+type AccessLogStdoutSink struct{}
+
+// AccessLogFileSink writes access logs to a file
+type AccessLogFileSink struct {
+	// The path of the file
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Path string `json:"path"`
+}
+
+// AccessLogGrpcSink sends access logs to a gRPC access log service
+type AccessLogGrpcSink struct {
+	// The name of the cluster of the access log service. Must point
+	// to one of the defined clusters.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ClusterName string `json:"clusterName"`
+	// The name of the access log, used by the service to
+	// identify the source of the logs. Defaults to the listener name.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	LogName *string `json:"logName,omitempty"`
+}
+
+// TracingOptions contains options for the tracing of the
+// requests handled by the HTTP connection manager
+type TracingOptions struct {
+	// The tracing provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum=opentelemetry;zipkin
+	Provider string `json:"provider"`
+	// The name of the cluster of the trace collector. Must point
+	// to one of the defined clusters.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ClusterName string `json:"clusterName"`
+	// The API endpoint of the Zipkin collector. Only used
+	// by the zipkin provider.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=/api/v2/spans
+	// +optional
+	CollectorEndpoint *string `json:"collectorEndpoint,omitempty"`
+	// The service name reported in the traces. Only used by the
+	// opentelemetry provider. Defaults to the listener name.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ServiceName *string `json:"serviceName,omitempty"`
+	// The percentage of requests that are randomly sampled for tracing
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +kubebuilder:default:=100
+	// +optional
+	RandomSampling *uint32 `json:"randomSampling,omitempty"`
 }
 
 // RateLimitOptions contains options for the ratelimit filter of the
@@ -273,6 +449,201 @@ type Cluster struct {
 	// +kubebuilder:default:=false
 	// +optional
 	IsHttp2 *bool `json:"isHttp2"`
+	// CanaryWeighted marks the cluster as the upstream cluster of the application
+	// container of the workload. When the canary of the workload uses traffic weights,
+	// the endpoints of this cluster are replaced by the per-Deployment Services of the
+	// main and the canary Deployments, each one with its corresponding weight. The
+	// port of the cluster is used as the port of the per-Deployment Services.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	CanaryWeighted *bool `json:"canaryWeighted,omitempty"`
+	// The timeout for new network connections to the upstream hosts.
+	// Defaults to 1s. Only used by generator version "v2".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ConnectTimeout *metav1.Duration `json:"connectTimeout,omitempty"`
+	// Active health checking of the upstream hosts.
+	// Only used by generator version "v2".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	HealthCheck *ClusterHealthCheck `json:"healthCheck,omitempty"`
+	// Passive health checking of the upstream hosts, ejecting the ones that
+	// consecutively fail. Only used by generator version "v2".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	OutlierDetection *ClusterOutlierDetection `json:"outlierDetection,omitempty"`
+	// Circuit breaking limits for the cluster.
+	// Only used by generator version "v2".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	CircuitBreakers *ClusterCircuitBreakers `json:"circuitBreakers,omitempty"`
+	// Connect to the upstream hosts using TLS.
+	// Only used by generator version "v2".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	UpstreamTLS *ClusterUpstreamTLS `json:"upstreamTLS,omitempty"`
+	// WeightedUpstreams is populated by the operator with the per-Deployment
+	// upstreams when the canary uses traffic weights. It is not part of the API.
+	WeightedUpstreams []WeightedUpstream `json:"-"`
+}
+
+// ClusterHealthCheck configures the active health checking of the
+// upstream hosts of a cluster
+type ClusterHealthCheck struct {
+	// The protocol used for health checking: "http", "tcp" or "grpc"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum=http;tcp;grpc
+	Protocol string `json:"protocol"`
+	// The path requested by http health checks
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="/"
+	// +optional
+	Path *string `json:"path,omitempty"`
+	// The service name sent in grpc health checks
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ServiceName *string `json:"serviceName,omitempty"`
+	// The interval between health checks
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Format:=duration
+	Interval metav1.Duration `json:"interval"`
+	// The time to wait for a health check response
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Format:=duration
+	Timeout metav1.Duration `json:"timeout"`
+	// The number of healthy checks required before a host is marked healthy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=1
+	// +optional
+	HealthyThreshold *uint32 `json:"healthyThreshold,omitempty"`
+	// The number of unhealthy checks required before a host is marked unhealthy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=3
+	// +optional
+	UnhealthyThreshold *uint32 `json:"unhealthyThreshold,omitempty"`
+}
+
+// ClusterOutlierDetection configures the ejection of the upstream
+// hosts of a cluster that consecutively fail
+type ClusterOutlierDetection struct {
+	// The number of consecutive 5xx responses before a host is ejected
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=5
+	// +optional
+	Consecutive5xx *uint32 `json:"consecutive5xx,omitempty"`
+	// The interval between ejection analysis sweeps
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Format:=duration
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// The base time that a host is ejected for. The real time is equal to the
+	// base time multiplied by the number of times the host has been ejected.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Format:=duration
+	// +optional
+	BaseEjectionTime *metav1.Duration `json:"baseEjectionTime,omitempty"`
+	// The maximum percentage of hosts that can be ejected
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	MaxEjectionPercent *uint32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// ClusterCircuitBreakers configures the circuit breaking limits of a cluster.
+// Envoy defaults are used for unset limits.
+type ClusterCircuitBreakers struct {
+	// The maximum number of connections to the upstream hosts
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxConnections *uint32 `json:"maxConnections,omitempty"`
+	// The maximum number of requests waiting for a connection
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxPendingRequests *uint32 `json:"maxPendingRequests,omitempty"`
+	// The maximum number of parallel requests to the upstream hosts
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxRequests *uint32 `json:"maxRequests,omitempty"`
+	// The maximum number of parallel retries to the upstream hosts
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxRetries *uint32 `json:"maxRetries,omitempty"`
+}
+
+// ClusterUpstreamTLS configures TLS for the connections
+// to the upstream hosts of a cluster
+type ClusterUpstreamTLS struct {
+	// The SNI sent to the upstream hosts. Defaults to the cluster host.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SNI *string `json:"sni,omitempty"`
+	// The name of a Secret of type "kubernetes.io/tls" with the client certificate
+	// presented to the upstream hosts (mTLS). The Secret generated by a cert-manager
+	// Certificate can be used. If unset, no client certificate is presented.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ClientCertificateSecretName *string `json:"clientCertificateSecretName,omitempty"`
+	// ClientCertificate configures a cert-manager Certificate that the operator
+	// creates to issue the client certificate into the Secret referenced by
+	// clientCertificateSecretName. Requires cert-manager to be installed in the
+	// cluster. If unset, the Secret must be provided.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ClientCertificate *ClusterClientCertificate `json:"clientCertificate,omitempty"`
+	// The name of a Secret of type "kubernetes.io/tls" whose "tls.crt" key holds the
+	// CA certificates used to verify the upstream hosts. If unset, the certificates
+	// of the upstream hosts are not verified.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	CASecretName *string `json:"caSecretName,omitempty"`
+}
+
+// ClusterClientCertificate configures the cert-manager Certificate
+// that issues the client certificate of a cluster
+type ClusterClientCertificate struct {
+	// The cert-manager issuer that signs the certificate
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	IssuerRef CertificateIssuerRef `json:"issuerRef"`
+	// The common name of the certificate
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	CommonName *string `json:"commonName,omitempty"`
+	// The DNS subject alternative names of the certificate
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// The requested validity of the certificate. Defaults to the cert-manager default.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Format:=duration
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// How long before its expiry the certificate is renewed.
+	// Defaults to the cert-manager default.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Format:=duration
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// CertificateIssuerRef references a cert-manager issuer
+type CertificateIssuerRef struct {
+	// The name of the issuer
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// The kind of the issuer: "Issuer" or "ClusterIssuer"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default:=Issuer
+	// +optional
+	Kind *string `json:"kind,omitempty"`
+}
+
+// WeightedUpstream is an upstream host of a cluster that
+// receives a percentage of the traffic
+type WeightedUpstream struct {
+	Host   string
+	Port   uint32
+	Weight uint32
 }
 
 // RouteConfiguration contains options for an Envoy route_configuration
@@ -282,6 +653,145 @@ type RouteConfiguration struct {
 	// Virtual hosts must be specified using directly Envoy's API
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	VirtualHosts []runtime.RawExtension `json:"virtualHosts"`
+	// Retry policy applied to the virtual hosts that do not define their own.
+	// Only used by generator version "v2".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// Rate limits applied to the virtual hosts and routes of this route configuration.
+	// The descriptors of the rate limit service configuration are generated from these
+	// same definitions, using the domain of the listeners that use the route configuration.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RateLimits []RateLimitPolicy `json:"rateLimits,omitempty"`
+}
+
+// RateLimitPolicy defines the rate limits applied to a virtual host
+// or to one of its routes
+type RateLimitPolicy struct {
+	// The name of the virtual host the rate limits are applied to
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	VirtualHost string `json:"virtualHost"`
+	// The name of the route, within the virtual host, that the rate limits
+	// are applied to. If unset, the rate limits apply to the virtual host.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Route *string `json:"route,omitempty"`
+	// The list of rate limits. Each one generates a descriptor that is
+	// sent to the rate limit service.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems:=1
+	Limits []RateLimit `json:"limits"`
+}
+
+// RateLimit defines a rate limit descriptor and the limit
+// that the rate limit service applies to it
+type RateLimit struct {
+	// The actions that generate the entries of the descriptor, in order
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems:=1
+	Actions []RateLimitAction `json:"actions"`
+	// The number of requests allowed per unit of time
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RequestsPerUnit uint32 `json:"requestsPerUnit"`
+	// The unit of time of the limit
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum=second;minute;hour;day
+	Unit string `json:"unit"`
+}
+
+// RateLimitAction generates a descriptor entry from the request.
+// Only one of the fields can be set.
+// +kubebuilder:validation:MinProperties:=1
+// +kubebuilder:validation:MaxProperties:=1
+type RateLimitAction struct {
+	// Generates a descriptor entry with the value of a request header
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RequestHeader *RateLimitRequestHeader `json:"requestHeader,omitempty"`
+	// Generates a descriptor entry with the downstream remote address
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RemoteAddress *RateLimitRemoteAddress `json:"remoteAddress,omitempty"`
+	// Generates a descriptor entry with a fixed value
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	GenericKey *RateLimitGenericKey `json:"genericKey,omitempty"`
+}
+
+// Validate checks that exactly one action is set
+func (action *RateLimitAction) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	set := 0
+	for _, isSet := range []bool{action.RequestHeader != nil, action.RemoteAddress != nil, action.GenericKey != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		errs = append(errs, field.Invalid(fldPath, action,
+			"exactly one of requestHeader, remoteAddress and genericKey must be set"))
+	}
+	return errs
+}
+
+// DescriptorEntry returns the key and the value of the descriptor entry
+// generated by the action. An empty value matches any value.
+func (action *RateLimitAction) DescriptorEntry() (string, string) {
+	switch {
+	case action.RequestHeader != nil:
+		return action.RequestHeader.DescriptorKey, ""
+	case action.RemoteAddress != nil:
+		return "remote_address", ""
+	case action.GenericKey != nil:
+		return *action.GenericKey.DescriptorKey, action.GenericKey.DescriptorValue
+	}
+	return "", ""
+}
+
+// RateLimitRequestHeader generates a descriptor entry
+// with the value of a request header
+type RateLimitRequestHeader struct {
+	// The name of the request header
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	HeaderName string `json:"headerName"`
+	// The key of the descriptor entry
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DescriptorKey string `json:"descriptorKey"`
+}
+
+// RateLimitRemoteAddress generates a descriptor entry
+// with the downstream remote address
+type RateLimitRemoteAddress struct{}
+
+// RateLimitGenericKey generates a descriptor entry with a fixed value
+type RateLimitGenericKey struct {
+	// The key of the descriptor entry
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=generic_key
+	// +optional
+	DescriptorKey *string `json:"descriptorKey,omitempty"`
+	// The value of the descriptor entry
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DescriptorValue string `json:"descriptorValue"`
+}
+
+// RetryPolicy configures the retries of the requests routed by a virtual host
+type RetryPolicy struct {
+	// The conditions under which a retry takes place, as a comma separated list
+	// of Envoy retry conditions (eg "5xx,reset,connect-failure")
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RetryOn string `json:"retryOn"`
+	// The maximum number of retries
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=1
+	// +optional
+	NumRetries *uint32 `json:"numRetries,omitempty"`
+	// The timeout of each retry. Defaults to the timeout of the route.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Format:=duration
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
 }
 
 // Runtime contains options for an Envoy runtime protobuffer message
@@ -300,7 +810,35 @@ type RawConfig struct {
 	Type string `json:"type"`
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Allows defining configuration using directly envoy's config API.
-	// WARNING: no validation of this field's value is performed before
-	// writting the custom resource to etcd.
+	// The value is validated against the Envoy API when the EnvoyConfig is
+	// generated, and validation errors are reported in the resource's status.
 	Value runtime.RawExtension `json:"value"`
 }
+
+// ListenerTcpProxy contains options for a listener that proxies
+// TCP connections to a cluster
+type ListenerTcpProxy struct {
+	// The address where the listener listens for new connections.
+	// Defaults to "0.0.0.0".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="0.0.0.0"
+	// +optional
+	Address *string `json:"address,omitempty"`
+	// The port where the listener listens for new connections
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Port uint32 `json:"port"`
+	// The name of the cluster where connections are proxied to
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ClusterName string `json:"clusterName"`
+	// Whether proxy protocol should be enabled or not. Defaults to false.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// +optional
+	ProxyProtocol *bool `json:"proxyProtocol,omitempty"`
+	// Connections are closed after being idle for this time. Defaults
+	// to Envoy's default of 1 hour.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Format:=duration
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+}
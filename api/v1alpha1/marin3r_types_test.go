@@ -4,9 +4,11 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/3scale-ops/basereconciler/util"
 	envoyconfig "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func TestMarin3rSidecarSpec_Default(t *testing.T) {
@@ -262,3 +264,53 @@ func TestMapOfEnvoyDynamicConfig_AsList(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvoyDynamicConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *EnvoyDynamicConfig
+		want   field.ErrorList
+	}{
+		{
+			name: "v2 options with generator version v2",
+			config: &EnvoyDynamicConfig{
+				GeneratorVersion: util.Pointer("v2"),
+				ListenerHttp: &ListenerHttp{
+					AccessLog: &AccessLogOptions{},
+					Tracing:   &TracingOptions{},
+				},
+			},
+			want: field.ErrorList{},
+		},
+		{
+			name: "v2 options with generator version v1",
+			config: &EnvoyDynamicConfig{
+				GeneratorVersion: util.Pointer("v1"),
+				ListenerHttp: &ListenerHttp{
+					AccessLog:        &AccessLogOptions{},
+					ClientValidation: &ListenerClientValidation{},
+				},
+			},
+			want: field.ErrorList{
+				field.Forbidden(field.NewPath("config", "listenerHttp", "accessLog"), `only supported by generator version "v2"`),
+				field.Forbidden(field.NewPath("config", "listenerHttp", "clientValidation"), `only supported by generator version "v2"`),
+			},
+		},
+		{
+			name: "v2 options without generator version",
+			config: &EnvoyDynamicConfig{
+				ListenerHttp: &ListenerHttp{Tracing: &TracingOptions{}},
+			},
+			want: field.ErrorList{
+				field.Forbidden(field.NewPath("config", "listenerHttp", "tracing"), `only supported by generator version "v2"`),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.Validate(field.NewPath("config")); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EnvoyDynamicConfig.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -55,6 +55,10 @@ type RedisShardSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Command *string `json:"command,omitempty"`
+	// Configures how changes are rolled out to the pods of the StatefulSet
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Rollout *StatefulSetRolloutSpec `json:"rollout,omitempty"`
 }
 
 // Default implements defaulting for RedisShardSpec
@@ -130,6 +134,7 @@ type RedisShardStatus struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 
 // RedisShard is the Schema for the redisshards API
 // +kubebuilder:printcolumn:JSONPath=".status.shardNodes.master",name=Master,type=string
@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of RedisShard with the manager
+func (rs *RedisShard) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(rs).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-redisshard,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=redisshards,verbs=create;update,versions=v1alpha1,name=mredisshard.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &RedisShard{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-redisshard,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=redisshards,verbs=create;update,versions=v1alpha1,name=vredisshard.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &RedisShard{}
+
+// ValidateCreate implements webhook.Validator
+func (rs *RedisShard) ValidateCreate() error {
+	return rs.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (rs *RedisShard) ValidateUpdate(old runtime.Object) error {
+	return rs.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (rs *RedisShard) ValidateDelete() error {
+	return nil
+}
+
+func (rs *RedisShard) validate() error {
+	o := rs.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	return invalid("RedisShard", rs.GetName(), errs)
+}
@@ -18,7 +18,9 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/3scale-ops/basereconciler/util"
@@ -155,6 +157,10 @@ type SentinelSpec struct {
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty" protobuf:"bytes,22,opt,name=tolerations"`
 	// Config configures the sentinel process
 	Config *SentinelConfig `json:"config"`
+	// Configures how changes are rolled out to the pods of the StatefulSet
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Rollout *StatefulSetRolloutSpec `json:"rollout,omitempty"`
 }
 
 // Default implements defaulting for SentinelSpec
@@ -220,6 +226,31 @@ func (ss *SentinelStatus) ShardedCluster(ctx context.Context, pool *redis.Server
 	return cluster, nil
 }
 
+// SentinelHosts returns the comma separated list of redis URLs of the sentinel
+// instances, as expected by the clients that connect to redis through sentinel.
+// An error is returned if there are no sentinel instances running or any of the
+// given shards is not monitored. A shard without a master, as happens during a
+// failover, is not an error as the clients discover the master through sentinel.
+func (ss *SentinelStatus) SentinelHosts(shards ...string) (string, error) {
+	if len(ss.Sentinels) == 0 {
+		return "", fmt.Errorf("no sentinel instances reported in status")
+	}
+
+	for _, shard := range shards {
+		if !ss.MonitoredShards.isMonitored(shard) {
+			return "", fmt.Errorf("shard %q is not monitored", shard)
+		}
+	}
+
+	hosts := make([]string, 0, len(ss.Sentinels))
+	for _, s := range ss.Sentinels {
+		hosts = append(hosts, "redis://"+s)
+	}
+	sort.Strings(hosts)
+
+	return strings.Join(hosts, ","), nil
+}
+
 type MonitoredShards []MonitoredShard
 
 // MonitoredShards implements sort.Interface based on the Name field.
@@ -227,6 +258,15 @@ func (ms MonitoredShards) Len() int           { return len(ms) }
 func (ms MonitoredShards) Less(i, j int) bool { return ms[i].Name < ms[j].Name }
 func (ms MonitoredShards) Swap(i, j int)      { ms[i], ms[j] = ms[j], ms[i] }
 
+func (ms MonitoredShards) isMonitored(shard string) bool {
+	for _, s := range ms {
+		if s.Name == shard {
+			return true
+		}
+	}
+	return false
+}
+
 // MonitoredShard contains information of one of the shards
 // monitored by the Sentinel resource
 type MonitoredShard struct {
@@ -256,6 +296,7 @@ type RedisServerDetails struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:JSONPath=".status.sentinels",name=Sentinels,type=string
 // +kubebuilder:printcolumn:JSONPath=".status.monitoredShards",name=Shards,type=string
 // Sentinel is the Schema for the sentinels API
@@ -108,3 +108,64 @@ func TestSentinelStatus_ShardedCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestSentinelStatus_SentinelHosts(t *testing.T) {
+	status := SentinelStatus{
+		Sentinels: []string{"127.0.0.2:26379", "127.0.0.1:26379"},
+		MonitoredShards: []MonitoredShard{
+			{Name: "shard01",
+				Servers: map[string]RedisServerDetails{
+					"srv1": {Role: client.Master, Address: "127.0.0.1:1000"},
+					"srv2": {Role: client.Slave, Address: "127.0.0.1:2000"},
+				}},
+			{Name: "shard02",
+				Servers: map[string]RedisServerDetails{
+					"srv3": {Role: client.Slave, Address: "127.0.0.1:3000"},
+				}},
+		},
+	}
+	tests := []struct {
+		name    string
+		status  SentinelStatus
+		shards  []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Returns the sorted list of sentinel URLs",
+			status: status,
+			shards: []string{"shard01"},
+			want:   "redis://127.0.0.1:26379,redis://127.0.0.2:26379",
+		},
+		{
+			name:    "Fails if the shard is not monitored",
+			status:  status,
+			shards:  []string{"shard01", "shard03"},
+			wantErr: true,
+		},
+		{
+			name:   "Does not fail if the shard has no master during a failover",
+			status: status,
+			shards: []string{"shard01", "shard02"},
+			want:   "redis://127.0.0.1:26379,redis://127.0.0.2:26379",
+		},
+		{
+			name:    "Fails if there are no sentinels",
+			status:  SentinelStatus{MonitoredShards: status.MonitoredShards},
+			shards:  []string{"shard01"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.status.SentinelHosts(tt.shards...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SentinelStatus.SentinelHosts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SentinelStatus.SentinelHosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of Sentinel with the manager
+func (s *Sentinel) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(s).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-sentinel,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=sentinels,verbs=create;update,versions=v1alpha1,name=msentinel.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Sentinel{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-sentinel,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=sentinels,verbs=create;update,versions=v1alpha1,name=vsentinel.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Sentinel{}
+
+// ValidateCreate implements webhook.Validator
+func (s *Sentinel) ValidateCreate() error {
+	return s.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (s *Sentinel) ValidateUpdate(old runtime.Object) error {
+	return s.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (s *Sentinel) ValidateDelete() error {
+	return nil
+}
+
+func (s *Sentinel) validate() error {
+	o := s.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	return invalid("Sentinel", s.GetName(), errs)
+}
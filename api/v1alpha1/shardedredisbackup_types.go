@@ -263,6 +263,7 @@ const (
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 
 // ShardedRedisBackup is the Schema for the shardedredisbackups API
 type ShardedRedisBackup struct {
@@ -0,0 +1,50 @@
+package v1alpha1
+
+import (
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of ShardedRedisBackup with the manager
+func (srb *ShardedRedisBackup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(srb).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-shardedredisbackup,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=shardedredisbackups,verbs=create;update,versions=v1alpha1,name=mshardedredisbackup.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &ShardedRedisBackup{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-shardedredisbackup,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=shardedredisbackups,verbs=create;update,versions=v1alpha1,name=vshardedredisbackup.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ShardedRedisBackup{}
+
+// ValidateCreate implements webhook.Validator
+func (srb *ShardedRedisBackup) ValidateCreate() error {
+	return srb.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (srb *ShardedRedisBackup) ValidateUpdate(old runtime.Object) error {
+	return srb.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (srb *ShardedRedisBackup) ValidateDelete() error {
+	return nil
+}
+
+func (srb *ShardedRedisBackup) validate() error {
+	o := srb.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	if _, err := cron.ParseStandard(o.Spec.Schedule); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "schedule"), o.Spec.Schedule, err.Error()))
+	}
+	return invalid("ShardedRedisBackup", srb.GetName(), errs)
+}
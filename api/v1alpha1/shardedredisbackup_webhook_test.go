@@ -0,0 +1,31 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestShardedRedisBackup_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		wantErr  bool
+	}{
+		{"Valid schedule", "*/15 * * * *", false},
+		{"Valid descriptor", "@hourly", false},
+		{"Invalid schedule", "every hour", true},
+		{"Too many fields", "0 */15 * * * *", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srb := &ShardedRedisBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec:       ShardedRedisBackupSpec{Schedule: tt.schedule},
+			}
+			if err := srb.ValidateCreate(); (err != nil) != tt.wantErr {
+				t.Errorf("ShardedRedisBackup.ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
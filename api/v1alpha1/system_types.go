@@ -17,13 +17,17 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
 	"time"
 
 	"github.com/3scale-ops/basereconciler/util"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -106,6 +110,15 @@ var (
 		MaxUnavailable: util.Pointer(intstr.FromInt(1)),
 	}
 
+	// Database migration
+	systemDefaultDatabaseMigration defaultDatabaseMigrationSpec = defaultDatabaseMigrationSpec{
+		Enabled:               util.Pointer(false),
+		Command:               []string{"container-entrypoint", "bundle", "exec", "rails", "db:migrate"},
+		BackoffLimit:          util.Pointer[int32](0),
+		ActiveDeadlineSeconds: util.Pointer[int64](1800),
+		Resources:             systemDefaultSystemTektonTaskResources,
+	}
+
 	// Sidekiq
 	systemDefaultSidekiqReplicas  int32                           = 2
 	systemDefaultSidekiqResources defaultResourceRequirementsSpec = defaultResourceRequirementsSpec{
@@ -163,6 +176,13 @@ var (
 		},
 		MaxThreads: util.Pointer[int32](15),
 	}
+	// named pools consume the queue with the same name by default
+	systemDefaultSidekiqConfigPool = func(name string) defaultSidekiqConfig {
+		return defaultSidekiqConfig{
+			Queues:     []string{name},
+			MaxThreads: util.Pointer[int32](15),
+		}
+	}
 
 	// Searchd
 	systemDefaultSearchdEnabled bool             = true
@@ -293,10 +313,23 @@ type SystemSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	SidekiqLow *SystemSidekiqSpec `json:"sidekiqLow,omitempty"`
+	// Additional named Sidekiq pools. Each pool is deployed as a separate
+	// "system-sidekiq-<name>" Deployment. The names "default", "billing" and "low"
+	// are reserved for the sidekiqDefault, sidekiqBilling and sidekiqLow pools.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SidekiqPools []SystemSidekiqPoolSpec `json:"sidekiqPools,omitempty"`
 	// Searchd specific configuration options
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Searchd *SystemSearchdSpec `json:"searchd,omitempty"`
+	// Configures the Job that runs the database migrations before the
+	// app and sidekiq Deployments are rolled out to a new image
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	DatabaseMigration *SystemDatabaseMigrationSpec `json:"databaseMigration,omitempty"`
 	// Console specific configuration options
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -341,11 +374,20 @@ func (spec *SystemSpec) Default() {
 	}
 	spec.SidekiqLow.Default(Low)
 
+	for i := range spec.SidekiqPools {
+		spec.SidekiqPools[i].Default()
+	}
+
 	if spec.Searchd == nil {
 		spec.Searchd = &SystemSearchdSpec{}
 	}
 	spec.Searchd.Default()
 
+	if spec.DatabaseMigration == nil {
+		spec.DatabaseMigration = &SystemDatabaseMigrationSpec{}
+	}
+	spec.DatabaseMigration.Default()
+
 	if spec.Console == nil {
 		spec.Console = &SystemRailsConsoleSpec{}
 	}
@@ -530,6 +572,9 @@ func (spec *SystemSpec) ResolveCanarySpec(canary *Canary) (*SystemSpec, error) {
 	canarySpec.SidekiqDefault.Replicas = canary.Replicas
 	canarySpec.SidekiqLow.Replicas = canary.Replicas
 	canarySpec.SidekiqBilling.Replicas = canary.Replicas
+	for i := range canarySpec.SidekiqPools {
+		canarySpec.SidekiqPools[i].Replicas = canary.Replicas
+	}
 
 	// Call Default() on the resolved canary spec to apply
 	// defaulting to potentially added fields
@@ -588,9 +633,45 @@ type RedHatCustomerPortalSpec struct {
 
 // RedisSpec holds redis configuration
 type RedisSpec struct {
-	// Data source name
+	// Data source name. Required unless sentinelRef is set.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	QueuesDSN string `json:"queuesDSN"`
+	// +optional
+	QueuesDSN string `json:"queuesDSN,omitempty"`
+	// SentinelRef configures the redis connection using the sentinel endpoints
+	// and the master name resolved from the status of a Sentinel resource.
+	// Takes precedence over queuesDSN.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SentinelRef *RedisSentinelReference `json:"sentinelRef,omitempty"`
+}
+
+// RedisSentinelReference is a reference to a redis shard monitored by a Sentinel resource
+type RedisSentinelReference struct {
+	SentinelReference `json:",inline"`
+	// Shard is the name of the redis shard, as monitored by the Sentinel resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Shard string `json:"shard"`
+}
+
+// Validate checks that the required fields of the RedisSentinelReference are set
+func (ref *RedisSentinelReference) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if ref.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("name"), ""))
+	}
+	if ref.Shard == "" {
+		errs = append(errs, field.Required(fldPath.Child("shard"), ""))
+	}
+	return errs
+}
+
+// Validate checks that either the queuesDSN or the sentinelRef are set
+func (spec *RedisSpec) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if spec.SentinelRef == nil && spec.QueuesDSN == "" {
+		errs = append(errs, field.Required(fldPath.Child("queuesDSN"), "required unless sentinelRef is set"))
+	}
+	return errs
 }
 
 // SMTPSpec has options to configure system's SMTP
@@ -741,6 +822,9 @@ type SystemAppSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Additional and overridden environment variables for the containers
+	// +optional
+	EnvironmentSpec `json:",inline"`
 }
 
 // Default implements defaulting for the system App component
@@ -807,6 +891,9 @@ type SystemSidekiqSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Additional and overridden environment variables for the containers
+	// +optional
+	EnvironmentSpec `json:",inline"`
 }
 
 // SidekiqConfig configures app behavior for System Sidekiq
@@ -834,8 +921,60 @@ func (cfg *SidekiqConfig) Default(def defaultSidekiqConfig) {
 	cfg.MaxThreads = intOrDefault(cfg.MaxThreads, util.Pointer[int32](*def.MaxThreads))
 }
 
+// sidekiqSuggestedMetrics returns the suggested autoscaling metrics for a sidekiq
+// that consumes the given queues: the number of jobs waiting in the queues
+func sidekiqSuggestedMetrics(queues []string) []autoscalingv2.MetricSpec {
+	names := make([]string, 0, len(queues))
+	for _, q := range queues {
+		names = append(names, strings.SplitN(q, ",", 2)[0])
+	}
+	return []autoscalingv2.MetricSpec{{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "sidekiq_jobs_waiting_count",
+				Selector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "queue",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   names,
+					}},
+				},
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: util.Pointer(resource.MustParse("10")),
+			},
+		},
+	}}
+}
+
+// SuggestedMetrics returns the metrics suggested to autoscale the
+// sidekiq, which depend on the queues it consumes
+func (spec *SystemSidekiqSpec) SuggestedMetrics() []autoscalingv2.MetricSpec {
+	if spec.Config == nil {
+		return nil
+	}
+	return sidekiqSuggestedMetrics(spec.Config.Queues)
+}
+
 // Default implements defaulting for the system Sidekiq component
 func (spec *SystemSidekiqSpec) Default(sidekiqType systemSidekiqType) {
+	if spec.Config == nil {
+		spec.Config = &SidekiqConfig{}
+	}
+
+	switch sidekiqType {
+	case Default:
+		spec.Config.Default(systemDefaultSidekiqConfigDefault)
+	case Billing:
+		spec.Config.Default(systemDefaultSidekiqConfigBilling)
+	case Low:
+		spec.Config.Default(systemDefaultSidekiqConfigLow)
+	default:
+		spec.Config.Default(systemDefaultSidekiqConfigPool(string(sidekiqType)))
+	}
+
 	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, systemDefaultSidekiqDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, systemDefaultSidekiqHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &systemDefaultSidekiqReplicas)
@@ -846,18 +985,65 @@ func (spec *SystemSidekiqSpec) Default(sidekiqType systemSidekiqType) {
 	spec.TerminationGracePeriodSeconds = int64OrDefault(
 		spec.TerminationGracePeriodSeconds, systemDefaultTerminationGracePeriodSeconds,
 	)
+}
 
-	if spec.Config == nil {
-		spec.Config = &SidekiqConfig{}
+// SystemSidekiqPoolSpec configures a named pool of Sidekiq workers of System
+type SystemSidekiqPoolSpec struct {
+	// The name of the pool. The pool consumes the queue with the
+	// same name unless a list of queues is explicitly configured.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength:=40
+	Name string `json:"name"`
+	// Sidekiq configuration options for the pool
+	// +optional
+	SystemSidekiqSpec `json:",inline"`
+}
+
+// Default implements defaulting for a named Sidekiq pool
+func (spec *SystemSidekiqPoolSpec) Default() {
+	spec.SystemSidekiqSpec.Default(systemSidekiqType(spec.Name))
+}
+
+// SidekiqPool returns the spec of the Sidekiq pool with the given name, be it
+// one of the sidekiqDefault, sidekiqBilling and sidekiqLow pools or one of
+// the pools in sidekiqPools. Returns nil if the pool does not exist.
+func (spec *SystemSpec) SidekiqPool(name string) *SystemSidekiqSpec {
+	switch systemSidekiqType(name) {
+	case Default:
+		return spec.SidekiqDefault
+	case Billing:
+		return spec.SidekiqBilling
+	case Low:
+		return spec.SidekiqLow
 	}
+	for i := range spec.SidekiqPools {
+		if spec.SidekiqPools[i].Name == name {
+			return &spec.SidekiqPools[i].SystemSidekiqSpec
+		}
+	}
+	return nil
+}
 
-	if sidekiqType == Billing {
-		spec.Config.Default(systemDefaultSidekiqConfigBilling)
-	} else if sidekiqType == Low {
-		spec.Config.Default(systemDefaultSidekiqConfigLow)
-	} else {
-		spec.Config.Default(systemDefaultSidekiqConfigDefault)
+// Validate checks that the names of the Sidekiq pools are unique
+// and do not collide with the reserved pool names
+func (spec *SystemSpec) Validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	names := map[string]bool{}
+	for i, pool := range spec.SidekiqPools {
+		path := fldPath.Child("sidekiqPools").Index(i).Child("name")
+		switch systemSidekiqType(pool.Name) {
+		case Default, Billing, Low:
+			errs = append(errs, field.Invalid(path, pool.Name,
+				"name is reserved, use sidekiqDefault, sidekiqBilling or sidekiqLow instead"))
+		default:
+			if names[pool.Name] {
+				errs = append(errs, field.Duplicate(path, pool.Name))
+			}
+		}
+		names[pool.Name] = true
 	}
+	return errs
 }
 
 // SystemSearchdSpec configures the App component of System
@@ -897,6 +1083,10 @@ type SystemSearchdSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Configures how changes are rolled out to the pods of the StatefulSet
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Rollout *StatefulSetRolloutSpec `json:"rollout,omitempty"`
 }
 
 // Default implements defaulting for the system searchd component
@@ -950,6 +1140,44 @@ func (sc *SearchdConfig) Default() {
 	}
 }
 
+// SystemDatabaseMigrationSpec configures the Job that runs the database migrations of System
+type SystemDatabaseMigrationSpec struct {
+	DatabaseMigrationSpec `json:",inline"`
+	// Canaries configures whether the database migrations must run with the canary
+	// image before the canary Deployments are rolled out to it. Defaults to "Require".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum=Require;Skip
+	// +optional
+	Canaries *CanaryDatabaseMigrationPolicy `json:"canaries,omitempty"`
+}
+
+// Default implements defaulting for SystemDatabaseMigrationSpec
+func (spec *SystemDatabaseMigrationSpec) Default() {
+	spec.DatabaseMigrationSpec.Default(systemDefaultDatabaseMigration)
+	if spec.Canaries == nil {
+		spec.Canaries = util.Pointer(CanaryDatabaseMigrationRequire)
+	}
+}
+
+// SkipsCanaries returns true if the canaries are
+// rolled out without running the database migrations
+func (spec *SystemDatabaseMigrationSpec) SkipsCanaries() bool {
+	return spec != nil && spec.Canaries != nil && *spec.Canaries == CanaryDatabaseMigrationSkip
+}
+
+// CanaryDatabaseMigrationPolicy configures whether the database
+// migrations run before the canary is rolled out
+type CanaryDatabaseMigrationPolicy string
+
+const (
+	// CanaryDatabaseMigrationRequire runs the database migrations with
+	// the canary image before the canary Deployment is rolled out to it
+	CanaryDatabaseMigrationRequire CanaryDatabaseMigrationPolicy = "Require"
+	// CanaryDatabaseMigrationSkip rolls out the canary Deployment
+	// without running the database migrations
+	CanaryDatabaseMigrationSkip CanaryDatabaseMigrationPolicy = "Skip"
+)
+
 // SystemRailsConsoleSpec configures the App component of System
 type SystemRailsConsoleSpec struct {
 	// Image specification for the Console component.
@@ -967,6 +1195,13 @@ type SystemRailsConsoleSpec struct {
 	// If specified, the pod's tolerations.
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty" protobuf:"bytes,22,opt,name=tolerations"`
+	// Configures how changes are rolled out to the pods of the StatefulSet
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Rollout *StatefulSetRolloutSpec `json:"rollout,omitempty"`
+	// Additional and overridden environment variables for the containers
+	// +optional
+	EnvironmentSpec `json:",inline"`
 }
 
 // Default implements defaulting for the system App component
@@ -976,10 +1211,24 @@ func (spec *SystemRailsConsoleSpec) Default(systemDefaultImage *ImageSpec) {
 }
 
 // SystemStatus defines the observed state of System
-type SystemStatus struct{}
+type SystemStatus struct {
+	WorkloadsStatus `json:",inline"`
+	// The status of the database migrations of the images pending to be rolled out
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +listType=map
+	// +listMapKey=image
+	// +optional
+	DatabaseMigrations []DatabaseMigrationStatus `json:"databaseMigrations,omitempty"`
+}
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Ready")].status`,name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Progressing")].status`,name=Progressing,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,name=Degraded,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.workloads[0].deployment.image`,name=Image,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name=Age,type=date
 
 // System is the Schema for the systems API
 type System struct {
@@ -995,6 +1244,71 @@ func (s *System) Default() {
 	s.Spec.Default()
 }
 
+// GetWorkloadsStatus returns the status of the workloads of the System resource
+func (s *System) GetWorkloadsStatus() *WorkloadsStatus {
+	return &s.Status.WorkloadsStatus
+}
+
+// GetDatabaseMigrationsStatus returns the status of the database migrations of the System resource
+func (s *System) GetDatabaseMigrationsStatus() *[]DatabaseMigrationStatus {
+	return &s.Status.DatabaseMigrations
+}
+
+// CanarySpecDiff returns the changes that the given canary
+// applies to the spec, as a JSON merge patch
+func (s *System) CanarySpecDiff(canary *Canary) (string, error) {
+	canarySpec, err := s.Spec.ResolveCanarySpec(canary)
+	if err != nil {
+		return "", err
+	}
+	return canarySpecDiff(s.Spec, canarySpec)
+}
+
+// SentinelKeys returns the keys of the Sentinel resources referenced
+// by the System, including the ones referenced by its canaries
+func (s *System) SentinelKeys() []types.NamespacedName {
+	specs := []*SystemSpec{&s.Spec}
+	canaries := []*Canary{}
+	if s.Spec.App != nil {
+		canaries = append(canaries, s.Spec.App.Canary)
+	}
+	for _, sidekiq := range []*SystemSidekiqSpec{s.Spec.SidekiqDefault, s.Spec.SidekiqBilling, s.Spec.SidekiqLow} {
+		if sidekiq != nil {
+			canaries = append(canaries, sidekiq.Canary)
+		}
+	}
+	for _, pool := range s.Spec.SidekiqPools {
+		canaries = append(canaries, pool.Canary)
+	}
+	for _, canary := range canaries {
+		if canary == nil {
+			continue
+		}
+		if canarySpec, err := s.Spec.ResolveCanarySpec(canary); err == nil {
+			specs = append(specs, canarySpec)
+		}
+	}
+
+	keys := []types.NamespacedName{}
+	for _, spec := range specs {
+		if spec.Config.Redis.SentinelRef != nil {
+			keys = appendKeyIfMissing(keys, spec.Config.Redis.SentinelRef.ObjectKey(s.GetNamespace()))
+		}
+	}
+	return keys
+}
+
+// UsesSentinel returns true if the Sentinel resource identified by the
+// given key is used to resolve the redis endpoints of the System
+func (s *System) UsesSentinel(key types.NamespacedName) bool {
+	for _, k := range s.SentinelKeys() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 // +kubebuilder:object:root=true
 
 // SystemList contains a list of System
@@ -0,0 +1,153 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	"github.com/go-test/deep"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestSystemSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		pools []SystemSidekiqPoolSpec
+		want  []string
+	}{
+		{
+			name:  "Valid pools",
+			pools: []SystemSidekiqPoolSpec{{Name: "mailers"}, {Name: "webhooks"}},
+			want:  []string{},
+		},
+		{
+			name:  "Reserved pool names",
+			pools: []SystemSidekiqPoolSpec{{Name: "mailers"}, {Name: "billing"}},
+			want:  []string{"spec.sidekiqPools[1].name"},
+		},
+		{
+			name:  "Duplicated pool names",
+			pools: []SystemSidekiqPoolSpec{{Name: "mailers"}, {Name: "webhooks"}, {Name: "mailers"}},
+			want:  []string{"spec.sidekiqPools[2].name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &SystemSpec{SidekiqPools: tt.pools}
+			errs := spec.Validate(field.NewPath("spec"))
+			got := make([]string, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SystemSpec.Validate() = %v, want errors in fields %v", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestSystemSpec_SidekiqPool(t *testing.T) {
+	spec := &SystemSpec{
+		SidekiqPools: []SystemSidekiqPoolSpec{
+			{Name: "mailers", SystemSidekiqSpec: SystemSidekiqSpec{Config: &SidekiqConfig{MaxThreads: util.Pointer[int32](5)}}},
+			{Name: "webhooks"},
+		},
+	}
+	spec.Default()
+
+	tests := []struct {
+		name        string
+		pool        string
+		wantQueues  []string
+		wantThreads int32
+	}{
+		{
+			name:        "Aliases the sidekiqDefault pool",
+			pool:        "default",
+			wantQueues:  systemDefaultSidekiqConfigDefault.Queues,
+			wantThreads: 15,
+		},
+		{
+			name:        "Aliases the sidekiqBilling pool",
+			pool:        "billing",
+			wantQueues:  []string{"billing"},
+			wantThreads: 15,
+		},
+		{
+			name:        "Named pool with explicit config",
+			pool:        "mailers",
+			wantQueues:  []string{"mailers"},
+			wantThreads: 5,
+		},
+		{
+			name:        "Named pool defaults its queues to the pool name",
+			pool:        "webhooks",
+			wantQueues:  []string{"webhooks"},
+			wantThreads: 15,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spec.SidekiqPool(tt.pool)
+			if got == nil {
+				t.Fatalf("SystemSpec.SidekiqPool() = nil")
+			}
+			if !reflect.DeepEqual(got.Config.Queues, tt.wantQueues) {
+				t.Errorf("SystemSpec.SidekiqPool().Config.Queues = %v, want %v", got.Config.Queues, tt.wantQueues)
+			}
+			if *got.Config.MaxThreads != tt.wantThreads {
+				t.Errorf("SystemSpec.SidekiqPool().Config.MaxThreads = %v, want %v", *got.Config.MaxThreads, tt.wantThreads)
+			}
+		})
+	}
+
+	if got := spec.SidekiqPool("unknown"); got != nil {
+		t.Errorf("SystemSpec.SidekiqPool() = %v, want nil", got)
+	}
+}
+
+func Test_sidekiqSuggestedMetrics(t *testing.T) {
+	tests := []struct {
+		name   string
+		queues []string
+		want   []string
+	}{
+		{
+			name:   "Selects the given queues",
+			queues: []string{"mailers", "webhooks"},
+			want:   []string{"mailers", "webhooks"},
+		},
+		{
+			name:   "Removes the weights of the queues",
+			queues: []string{"critical,8", "backend_sync,4", "default"},
+			want:   []string{"critical", "backend_sync", "default"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "sidekiq_jobs_waiting_count",
+						Selector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "queue", Operator: metav1.LabelSelectorOpIn, Values: tt.want},
+							},
+						},
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: util.Pointer(resource.MustParse("10")),
+					},
+				},
+			}}
+			if diff := deep.Equal(sidekiqSuggestedMetrics(tt.queues), want); len(diff) > 0 {
+				t.Errorf("sidekiqSuggestedMetrics() = diff %v", diff)
+			}
+		})
+	}
+}
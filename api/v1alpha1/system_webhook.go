@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of System with the manager
+func (s *System) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(s).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-system,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=systems,verbs=create;update,versions=v1alpha1,name=msystem.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &System{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-system,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=systems,verbs=create;update,versions=v1alpha1,name=vsystem.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &System{}
+
+// ValidateCreate implements webhook.Validator
+func (s *System) ValidateCreate() error {
+	return s.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (s *System) ValidateUpdate(old runtime.Object) error {
+	return s.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (s *System) ValidateDelete() error {
+	return nil
+}
+
+func (s *System) validate() error {
+	o := s.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	canaries := []canaryField{
+		{field.NewPath("spec", "app", "canary"), o.Spec.App.Canary},
+		{field.NewPath("spec", "sidekiqDefault", "canary"), o.Spec.SidekiqDefault.Canary},
+		{field.NewPath("spec", "sidekiqBilling", "canary"), o.Spec.SidekiqBilling.Canary},
+		{field.NewPath("spec", "sidekiqLow", "canary"), o.Spec.SidekiqLow.Canary},
+	}
+	for i, pool := range o.Spec.SidekiqPools {
+		canaries = append(canaries, canaryField{field.NewPath("spec", "sidekiqPools").Index(i).Child("canary"), pool.Canary})
+	}
+	errs = append(errs, validateCanaries(&o.Spec, o.Spec.ResolveCanarySpec, canaries...)...)
+	return invalid("System", s.GetName(), errs)
+}
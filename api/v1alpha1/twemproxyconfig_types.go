@@ -20,13 +20,18 @@ import (
 	"fmt"
 
 	"github.com/3scale-ops/basereconciler/util"
+	envoyconfig "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
 	TwemproxyPodSyncLabelKey   string = fmt.Sprintf("%s/twemproxyconfig.sync", GroupVersion.Group)
 	TwemproxySyncAnnotationKey string = fmt.Sprintf("%s/twemproxyconfig.configmap-hash", GroupVersion.Group)
+	// TwemproxyServerPoolsAnnotationKey is the annotation of the managed ConfigMap that
+	// stores the server pools used to generate it
+	TwemproxyServerPoolsAnnotationKey string = fmt.Sprintf("%s/twemproxyconfig.server-pools", GroupVersion.Group)
 
 	twemproxyDefaultGrafanaDashboard defaultGrafanaDashboardSpec = defaultGrafanaDashboardSpec{
 		SelectorKey:   util.Pointer("monitoring-key"),
@@ -36,12 +41,15 @@ var (
 
 // TwemproxyConfigSpec defines the desired state of TwemproxyConfig
 type TwemproxyConfigSpec struct {
-	// SentinelURI is the redis URI of sentinel. If not set, the controller
-	// will try to autodiscover Sentinel within the namespace.
-	// TODO: remove, unused
+	// SentinelRefs is the list of Sentinel resources that monitor the
+	// shards targeted by the server pools. The Sentinel endpoints are
+	// resolved from the status of each of the Sentinel resources. Several
+	// references can be used in split clusters, where each Sentinel set
+	// monitors a different subset of the shards. If not set, the controller
+	// will try to autodiscover a Sentinel resource within the namespace.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
-	SentinelURIs []string `json:"sentinelURIs,omitempty"`
+	SentinelRefs []SentinelReference `json:"sentinelRefs,omitempty"`
 	// ServerPools is the list of Twemproxy server pools
 	// WARNING: only 1 pool is supported at this time
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -53,6 +61,24 @@ type TwemproxyConfigSpec struct {
 	// are changed, even if they are manually changed.
 	// This switch defaults to "true".
 	ReconcileServerPools *bool `json:"reconcileServerPools,omitempty"`
+	// Preview configures the preview mode. When the preview mode is enabled,
+	// changes in the server pools are not directly applied to the managed
+	// ConfigMap. A preview of the changes is published in the status instead
+	// and the changes are only promoted to the ConfigMap once approved. Changes
+	// in the targeted servers due to Sentinel failovers are always applied.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Preview *TwemproxyConfigPreviewSpec `json:"preview,omitempty"`
+	// EnvoyRedisProxy enables the generation of an Envoy configuration equivalent
+	// to the twemproxy one, as an alternative to twemproxy sidecars. Each server pool
+	// is rendered as a listener using the Envoy redis_proxy filter and a cluster
+	// with the targeted servers. The configuration is delivered through a marin3r
+	// EnvoyConfig resource with the same name as the TwemproxyConfig.
+	// NOTE: Envoy does not hash keys in the same way twemproxy does, so keys are
+	// distributed differently across the logical shards of a server pool.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	EnvoyRedisProxy *EnvoyRedisProxySpec `json:"envoyRedisProxy,omitempty"`
 	// Configures the Grafana Dashboard for the component
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -69,6 +95,28 @@ func (spec *TwemproxyConfigSpec) Default() {
 	spec.GrafanaDashboard = InitializeGrafanaDashboardSpec(spec.GrafanaDashboard, twemproxyDefaultGrafanaDashboard)
 }
 
+// TwemproxyConfigPreviewSpec configures the preview mode of a TwemproxyConfig
+type TwemproxyConfigPreviewSpec struct {
+	// Enabled activates the preview mode
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Enabled bool `json:"enabled"`
+	// ApprovedHash approves the promotion of the preview identified
+	// by the given hash, as reported in the status of the resource.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ApprovedHash *string `json:"approvedHash,omitempty"`
+}
+
+// IsEnabled returns true if the preview mode is enabled
+func (spec *TwemproxyConfigPreviewSpec) IsEnabled() bool {
+	return spec != nil && spec.Enabled
+}
+
+// IsApproved returns true if the preview identified by the given hash has been approved
+func (spec *TwemproxyConfigPreviewSpec) IsApproved(hash string) bool {
+	return spec != nil && spec.ApprovedHash != nil && *spec.ApprovedHash == hash
+}
+
 type TwemproxyServerPool struct {
 	// The name of the server pool
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -129,14 +177,172 @@ type ShardedRedisTopology struct {
 	PhysicalShard string `json:"physicalShard"`
 }
 
+// EnvoyRedisProxySpec configures the generation of Envoy redis_proxy
+// configuration for the server pools of a TwemproxyConfig
+type EnvoyRedisProxySpec struct {
+	// The NodeID that identifies the Envoy proxies that
+	// consume the configuration
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeID string `json:"nodeID"`
+}
+
+// EnvoyRedisProxyDynamicConfig describes one of the Envoy dynamic configurations
+// generated for the server pools of a TwemproxyConfig. It is not part of the
+// API of any resource, as the configurations are derived from the server pools.
+type EnvoyRedisProxyDynamicConfig struct {
+	// hidden field
+	Name string `json:"-"`
+	// GeneratorVersion specifies the version of a given template
+	GeneratorVersion *string `json:"generatorVersion,omitempty"`
+	// ListenerRedisProxy contains options for a listener that proxies
+	// redis commands using the Envoy redis_proxy network filter
+	ListenerRedisProxy *ListenerRedisProxy `json:"listenerRedisProxy,omitempty"`
+	// ClusterShardedRedis contains options for an Envoy cluster that
+	// distributes redis keys across a set of shards
+	ClusterShardedRedis *ClusterShardedRedis `json:"clusterShardedRedis,omitempty"`
+}
+
+// AsEnvoyDynamicConfigDescriptor converts the type into the internal EnvoyDynamicConfigDescriptor
+// interface. The name field is populated with the parameter passed to the function.
+func (config *EnvoyRedisProxyDynamicConfig) AsEnvoyDynamicConfigDescriptor(name string) envoyconfig.EnvoyDynamicConfigDescriptor {
+	config.Name = name
+	return config
+}
+
+func (config *EnvoyRedisProxyDynamicConfig) GetName() string {
+	return config.Name
+}
+
+// GetGeneratorVersion returns the template's version
+func (config *EnvoyRedisProxyDynamicConfig) GetGeneratorVersion() string {
+	return *config.GeneratorVersion
+}
+
+func (config *EnvoyRedisProxyDynamicConfig) GetOptions() interface{} {
+	if config.ListenerRedisProxy != nil {
+		return config.ListenerRedisProxy
+	} else if config.ClusterShardedRedis != nil {
+		return config.ClusterShardedRedis
+	}
+
+	return nil
+}
+
+// ListenerRedisProxy contains options for a listener that proxies
+// redis commands using the Envoy redis_proxy network filter
+type ListenerRedisProxy struct {
+	// The address where the listener listens for new connections.
+	// Defaults to "0.0.0.0".
+	Address *string `json:"address,omitempty"`
+	// The port where the listener listens for new connections
+	Port uint32 `json:"port"`
+	// The name of the cluster where redis commands are sent to
+	ClusterName string `json:"clusterName"`
+	// Max time to wait for the response of a redis command
+	OpTimeout metav1.Duration `json:"opTimeout"`
+}
+
+// ClusterShardedRedis contains options for an Envoy cluster that
+// distributes redis keys across a set of shards. Keys are consistently
+// hashed to the shards using the name of the shard, so the distribution
+// of keys does not change when the server of a shard does.
+type ClusterShardedRedis struct {
+	// The list of shards of the cluster
+	Shards []RedisShardEndpoint `json:"shards"`
+}
+
+// RedisShardEndpoint is a shard of a ClusterShardedRedis
+type RedisShardEndpoint struct {
+	// The name of the shard
+	Name string `json:"name"`
+	// The IP address of the redis server that holds the shard
+	Host string `json:"host"`
+	// The port of the redis server that holds the shard
+	Port uint32 `json:"port"`
+}
+
 // TwemproxyConfigStatus defines the observed state of TwemproxyConfig
 type TwemproxyConfigStatus struct {
-	// The list of serves currently targeted by this TwemproxyConfig
+	// The list of serves currently targeted by the first server pool of this
+	// TwemproxyConfig. Kept for backwards compatibility, see 'serverPools' for
+	// the servers targeted by each of the server pools.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	SelectedTargets map[string]TargetServer `json:"targets,omitempty"`
+	// ServerPools is the list of servers currently targeted by each server pool
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ServerPools []ServerPoolStatus `json:"serverPools,omitempty"`
+	// Preview holds the changes in the server pools that are pending
+	// approval. Only used when the preview mode is enabled.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Preview *TwemproxyConfigPreview `json:"preview,omitempty"`
+}
+
+// ServerPoolStatus describes the servers targeted by a server pool
+type ServerPoolStatus struct {
+	// The name of the server pool
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// The list of servers currently targeted by the server pool
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	// +optional
 	SelectedTargets map[string]TargetServer `json:"targets,omitempty"`
 }
 
+// TwemproxyConfigPreview describes the changes between the server
+// pools currently applied and the ones in the spec of the resource
+type TwemproxyConfigPreview struct {
+	// Hash identifies the previewed change from the current to the desired
+	// server pools. Set it in 'spec.preview.approvedHash' to promote the changes.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Hash string `json:"hash"`
+	// ServerPools is the list of changes for each server pool
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ServerPools []ServerPoolPreview `json:"serverPools,omitempty"`
+}
+
+// ServerPoolPreview describes the changes in a server pool
+type ServerPoolPreview struct {
+	// The name of the server pool
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Logical shards added to the server pool
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Added []string `json:"added,omitempty"`
+	// Logical shards removed from the server pool
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Removed []string `json:"removed,omitempty"`
+	// Logical shards that are moved to a different physical shard
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Remapped []ShardRemap `json:"remapped,omitempty"`
+	// KeysAffected is the estimated percentage of keys that would be
+	// routed to a different server. It is computed from the difference
+	// between the current and the desired ketama hash rings.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	KeysAffected string `json:"keysAffected"`
+}
+
+// ShardRemap describes a logical shard that changes its physical shard
+type ShardRemap struct {
+	// The name of the logical shard
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ShardName string `json:"shardName"`
+	// The physical shard currently holding the logical shard
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	From string `json:"from"`
+	// The physical shard that will hold the logical shard
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	To string `json:"to"`
+}
+
 // Defines a server targeted by one of the TwemproxyConfig server pools
 type TargetServer struct {
 	ServerAlias   *string `json:"serverAlias,omitempty"`
@@ -145,7 +351,9 @@ type TargetServer struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:JSONPath=`.status.targets`,name=Selected Targets,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.preview.hash`,name=Pending Preview,type=string
 // TwemproxyConfig is the Schema for the twemproxyconfigs API
 type TwemproxyConfig struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -159,6 +367,35 @@ func (tc *TwemproxyConfig) Default() {
 	tc.Spec.Default()
 }
 
+// SentinelKeys returns the keys of the Sentinel resources referenced by the
+// TwemproxyConfig. It returns nil if Sentinel autodiscovery is used.
+func (tc *TwemproxyConfig) SentinelKeys() []types.NamespacedName {
+	if len(tc.Spec.SentinelRefs) == 0 {
+		return nil
+	}
+	keys := make([]types.NamespacedName, 0, len(tc.Spec.SentinelRefs))
+	for _, ref := range tc.Spec.SentinelRefs {
+		keys = append(keys, ref.ObjectKey(tc.GetNamespace()))
+	}
+	return keys
+}
+
+// UsesSentinel returns true if the Sentinel resource identified by the given key
+// is one of the Sentinels used by the TwemproxyConfig to discover its targets
+func (tc *TwemproxyConfig) UsesSentinel(key types.NamespacedName) bool {
+	keys := tc.SentinelKeys()
+	// autodiscovery uses any Sentinel within the namespace
+	if keys == nil {
+		return key.Namespace == tc.GetNamespace()
+	}
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 func (tc *TwemproxyConfig) PodSyncSelector() client.MatchingLabels {
 	return client.MatchingLabels{
 		TwemproxyPodSyncLabelKey: util.ObjectKey(tc).Name,
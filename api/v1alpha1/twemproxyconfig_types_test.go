@@ -0,0 +1,79 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestTwemproxyConfig_UsesSentinel(t *testing.T) {
+	tests := []struct {
+		name string
+		spec TwemproxyConfigSpec
+		key  types.NamespacedName
+		want bool
+	}{
+		{
+			name: "Autodiscovery uses Sentinels in the same namespace",
+			spec: TwemproxyConfigSpec{},
+			key:  types.NamespacedName{Name: "sentinel", Namespace: "ns"},
+			want: true,
+		},
+		{
+			name: "Autodiscovery does not use Sentinels in other namespaces",
+			spec: TwemproxyConfigSpec{},
+			key:  types.NamespacedName{Name: "sentinel", Namespace: "other"},
+			want: false,
+		},
+		{
+			name: "Reference without namespace defaults to the TwemproxyConfig namespace",
+			spec: TwemproxyConfigSpec{SentinelRefs: []SentinelReference{{Name: "sentinel"}}},
+			key:  types.NamespacedName{Name: "sentinel", Namespace: "ns"},
+			want: true,
+		},
+		{
+			name: "Cross-namespace reference",
+			spec: TwemproxyConfigSpec{SentinelRefs: []SentinelReference{
+				{Name: "sentinel-a"},
+				{Name: "sentinel-b", Namespace: util.Pointer("other")},
+			}},
+			key:  types.NamespacedName{Name: "sentinel-b", Namespace: "other"},
+			want: true,
+		},
+		{
+			name: "Not referenced",
+			spec: TwemproxyConfigSpec{SentinelRefs: []SentinelReference{{Name: "sentinel-a"}}},
+			key:  types.NamespacedName{Name: "sentinel-b", Namespace: "ns"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := &TwemproxyConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+				Spec:       tt.spec,
+			}
+			if got := tc.UsesSentinel(tt.key); got != tt.want {
+				t.Errorf("TwemproxyConfig.UsesSentinel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
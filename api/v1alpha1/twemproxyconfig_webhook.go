@@ -0,0 +1,119 @@
+package v1alpha1
+
+import (
+	"context"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of TwemproxyConfig with the manager.
+// The validating webhook reads the Sentinel resources to validate the topology
+// of the server pools.
+func (tc *TwemproxyConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(tc).
+		WithValidator(&twemproxyConfigValidator{client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-twemproxyconfig,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=twemproxyconfigs,verbs=create;update,versions=v1alpha1,name=mtwemproxyconfig.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &TwemproxyConfig{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-twemproxyconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=twemproxyconfigs,verbs=create;update,versions=v1alpha1,name=vtwemproxyconfig.saas.3scale.net,admissionReviewVersions=v1
+
+// twemproxyConfigValidator validates TwemproxyConfig resources
+type twemproxyConfigValidator struct {
+	client client.Reader
+}
+
+var _ webhook.CustomValidator = &twemproxyConfigValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *twemproxyConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(ctx, obj.(*TwemproxyConfig))
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *twemproxyConfigValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return v.validate(ctx, newObj.(*TwemproxyConfig))
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *twemproxyConfigValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *twemproxyConfigValidator) validate(ctx context.Context, tc *TwemproxyConfig) error {
+	o := tc.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	topologyErrs, err := o.validateTopology(ctx, v.client)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	errs = append(errs, topologyErrs...)
+	return invalid("TwemproxyConfig", tc.GetName(), errs)
+}
+
+// validateTopology checks that the physical shards of the server pools are monitored
+// by the Sentinel resources used by the TwemproxyConfig. The check is skipped if the
+// Sentinel resources cannot be found, as they might be created after the TwemproxyConfig.
+func (tc *TwemproxyConfig) validateTopology(ctx context.Context, cl client.Reader) (field.ErrorList, error) {
+	errs := field.ErrorList{}
+
+	keys := tc.SentinelKeys()
+	if keys == nil {
+		sl := &SentinelList{}
+		if err := cl.List(ctx, sl, client.InNamespace(tc.GetNamespace())); err != nil {
+			return nil, err
+		}
+		if len(sl.Items) != 1 {
+			return errs, nil
+		}
+		keys = []types.NamespacedName{client.ObjectKeyFromObject(&sl.Items[0])}
+	}
+
+	shards := map[string]bool{}
+	for _, key := range keys {
+		s := &Sentinel{}
+		if err := cl.Get(ctx, key, s); err != nil {
+			if apierrors.IsNotFound(err) {
+				return errs, nil
+			}
+			return nil, err
+		}
+		if s.Spec.Config == nil {
+			return errs, nil
+		}
+		for name := range s.Spec.Config.MonitoredShards {
+			shards[name] = true
+		}
+	}
+
+	monitored := make([]string, 0, len(shards))
+	for name := range shards {
+		monitored = append(monitored, name)
+	}
+	sort.Strings(monitored)
+
+	for i, pool := range tc.Spec.ServerPools {
+		for j, topology := range pool.Topology {
+			if !shards[topology.PhysicalShard] {
+				errs = append(errs, field.NotSupported(
+					field.NewPath("spec", "serverPools").Index(i).Child("topology").Index(j).Child("physicalShard"),
+					topology.PhysicalShard, monitored))
+			}
+		}
+	}
+
+	return errs, nil
+}
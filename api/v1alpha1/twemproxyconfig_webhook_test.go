@@ -0,0 +1,104 @@
+package v1alpha1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTwemproxyConfig_validateTopology(t *testing.T) {
+	tests := []struct {
+		name      string
+		refs      []SentinelReference
+		sentinels []client.Object
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "All physical shards are monitored by the autodiscovered Sentinel",
+			refs:      nil,
+			sentinels: []client.Object{testSentinel("sentinel", "shard01", "shard02")},
+			want:      []string{},
+		},
+		{
+			name:      "Reports physical shards not monitored by the autodiscovered Sentinel",
+			refs:      nil,
+			sentinels: []client.Object{testSentinel("sentinel", "shard01")},
+			want:      []string{"spec.serverPools[0].topology[1].physicalShard"},
+		},
+		{
+			name: "Uses the union of the shards of the referenced Sentinels",
+			refs: []SentinelReference{{Name: "sentinel-a"}, {Name: "sentinel-b"}},
+			sentinels: []client.Object{
+				testSentinel("sentinel-a", "shard01"),
+				testSentinel("sentinel-b", "shard02"),
+				testSentinel("sentinel-c", "shard03"),
+			},
+			want: []string{},
+		},
+		{
+			name:      "Skips the check if a referenced Sentinel does not exist",
+			refs:      []SentinelReference{{Name: "sentinel-a"}, {Name: "missing", Namespace: util.Pointer("other")}},
+			sentinels: []client.Object{testSentinel("sentinel-a")},
+			want:      []string{},
+		},
+		{
+			name:      "Skips the check if the Sentinel cannot be autodiscovered",
+			refs:      nil,
+			sentinels: []client.Object{testSentinel("sentinel-a"), testSentinel("sentinel-b")},
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			cl := fake.NewClientBuilder().WithScheme(s).WithObjects(tt.sentinels...).Build()
+
+			tc := &TwemproxyConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: TwemproxyConfigSpec{
+					SentinelRefs: tt.refs,
+					ServerPools: []TwemproxyServerPool{{
+						Name: "pool",
+						Topology: []ShardedRedisTopology{
+							{ShardName: "l-shard01", PhysicalShard: "shard01"},
+							{ShardName: "l-shard02", PhysicalShard: "shard02"},
+						},
+					}},
+				},
+			}
+			errs, err := tc.validateTopology(context.TODO(), cl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TwemproxyConfig.validateTopology() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := make([]string, 0, len(errs))
+			for _, e := range errs {
+				got = append(got, e.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TwemproxyConfig.validateTopology() = %v, want errors in fields %v", errs, tt.want)
+			}
+		})
+	}
+}
+
+func testSentinel(name string, shards ...string) *Sentinel {
+	monitored := map[string][]string{}
+	for _, shard := range shards {
+		monitored[shard] = []string{"redis://127.0.0.1:6379"}
+	}
+	return &Sentinel{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       SentinelSpec{Config: &SentinelConfig{MonitoredShards: monitored}},
+	}
+}
@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validator is implemented by the API types that validate themselves
+type validator interface {
+	Validate(fldPath *field.Path) field.ErrorList
+}
+
+// validateSpec walks the given spec and runs the validations of all the
+// types of this API found within it. The spec must be passed as a pointer.
+func validateSpec(spec interface{}, fldPath *field.Path) field.ErrorList {
+	return validateValue(reflect.ValueOf(spec), fldPath)
+}
+
+func validateValue(v reflect.Value, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			errs = append(errs, validateValue(v.Elem(), fldPath)...)
+		}
+
+	case reflect.Struct:
+		// types from other APIs are validated by the API server
+		if v.Type().PkgPath() != reflect.TypeOf(SecretReference{}).PkgPath() {
+			return errs
+		}
+		if v.CanAddr() {
+			if val, ok := v.Addr().Interface().(validator); ok {
+				errs = append(errs, val.Validate(fldPath)...)
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, inline := jsonFieldName(f)
+			if name == "-" {
+				continue
+			}
+			path := fldPath
+			if !inline {
+				path = fldPath.Child(name)
+			}
+			errs = append(errs, validateValue(v.Field(i), path)...)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			errs = append(errs, validateValue(v.Index(i), fldPath.Index(i))...)
+		}
+
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// map values are not addressable, so a copy is validated
+			value := reflect.New(iter.Value().Type())
+			value.Elem().Set(iter.Value())
+			errs = append(errs, validateValue(value, fldPath.Key(fmt.Sprint(iter.Key().Interface())))...)
+		}
+	}
+
+	return errs
+}
+
+// jsonFieldName returns the name of the field in its JSON representation
+// and whether the field is inlined in its parent
+func jsonFieldName(f reflect.StructField) (string, bool) {
+	opts := strings.Split(f.Tag.Get("json"), ",")
+	for _, opt := range opts[1:] {
+		if opt == "inline" {
+			return "", true
+		}
+	}
+	if opts[0] == "" {
+		if f.Anonymous {
+			return "", true
+		}
+		return f.Name, false
+	}
+	return opts[0], false
+}
+
+// invalid returns an Invalid error for the given resource
+// if the list of errors is not empty, or nil otherwise
+func invalid(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
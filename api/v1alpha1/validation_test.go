@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type testValidationSpec struct {
+	Secret   SecretReference                         `json:"secret"`
+	Optional *SecretReference                        `json:"optional,omitempty"`
+	List     []SecretReference                       `json:"list,omitempty"`
+	Map      map[string]*HorizontalPodAutoscalerSpec `json:"map,omitempty"`
+	InlinedTestSpec
+}
+
+type InlinedTestSpec struct {
+	PDB *PodDisruptionBudgetSpec `json:"pdb,omitempty"`
+}
+
+func Test_validateSpec(t *testing.T) {
+	valid := SecretReference{Override: util.Pointer("value")}
+	tests := []struct {
+		name string
+		spec *testValidationSpec
+		want []string
+	}{
+		{
+			name: "Valid spec",
+			spec: &testValidationSpec{Secret: valid},
+			want: []string{},
+		},
+		{
+			name: "Validates struct fields",
+			spec: &testValidationSpec{},
+			want: []string{"spec.secret"},
+		},
+		{
+			name: "Validates pointer and slice fields",
+			spec: &testValidationSpec{
+				Secret:   valid,
+				Optional: &SecretReference{},
+				List:     []SecretReference{valid, {}},
+			},
+			want: []string{"spec.optional", "spec.list[1]"},
+		},
+		{
+			name: "Validates map fields",
+			spec: &testValidationSpec{
+				Secret: valid,
+				Map: map[string]*HorizontalPodAutoscalerSpec{
+					"key": {MinReplicas: util.Pointer[int32](2), MaxReplicas: util.Pointer[int32](1)},
+				},
+			},
+			want: []string{"spec.map[key].minReplicas"},
+		},
+		{
+			name: "Validates inlined fields",
+			spec: &testValidationSpec{
+				Secret: valid,
+				InlinedTestSpec: InlinedTestSpec{PDB: &PodDisruptionBudgetSpec{
+					MinAvailable:   util.Pointer(intstr.FromInt(1)),
+					MaxUnavailable: util.Pointer(intstr.FromInt(1)),
+				}},
+			},
+			want: []string{"spec.pdb.maxUnavailable"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateSpec(tt.spec, field.NewPath("spec"))
+			got := make([]string, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateSpec() = %v, want errors in fields %v", errs, tt.want)
+			}
+		})
+	}
+}
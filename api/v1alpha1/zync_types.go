@@ -34,6 +34,13 @@ var (
 		SelectorKey:   util.Pointer("monitoring-key"),
 		SelectorValue: util.Pointer("middleware"),
 	}
+	zyncDefaultDatabaseMigration defaultDatabaseMigrationSpec = defaultDatabaseMigrationSpec{
+		Enabled:               util.Pointer(false),
+		Command:               []string{"bundle", "exec", "rails", "db:migrate"},
+		BackoffLimit:          util.Pointer[int32](0),
+		ActiveDeadlineSeconds: util.Pointer[int64](1800),
+		Resources:             zyncDefaultAPIResources,
+	}
 	zyncDefaultRailsConsoleEnabled    bool                               = false
 	zyncDefaultConfigRailsEnvironment string                             = "development"
 	zyncDefaultConfigRailsLogLevel    string                             = "info"
@@ -45,6 +52,10 @@ var (
 		ResourceUtilization: util.Pointer[int32](90),
 		ResourceName:        util.Pointer("cpu"),
 	}
+	zyncDefaultAPIDeploymentStrategy defaultDeploymentRollingStrategySpec = defaultDeploymentRollingStrategySpec{
+		MaxUnavailable: util.Pointer(intstr.FromInt(0)),
+		MaxSurge:       util.Pointer(intstr.FromInt(1)),
+	}
 	zyncDefaultAPIPDB defaultPodDisruptionBudgetSpec = defaultPodDisruptionBudgetSpec{
 		MaxUnavailable: util.Pointer(intstr.FromInt(1)),
 	}
@@ -79,6 +90,10 @@ var (
 		ResourceUtilization: util.Pointer[int32](90),
 		ResourceName:        util.Pointer("cpu"),
 	}
+	zyncDefaultQueDeploymentStrategy defaultDeploymentRollingStrategySpec = defaultDeploymentRollingStrategySpec{
+		MaxUnavailable: util.Pointer(intstr.FromInt(0)),
+		MaxSurge:       util.Pointer(intstr.FromInt(1)),
+	}
 	zyncDefaultQuePDB defaultPodDisruptionBudgetSpec = defaultPodDisruptionBudgetSpec{
 		MaxUnavailable: util.Pointer(intstr.FromInt(1)),
 	}
@@ -144,6 +159,11 @@ type ZyncSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Console *ZyncRailsConsoleSpec `json:"console,omitempty"`
+	// Configures the Job that runs the database migrations before the
+	// api and que Deployments are rolled out to a new image
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	DatabaseMigration *DatabaseMigrationSpec `json:"databaseMigration,omitempty"`
 }
 
 // Default implements defaulting for ZyncSpec
@@ -165,10 +185,16 @@ func (spec *ZyncSpec) Default() {
 		spec.Console = &ZyncRailsConsoleSpec{}
 	}
 	spec.Console.Default(spec.Image)
+
+	spec.DatabaseMigration = InitializeDatabaseMigrationSpec(spec.DatabaseMigration, zyncDefaultDatabaseMigration)
 }
 
 // APISpec is the configuration for main Zync api component
 type APISpec struct {
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	// +patchStrategy=retainKeys
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
 	// Pod Disruption Budget for the component
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -199,11 +225,15 @@ type APISpec struct {
 	// If specified, the pod's tolerations.
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty" protobuf:"bytes,22,opt,name=tolerations"`
+	// Additional and overridden environment variables for the containers
+	// +optional
+	EnvironmentSpec `json:",inline"`
 }
 
 // Default implements defaulting for the each main zync api component
 func (spec *APISpec) Default() {
 
+	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, zyncDefaultAPIDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, zyncDefaultAPIHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &zyncDefaultAPIReplicas)
 	spec.PDB = InitializePodDisruptionBudgetSpec(spec.PDB, zyncDefaultAPIPDB)
@@ -214,6 +244,10 @@ func (spec *APISpec) Default() {
 
 // QueSpec is the configuration for Zync que
 type QueSpec struct {
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	// +patchStrategy=retainKeys
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
 	// Pod Disruption Budget for the component
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -244,11 +278,15 @@ type QueSpec struct {
 	// If specified, the pod's tolerations.
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty" protobuf:"bytes,22,opt,name=tolerations"`
+	// Additional and overridden environment variables for the containers
+	// +optional
+	EnvironmentSpec `json:",inline"`
 }
 
 // Default implements defaulting for the each zync que
 func (spec *QueSpec) Default() {
 
+	spec.DeploymentStrategy = InitializeDeploymentStrategySpec(spec.DeploymentStrategy, zyncDefaultQueDeploymentStrategy)
 	spec.HPA = InitializeHorizontalPodAutoscalerSpec(spec.HPA, zyncDefaultQueHPA)
 	spec.Replicas = intOrDefault(spec.Replicas, &zyncDefaultQueReplicas)
 	spec.PDB = InitializePodDisruptionBudgetSpec(spec.PDB, zyncDefaultQuePDB)
@@ -339,6 +377,13 @@ type ZyncRailsConsoleSpec struct {
 	// If specified, the pod's tolerations.
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty" protobuf:"bytes,22,opt,name=tolerations"`
+	// Configures how changes are rolled out to the pods of the StatefulSet
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Rollout *StatefulSetRolloutSpec `json:"rollout,omitempty"`
+	// Additional and overridden environment variables for the containers
+	// +optional
+	EnvironmentSpec `json:",inline"`
 }
 
 // Default implements defaulting for the Zync console component
@@ -349,10 +394,24 @@ func (spec *ZyncRailsConsoleSpec) Default(zyncDefaultImage *ImageSpec) {
 }
 
 // ZyncStatus defines the observed state of Zync
-type ZyncStatus struct{}
+type ZyncStatus struct {
+	WorkloadsStatus `json:",inline"`
+	// The status of the database migrations of the images pending to be rolled out
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +listType=map
+	// +listMapKey=image
+	// +optional
+	DatabaseMigrations []DatabaseMigrationStatus `json:"databaseMigrations,omitempty"`
+}
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Ready")].status`,name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Progressing")].status`,name=Progressing,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,name=Degraded,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.workloads[0].deployment.image`,name=Image,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name=Age,type=date
 
 // Zync is the Schema for the zyncs API
 type Zync struct {
@@ -368,6 +427,16 @@ func (z *Zync) Default() {
 	z.Spec.Default()
 }
 
+// GetWorkloadsStatus returns the status of the workloads of the Zync resource
+func (z *Zync) GetWorkloadsStatus() *WorkloadsStatus {
+	return &z.Status.WorkloadsStatus
+}
+
+// GetDatabaseMigrationsStatus returns the status of the database migrations of the Zync resource
+func (z *Zync) GetDatabaseMigrationsStatus() *[]DatabaseMigrationStatus {
+	return &z.Status.DatabaseMigrations
+}
+
 // +kubebuilder:object:root=true
 
 // ZyncList contains a list of Zync
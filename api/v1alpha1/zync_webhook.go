@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the webhooks of Zync with the manager
+func (z *Zync) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(z).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-saas-3scale-net-v1alpha1-zync,mutating=true,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=zyncs,verbs=create;update,versions=v1alpha1,name=mzync.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Zync{}
+
+// +kubebuilder:webhook:path=/validate-saas-3scale-net-v1alpha1-zync,mutating=false,failurePolicy=fail,sideEffects=None,groups=saas.3scale.net,resources=zyncs,verbs=create;update,versions=v1alpha1,name=vzync.saas.3scale.net,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Zync{}
+
+// ValidateCreate implements webhook.Validator
+func (z *Zync) ValidateCreate() error {
+	return z.validate()
+}
+
+// ValidateUpdate implements webhook.Validator
+func (z *Zync) ValidateUpdate(old runtime.Object) error {
+	return z.validate()
+}
+
+// ValidateDelete implements webhook.Validator
+func (z *Zync) ValidateDelete() error {
+	return nil
+}
+
+func (z *Zync) validate() error {
+	o := z.DeepCopy()
+	o.Default()
+
+	errs := validateSpec(&o.Spec, field.NewPath("spec"))
+	return invalid("Zync", z.GetName(), errs)
+}
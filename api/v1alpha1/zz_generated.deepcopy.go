@@ -34,6 +34,11 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APISpec) DeepCopyInto(out *APISpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PDB != nil {
 		in, out := &in.PDB, &out.PDB
 		*out = new(PodDisruptionBudgetSpec)
@@ -76,6 +81,7 @@ func (in *APISpec) DeepCopyInto(out *APISpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.EnvironmentSpec.DeepCopyInto(&out.EnvironmentSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APISpec.
@@ -88,6 +94,135 @@ func (in *APISpec) DeepCopy() *APISpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSecretsManagerSecretReference) DeepCopyInto(out *AWSSecretsManagerSecretReference) {
+	*out = *in
+	if in.SecretStoreRef != nil {
+		in, out := &in.SecretStoreRef, &out.SecretStoreRef
+		*out = new(ExternalSecretSecretStoreReferenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSecretsManagerSecretReference.
+func (in *AWSSecretsManagerSecretReference) DeepCopy() *AWSSecretsManagerSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSecretsManagerSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogFileSink) DeepCopyInto(out *AccessLogFileSink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogFileSink.
+func (in *AccessLogFileSink) DeepCopy() *AccessLogFileSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogFileSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogGrpcSink) DeepCopyInto(out *AccessLogGrpcSink) {
+	*out = *in
+	if in.LogName != nil {
+		in, out := &in.LogName, &out.LogName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogGrpcSink.
+func (in *AccessLogGrpcSink) DeepCopy() *AccessLogGrpcSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogGrpcSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogOptions) DeepCopyInto(out *AccessLogOptions) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]AccessLogSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogOptions.
+func (in *AccessLogOptions) DeepCopy() *AccessLogOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogSink) DeepCopyInto(out *AccessLogSink) {
+	*out = *in
+	if in.Stdout != nil {
+		in, out := &in.Stdout, &out.Stdout
+		*out = new(AccessLogStdoutSink)
+		**out = **in
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(AccessLogFileSink)
+		**out = **in
+	}
+	if in.Grpc != nil {
+		in, out := &in.Grpc, &out.Grpc
+		*out = new(AccessLogGrpcSink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogSink.
+func (in *AccessLogSink) DeepCopy() *AccessLogSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogStdoutSink) DeepCopyInto(out *AccessLogStdoutSink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogStdoutSink.
+func (in *AccessLogStdoutSink) DeepCopy() *AccessLogStdoutSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogStdoutSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddressSpec) DeepCopyInto(out *AddressSpec) {
 	*out = *in
@@ -119,7 +254,7 @@ func (in *Apicast) DeepCopyInto(out *Apicast) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Apicast.
@@ -168,6 +303,11 @@ func (in *ApicastConfig) DeepCopy() *ApicastConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApicastEnvironmentSpec) DeepCopyInto(out *ApicastEnvironmentSpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(ImageSpec)
@@ -301,6 +441,7 @@ func (in *ApicastSpec) DeepCopy() *ApicastSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApicastStatus) DeepCopyInto(out *ApicastStatus) {
 	*out = *in
+	in.WorkloadsStatus.DeepCopyInto(&out.WorkloadsStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastStatus.
@@ -341,7 +482,7 @@ func (in *AutoSSL) DeepCopyInto(out *AutoSSL) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSSL.
@@ -437,6 +578,11 @@ func (in *AutoSSLList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoSSLSpec) DeepCopyInto(out *AutoSSLSpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(ImageSpec)
@@ -516,6 +662,7 @@ func (in *AutoSSLSpec) DeepCopy() *AutoSSLSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoSSLStatus) DeepCopyInto(out *AutoSSLStatus) {
 	*out = *in
+	in.WorkloadsStatus.DeepCopyInto(&out.WorkloadsStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSSLStatus.
@@ -534,7 +681,7 @@ func (in *Backend) DeepCopyInto(out *Backend) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backend.
@@ -568,6 +715,11 @@ func (in *BackendConfig) DeepCopyInto(out *BackendConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.SentinelRef != nil {
+		in, out := &in.SentinelRef, &out.SentinelRef
+		*out = new(BackendSentinelReference)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ExternalSecret.DeepCopyInto(&out.ExternalSecret)
 	in.SystemEventsHookURL.DeepCopyInto(&out.SystemEventsHookURL)
 	in.SystemEventsHookPassword.DeepCopyInto(&out.SystemEventsHookPassword)
@@ -627,6 +779,22 @@ func (in *BackendList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendSentinelReference) DeepCopyInto(out *BackendSentinelReference) {
+	*out = *in
+	in.SentinelReference.DeepCopyInto(&out.SentinelReference)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSentinelReference.
+func (in *BackendSentinelReference) DeepCopy() *BackendSentinelReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendSentinelReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 	*out = *in
@@ -672,6 +840,7 @@ func (in *BackendSpec) DeepCopy() *BackendSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendStatus) DeepCopyInto(out *BackendStatus) {
 	*out = *in
+	in.WorkloadsStatus.DeepCopyInto(&out.WorkloadsStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendStatus.
@@ -749,6 +918,50 @@ func (in BackupStatusList) DeepCopy() BackupStatusList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueGreenStatus) DeepCopyInto(out *BlueGreenStatus) {
+	*out = *in
+	if in.ActiveSince != nil {
+		in, out := &in.ActiveSince, &out.ActiveSince
+		*out = (*in).DeepCopy()
+	}
+	if in.Inactive != nil {
+		in, out := &in.Inactive, &out.Inactive
+		*out = new(DeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenStatus.
+func (in *BlueGreenStatus) DeepCopy() *BlueGreenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueGreenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueGreenStrategySpec) DeepCopyInto(out *BlueGreenStrategySpec) {
+	*out = *in
+	if in.RollbackWindow != nil {
+		in, out := &in.RollbackWindow, &out.RollbackWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenStrategySpec.
+func (in *BlueGreenStrategySpec) DeepCopy() *BlueGreenStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueGreenStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BugsnagSpec) DeepCopyInto(out *BugsnagSpec) {
 	*out = *in
@@ -776,7 +989,7 @@ func (in *CORSProxy) DeepCopyInto(out *CORSProxy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORSProxy.
@@ -849,6 +1062,11 @@ func (in *CORSProxyList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CORSProxySpec) DeepCopyInto(out *CORSProxySpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(ImageSpec)
@@ -917,6 +1135,7 @@ func (in *CORSProxySpec) DeepCopy() *CORSProxySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CORSProxyStatus) DeepCopyInto(out *CORSProxyStatus) {
 	*out = *in
+	in.WorkloadsStatus.DeepCopyInto(&out.WorkloadsStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORSProxyStatus.
@@ -952,6 +1171,21 @@ func (in *Canary) DeepCopyInto(out *Canary) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]CanaryStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(CanaryAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Canary.
@@ -965,144 +1199,604 @@ func (in *Canary) DeepCopy() *Canary {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Cluster) DeepCopyInto(out *Cluster) {
+func (in *CanaryAnalysis) DeepCopyInto(out *CanaryAnalysis) {
 	*out = *in
-	if in.IsHttp2 != nil {
-		in, out := &in.IsHttp2, &out.IsHttp2
-		*out = new(bool)
+	out.Duration = in.Duration
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]CanaryMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
-func (in *Cluster) DeepCopy() *Cluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryAnalysis.
+func (in *CanaryAnalysis) DeepCopy() *CanaryAnalysis {
 	if in == nil {
 		return nil
 	}
-	out := new(Cluster)
+	out := new(CanaryAnalysis)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CronSpec) DeepCopyInto(out *CronSpec) {
+func (in *CanaryAnalysisStatus) DeepCopyInto(out *CanaryAnalysisStatus) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ResourceRequirementsSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NodeAffinity != nil {
-		in, out := &in.NodeAffinity, &out.NodeAffinity
-		*out = new(v1.NodeAffinity)
-		(*in).DeepCopyInto(*out)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]CanaryMetricStatus, len(*in))
+		copy(*out, *in)
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.LastEvaluationTime != nil {
+		in, out := &in.LastEvaluationTime, &out.LastEvaluationTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronSpec.
-func (in *CronSpec) DeepCopy() *CronSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryAnalysisStatus.
+func (in *CanaryAnalysisStatus) DeepCopy() *CanaryAnalysisStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CronSpec)
+	out := new(CanaryAnalysisStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentStrategySpec) DeepCopyInto(out *DeploymentStrategySpec) {
+func (in *CanaryMetric) DeepCopyInto(out *CanaryMetric) {
 	*out = *in
-	if in.RollingUpdate != nil {
-		in, out := &in.RollingUpdate, &out.RollingUpdate
-		*out = new(appsv1.RollingUpdateDeployment)
-		(*in).DeepCopyInto(*out)
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(string)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStrategySpec.
-func (in *DeploymentStrategySpec) DeepCopy() *DeploymentStrategySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryMetric.
+func (in *CanaryMetric) DeepCopy() *CanaryMetric {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentStrategySpec)
+	out := new(CanaryMetric)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EchoAPI) DeepCopyInto(out *EchoAPI) {
+func (in *CanaryMetricStatus) DeepCopyInto(out *CanaryMetricStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EchoAPI.
-func (in *EchoAPI) DeepCopy() *EchoAPI {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryMetricStatus.
+func (in *CanaryMetricStatus) DeepCopy() *CanaryMetricStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EchoAPI)
+	out := new(CanaryMetricStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EchoAPI) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStep) DeepCopyInto(out *CanaryStep) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStep.
+func (in *CanaryStep) DeepCopy() *CanaryStep {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(CanaryStep)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EchoAPIList) DeepCopyInto(out *EchoAPIList) {
+func (in *CertificateIssuerRef) DeepCopyInto(out *CertificateIssuerRef) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]EchoAPI, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EchoAPIList.
-func (in *EchoAPIList) DeepCopy() *EchoAPIList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuerRef.
+func (in *CertificateIssuerRef) DeepCopy() *CertificateIssuerRef {
 	if in == nil {
 		return nil
 	}
-	out := new(EchoAPIList)
+	out := new(CertificateIssuerRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EchoAPIList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cluster) DeepCopyInto(out *Cluster) {
+	*out = *in
+	if in.IsHttp2 != nil {
+		in, out := &in.IsHttp2, &out.IsHttp2
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CanaryWeighted != nil {
+		in, out := &in.CanaryWeighted, &out.CanaryWeighted
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(ClusterHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(ClusterOutlierDetection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreakers != nil {
+		in, out := &in.CircuitBreakers, &out.CircuitBreakers
+		*out = new(ClusterCircuitBreakers)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpstreamTLS != nil {
+		in, out := &in.UpstreamTLS, &out.UpstreamTLS
+		*out = new(ClusterUpstreamTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WeightedUpstreams != nil {
+		in, out := &in.WeightedUpstreams, &out.WeightedUpstreams
+		*out = make([]WeightedUpstream, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
+func (in *Cluster) DeepCopy() *Cluster {
+	if in == nil {
+		return nil
+	}
+	out := new(Cluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCircuitBreakers) DeepCopyInto(out *ClusterCircuitBreakers) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxPendingRequests != nil {
+		in, out := &in.MaxPendingRequests, &out.MaxPendingRequests
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxRequests != nil {
+		in, out := &in.MaxRequests, &out.MaxRequests
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCircuitBreakers.
+func (in *ClusterCircuitBreakers) DeepCopy() *ClusterCircuitBreakers {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCircuitBreakers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClientCertificate) DeepCopyInto(out *ClusterClientCertificate) {
+	*out = *in
+	in.IssuerRef.DeepCopyInto(&out.IssuerRef)
+	if in.CommonName != nil {
+		in, out := &in.CommonName, &out.CommonName
+		*out = new(string)
+		**out = **in
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClientCertificate.
+func (in *ClusterClientCertificate) DeepCopy() *ClusterClientCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClientCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHealthCheck) DeepCopyInto(out *ClusterHealthCheck) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceName != nil {
+		in, out := &in.ServiceName, &out.ServiceName
+		*out = new(string)
+		**out = **in
+	}
+	out.Interval = in.Interval
+	out.Timeout = in.Timeout
+	if in.HealthyThreshold != nil {
+		in, out := &in.HealthyThreshold, &out.HealthyThreshold
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHealthCheck.
+func (in *ClusterHealthCheck) DeepCopy() *ClusterHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOutlierDetection) DeepCopyInto(out *ClusterOutlierDetection) {
+	*out = *in
+	if in.Consecutive5xx != nil {
+		in, out := &in.Consecutive5xx, &out.Consecutive5xx
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BaseEjectionTime != nil {
+		in, out := &in.BaseEjectionTime, &out.BaseEjectionTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOutlierDetection.
+func (in *ClusterOutlierDetection) DeepCopy() *ClusterOutlierDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOutlierDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterShardedRedis) DeepCopyInto(out *ClusterShardedRedis) {
+	*out = *in
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]RedisShardEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterShardedRedis.
+func (in *ClusterShardedRedis) DeepCopy() *ClusterShardedRedis {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterShardedRedis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpstreamTLS) DeepCopyInto(out *ClusterUpstreamTLS) {
+	*out = *in
+	if in.SNI != nil {
+		in, out := &in.SNI, &out.SNI
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientCertificateSecretName != nil {
+		in, out := &in.ClientCertificateSecretName, &out.ClientCertificateSecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(ClusterClientCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CASecretName != nil {
+		in, out := &in.CASecretName, &out.CASecretName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpstreamTLS.
+func (in *ClusterUpstreamTLS) DeepCopy() *ClusterUpstreamTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpstreamTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronSpec) DeepCopyInto(out *CronSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirementsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(v1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.EnvironmentSpec.DeepCopyInto(&out.EnvironmentSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronSpec.
+func (in *CronSpec) DeepCopy() *CronSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CronSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseMigrationSpec) DeepCopyInto(out *DatabaseMigrationSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirementsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseMigrationSpec.
+func (in *DatabaseMigrationSpec) DeepCopy() *DatabaseMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseMigrationStatus) DeepCopyInto(out *DatabaseMigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseMigrationStatus.
+func (in *DatabaseMigrationStatus) DeepCopy() *DatabaseMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
+	*out = *in
+	if in.HPACurrentReplicas != nil {
+		in, out := &in.HPACurrentReplicas, &out.HPACurrentReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SecretHashes != nil {
+		in, out := &in.SecretHashes, &out.SecretHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastSecretRollout != nil {
+		in, out := &in.LastSecretRollout, &out.LastSecretRollout
+		*out = new(SecretRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatus.
+func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStrategySpec) DeepCopyInto(out *DeploymentStrategySpec) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(BlueGreenStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStrategySpec.
+func (in *DeploymentStrategySpec) DeepCopy() *DeploymentStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EchoAPI) DeepCopyInto(out *EchoAPI) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EchoAPI.
+func (in *EchoAPI) DeepCopy() *EchoAPI {
+	if in == nil {
+		return nil
+	}
+	out := new(EchoAPI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EchoAPI) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EchoAPIList) DeepCopyInto(out *EchoAPIList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EchoAPI, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EchoAPIList.
+func (in *EchoAPIList) DeepCopy() *EchoAPIList {
+	if in == nil {
+		return nil
+	}
+	out := new(EchoAPIList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EchoAPIList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EchoAPISpec) DeepCopyInto(out *EchoAPISpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(ImageSpec)
@@ -1176,6 +1870,7 @@ func (in *EchoAPISpec) DeepCopy() *EchoAPISpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EchoAPIStatus) DeepCopyInto(out *EchoAPIStatus) {
 	*out = *in
+	in.WorkloadsStatus.DeepCopyInto(&out.WorkloadsStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EchoAPIStatus.
@@ -1208,6 +1903,35 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSpec) DeepCopyInto(out *EnvironmentSpec) {
+	*out = *in
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvOverrides != nil {
+		in, out := &in.EnvOverrides, &out.EnvOverrides
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSpec.
+func (in *EnvironmentSpec) DeepCopy() *EnvironmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyDynamicConfig) DeepCopyInto(out *EnvoyDynamicConfig) {
 	*out = *in
@@ -1241,6 +1965,11 @@ func (in *EnvoyDynamicConfig) DeepCopyInto(out *EnvoyDynamicConfig) {
 		*out = new(RawConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ListenerTcpProxy != nil {
+		in, out := &in.ListenerTcpProxy, &out.ListenerTcpProxy
+		*out = new(ListenerTcpProxy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyDynamicConfig.
@@ -1253,6 +1982,51 @@ func (in *EnvoyDynamicConfig) DeepCopy() *EnvoyDynamicConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyRedisProxyDynamicConfig) DeepCopyInto(out *EnvoyRedisProxyDynamicConfig) {
+	*out = *in
+	if in.GeneratorVersion != nil {
+		in, out := &in.GeneratorVersion, &out.GeneratorVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.ListenerRedisProxy != nil {
+		in, out := &in.ListenerRedisProxy, &out.ListenerRedisProxy
+		*out = new(ListenerRedisProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterShardedRedis != nil {
+		in, out := &in.ClusterShardedRedis, &out.ClusterShardedRedis
+		*out = new(ClusterShardedRedis)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyRedisProxyDynamicConfig.
+func (in *EnvoyRedisProxyDynamicConfig) DeepCopy() *EnvoyRedisProxyDynamicConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyRedisProxyDynamicConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyRedisProxySpec) DeepCopyInto(out *EnvoyRedisProxySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyRedisProxySpec.
+func (in *EnvoyRedisProxySpec) DeepCopy() *EnvoyRedisProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyRedisProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecret) DeepCopyInto(out *ExternalSecret) {
 	*out = *in
@@ -1266,6 +2040,11 @@ func (in *ExternalSecret) DeepCopyInto(out *ExternalSecret) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.RolloutOnChange != nil {
+		in, out := &in.RolloutOnChange, &out.RolloutOnChange
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecret.
@@ -1303,6 +2082,26 @@ func (in *ExternalSecretSecretStoreReferenceSpec) DeepCopy() *ExternalSecretSecr
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretsProviderSecretReference) DeepCopyInto(out *ExternalSecretsProviderSecretReference) {
+	*out = *in
+	if in.SecretStoreRef != nil {
+		in, out := &in.SecretStoreRef, &out.SecretStoreRef
+		*out = new(ExternalSecretSecretStoreReferenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretsProviderSecretReference.
+func (in *ExternalSecretsProviderSecretReference) DeepCopy() *ExternalSecretsProviderSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretsProviderSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GithubSpec) DeepCopyInto(out *GithubSpec) {
 	*out = *in
@@ -1340,7 +2139,33 @@ func (in *GrafanaDashboardSpec) DeepCopy() *GrafanaDashboardSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GrafanaDashboardSpec)
+	out := new(GrafanaDashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizontalPodAutoscalerScheduleSpec) DeepCopyInto(out *HorizontalPodAutoscalerScheduleSpec) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizontalPodAutoscalerScheduleSpec.
+func (in *HorizontalPodAutoscalerScheduleSpec) DeepCopy() *HorizontalPodAutoscalerScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizontalPodAutoscalerScheduleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1368,11 +2193,30 @@ func (in *HorizontalPodAutoscalerSpec) DeepCopyInto(out *HorizontalPodAutoscaler
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SuggestedMetrics != nil {
+		in, out := &in.SuggestedMetrics, &out.SuggestedMetrics
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Behavior != nil {
 		in, out := &in.Behavior, &out.Behavior
 		*out = new(v2.HorizontalPodAutoscalerBehavior)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]HorizontalPodAutoscalerScheduleSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizontalPodAutoscalerSpec.
@@ -1420,6 +2264,41 @@ func (in *ImageSpec) DeepCopy() *ImageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesSecretReference) DeepCopyInto(out *KubernetesSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSecretReference.
+func (in *KubernetesSecretReference) DeepCopy() *KubernetesSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerClientValidation) DeepCopyInto(out *ListenerClientValidation) {
+	*out = *in
+	if in.RequireClientCertificate != nil {
+		in, out := &in.RequireClientCertificate, &out.RequireClientCertificate
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerClientValidation.
+func (in *ListenerClientValidation) DeepCopy() *ListenerClientValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerClientValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ListenerConfig) DeepCopyInto(out *ListenerConfig) {
 	*out = *in
@@ -1493,6 +2372,21 @@ func (in *ListenerHttp) DeepCopyInto(out *ListenerHttp) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(AccessLogOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientValidation != nil {
+		in, out := &in.ClientValidation, &out.ClientValidation
+		*out = new(ListenerClientValidation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerHttp.
@@ -1505,9 +2399,35 @@ func (in *ListenerHttp) DeepCopy() *ListenerHttp {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerRedisProxy) DeepCopyInto(out *ListenerRedisProxy) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+	out.OpTimeout = in.OpTimeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerRedisProxy.
+func (in *ListenerRedisProxy) DeepCopy() *ListenerRedisProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerRedisProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ListenerSpec) DeepCopyInto(out *ListenerSpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(ListenerConfig)
@@ -1571,6 +2491,7 @@ func (in *ListenerSpec) DeepCopyInto(out *ListenerSpec) {
 		*out = new(Canary)
 		(*in).DeepCopyInto(*out)
 	}
+	in.EnvironmentSpec.DeepCopyInto(&out.EnvironmentSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerSpec.
@@ -1583,6 +2504,36 @@ func (in *ListenerSpec) DeepCopy() *ListenerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerTcpProxy) DeepCopyInto(out *ListenerTcpProxy) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProxyProtocol != nil {
+		in, out := &in.ProxyProtocol, &out.ProxyProtocol
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerTcpProxy.
+func (in *ListenerTcpProxy) DeepCopy() *ListenerTcpProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerTcpProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 	*out = *in
@@ -1665,7 +2616,7 @@ func (in *MappingService) DeepCopyInto(out *MappingService) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappingService.
@@ -1748,6 +2699,11 @@ func (in *MappingServiceList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MappingServiceSpec) DeepCopyInto(out *MappingServiceSpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(ImageSpec)
@@ -1816,6 +2772,7 @@ func (in *MappingServiceSpec) DeepCopy() *MappingServiceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MappingServiceStatus) DeepCopyInto(out *MappingServiceStatus) {
 	*out = *in
+	in.WorkloadsStatus.DeepCopyInto(&out.WorkloadsStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappingServiceStatus.
@@ -2033,6 +2990,11 @@ func (in *ProbeSpec) DeepCopy() *ProbeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueSpec) DeepCopyInto(out *QueSpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PDB != nil {
 		in, out := &in.PDB, &out.PDB
 		*out = new(PodDisruptionBudgetSpec)
@@ -2075,6 +3037,7 @@ func (in *QueSpec) DeepCopyInto(out *QueSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.EnvironmentSpec.DeepCopyInto(&out.EnvironmentSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueSpec.
@@ -2087,6 +3050,78 @@ func (in *QueSpec) DeepCopy() *QueSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]RateLimitAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitAction) DeepCopyInto(out *RateLimitAction) {
+	*out = *in
+	if in.RequestHeader != nil {
+		in, out := &in.RequestHeader, &out.RequestHeader
+		*out = new(RateLimitRequestHeader)
+		**out = **in
+	}
+	if in.RemoteAddress != nil {
+		in, out := &in.RemoteAddress, &out.RemoteAddress
+		*out = new(RateLimitRemoteAddress)
+		**out = **in
+	}
+	if in.GenericKey != nil {
+		in, out := &in.GenericKey, &out.GenericKey
+		*out = new(RateLimitGenericKey)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitAction.
+func (in *RateLimitAction) DeepCopy() *RateLimitAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitGenericKey) DeepCopyInto(out *RateLimitGenericKey) {
+	*out = *in
+	if in.DescriptorKey != nil {
+		in, out := &in.DescriptorKey, &out.DescriptorKey
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitGenericKey.
+func (in *RateLimitGenericKey) DeepCopy() *RateLimitGenericKey {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitGenericKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimitOptions) DeepCopyInto(out *RateLimitOptions) {
 	*out = *in
@@ -2108,6 +3143,63 @@ func (in *RateLimitOptions) DeepCopy() *RateLimitOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitPolicy) DeepCopyInto(out *RateLimitPolicy) {
+	*out = *in
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(string)
+		**out = **in
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]RateLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicy.
+func (in *RateLimitPolicy) DeepCopy() *RateLimitPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitRemoteAddress) DeepCopyInto(out *RateLimitRemoteAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitRemoteAddress.
+func (in *RateLimitRemoteAddress) DeepCopy() *RateLimitRemoteAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitRemoteAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitRequestHeader) DeepCopyInto(out *RateLimitRequestHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitRequestHeader.
+func (in *RateLimitRequestHeader) DeepCopy() *RateLimitRequestHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitRequestHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RawConfig) DeepCopyInto(out *RawConfig) {
 	*out = *in
@@ -2146,6 +3238,22 @@ func (in *RedHatCustomerPortalSpec) DeepCopy() *RedHatCustomerPortalSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisSentinelReference) DeepCopyInto(out *RedisSentinelReference) {
+	*out = *in
+	in.SentinelReference.DeepCopyInto(&out.SentinelReference)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisSentinelReference.
+func (in *RedisSentinelReference) DeepCopy() *RedisSentinelReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSentinelReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisServerDetails) DeepCopyInto(out *RedisServerDetails) {
 	*out = *in
@@ -2202,6 +3310,21 @@ func (in *RedisShard) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisShardEndpoint) DeepCopyInto(out *RedisShardEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisShardEndpoint.
+func (in *RedisShardEndpoint) DeepCopy() *RedisShardEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisShardEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisShardList) DeepCopyInto(out *RedisShardList) {
 	*out = *in
@@ -2286,6 +3409,11 @@ func (in *RedisShardSpec) DeepCopyInto(out *RedisShardSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(StatefulSetRolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisShardSpec.
@@ -2321,6 +3449,11 @@ func (in *RedisShardStatus) DeepCopy() *RedisShardStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisSpec) DeepCopyInto(out *RedisSpec) {
 	*out = *in
+	if in.SentinelRef != nil {
+		in, out := &in.SentinelRef, &out.SentinelRef
+		*out = new(RedisSentinelReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisSpec.
@@ -2367,6 +3500,31 @@ func (in *ResourceRequirementsSpec) DeepCopy() *ResourceRequirementsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.NumRetries != nil {
+		in, out := &in.NumRetries, &out.NumRetries
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.PerTryTimeout != nil {
+		in, out := &in.PerTryTimeout, &out.PerTryTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteConfiguration) DeepCopyInto(out *RouteConfiguration) {
 	*out = *in
@@ -2377,6 +3535,18 @@ func (in *RouteConfiguration) DeepCopyInto(out *RouteConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = make([]RateLimitPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteConfiguration.
@@ -2552,19 +3722,60 @@ func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 		*out = new(VaultSecretReference)
 		**out = **in
 	}
+	if in.FromKubernetesSecret != nil {
+		in, out := &in.FromKubernetesSecret, &out.FromKubernetesSecret
+		*out = new(KubernetesSecretReference)
+		**out = **in
+	}
+	if in.FromAWSSecretsManager != nil {
+		in, out := &in.FromAWSSecretsManager, &out.FromAWSSecretsManager
+		*out = new(AWSSecretsManagerSecretReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FromExternalSecretsProvider != nil {
+		in, out := &in.FromExternalSecretsProvider, &out.FromExternalSecretsProvider
+		*out = new(ExternalSecretsProviderSecretReference)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Override != nil {
 		in, out := &in.Override, &out.Override
 		*out = new(string)
 		**out = **in
 	}
+	if in.MountAsFile != nil {
+		in, out := &in.MountAsFile, &out.MountAsFile
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRolloutStatus) DeepCopyInto(out *SecretRolloutStatus) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Time.DeepCopyInto(&out.Time)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
-func (in *SecretReference) DeepCopy() *SecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRolloutStatus.
+func (in *SecretRolloutStatus) DeepCopy() *SecretRolloutStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretReference)
+	out := new(SecretRolloutStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2707,6 +3918,26 @@ func (in *SentinelList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SentinelReference) DeepCopyInto(out *SentinelReference) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SentinelReference.
+func (in *SentinelReference) DeepCopy() *SentinelReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SentinelReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SentinelSpec) DeepCopyInto(out *SentinelSpec) {
 	*out = *in
@@ -2762,6 +3993,11 @@ func (in *SentinelSpec) DeepCopyInto(out *SentinelSpec) {
 		*out = new(SentinelConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(StatefulSetRolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SentinelSpec.
@@ -2801,6 +4037,73 @@ func (in *SentinelStatus) DeepCopy() *SentinelStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerPoolPreview) DeepCopyInto(out *ServerPoolPreview) {
+	*out = *in
+	if in.Added != nil {
+		in, out := &in.Added, &out.Added
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remapped != nil {
+		in, out := &in.Remapped, &out.Remapped
+		*out = make([]ShardRemap, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerPoolPreview.
+func (in *ServerPoolPreview) DeepCopy() *ServerPoolPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerPoolPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerPoolStatus) DeepCopyInto(out *ServerPoolStatus) {
+	*out = *in
+	if in.SelectedTargets != nil {
+		in, out := &in.SelectedTargets, &out.SelectedTargets
+		*out = make(map[string]TargetServer, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerPoolStatus.
+func (in *ServerPoolStatus) DeepCopy() *ServerPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardRemap) DeepCopyInto(out *ShardRemap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardRemap.
+func (in *ShardRemap) DeepCopy() *ShardRemap {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardRemap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardedRedisBackup) DeepCopyInto(out *ShardedRedisBackup) {
 	*out = *in
@@ -2974,13 +4277,33 @@ func (in *SidekiqConfig) DeepCopy() *SidekiqConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatefulSetRolloutSpec) DeepCopyInto(out *StatefulSetRolloutSpec) {
+	*out = *in
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetRolloutSpec.
+func (in *StatefulSetRolloutSpec) DeepCopy() *StatefulSetRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StatefulSetRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *System) DeepCopyInto(out *System) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new System.
@@ -3061,6 +4384,7 @@ func (in *SystemAppSpec) DeepCopyInto(out *SystemAppSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	in.EnvironmentSpec.DeepCopyInto(&out.EnvironmentSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemAppSpec.
@@ -3148,7 +4472,7 @@ func (in *SystemConfig) DeepCopyInto(out *SystemConfig) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.DatabaseSecret.DeepCopyInto(&out.DatabaseSecret)
-	out.Redis = in.Redis
+	in.Redis.DeepCopyInto(&out.Redis)
 	in.SMTP.DeepCopyInto(&out.SMTP)
 	in.MappingServiceAccessToken.DeepCopyInto(&out.MappingServiceAccessToken)
 	if in.ZyncAuthToken != nil {
@@ -3175,6 +4499,27 @@ func (in *SystemConfig) DeepCopy() *SystemConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemDatabaseMigrationSpec) DeepCopyInto(out *SystemDatabaseMigrationSpec) {
+	*out = *in
+	in.DatabaseMigrationSpec.DeepCopyInto(&out.DatabaseMigrationSpec)
+	if in.Canaries != nil {
+		in, out := &in.Canaries, &out.Canaries
+		*out = new(CanaryDatabaseMigrationPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemDatabaseMigrationSpec.
+func (in *SystemDatabaseMigrationSpec) DeepCopy() *SystemDatabaseMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemDatabaseMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemList) DeepCopyInto(out *SystemList) {
 	*out = *in
@@ -3232,6 +4577,12 @@ func (in *SystemRailsConsoleSpec) DeepCopyInto(out *SystemRailsConsoleSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(StatefulSetRolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.EnvironmentSpec.DeepCopyInto(&out.EnvironmentSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemRailsConsoleSpec.
@@ -3341,6 +4692,11 @@ func (in *SystemSearchdSpec) DeepCopyInto(out *SystemSearchdSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(StatefulSetRolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSearchdSpec.
@@ -3353,6 +4709,22 @@ func (in *SystemSearchdSpec) DeepCopy() *SystemSearchdSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemSidekiqPoolSpec) DeepCopyInto(out *SystemSidekiqPoolSpec) {
+	*out = *in
+	in.SystemSidekiqSpec.DeepCopyInto(&out.SystemSidekiqSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSidekiqPoolSpec.
+func (in *SystemSidekiqPoolSpec) DeepCopy() *SystemSidekiqPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemSidekiqPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemSidekiqSpec) DeepCopyInto(out *SystemSidekiqSpec) {
 	*out = *in
@@ -3418,6 +4790,7 @@ func (in *SystemSidekiqSpec) DeepCopyInto(out *SystemSidekiqSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	in.EnvironmentSpec.DeepCopyInto(&out.EnvironmentSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSidekiqSpec.
@@ -3459,11 +4832,23 @@ func (in *SystemSpec) DeepCopyInto(out *SystemSpec) {
 		*out = new(SystemSidekiqSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SidekiqPools != nil {
+		in, out := &in.SidekiqPools, &out.SidekiqPools
+		*out = make([]SystemSidekiqPoolSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Searchd != nil {
 		in, out := &in.Searchd, &out.Searchd
 		*out = new(SystemSearchdSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DatabaseMigration != nil {
+		in, out := &in.DatabaseMigration, &out.DatabaseMigration
+		*out = new(SystemDatabaseMigrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Console != nil {
 		in, out := &in.Console, &out.Console
 		*out = new(SystemRailsConsoleSpec)
@@ -3501,6 +4886,12 @@ func (in *SystemSpec) DeepCopy() *SystemSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemStatus) DeepCopyInto(out *SystemStatus) {
 	*out = *in
+	in.WorkloadsStatus.DeepCopyInto(&out.WorkloadsStatus)
+	if in.DatabaseMigrations != nil {
+		in, out := &in.DatabaseMigrations, &out.DatabaseMigrations
+		*out = make([]DatabaseMigrationStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemStatus.
@@ -3648,6 +5039,36 @@ func (in *TargetServer) DeepCopy() *TargetServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingOptions) DeepCopyInto(out *TracingOptions) {
+	*out = *in
+	if in.CollectorEndpoint != nil {
+		in, out := &in.CollectorEndpoint, &out.CollectorEndpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceName != nil {
+		in, out := &in.ServiceName, &out.ServiceName
+		*out = new(string)
+		**out = **in
+	}
+	if in.RandomSampling != nil {
+		in, out := &in.RandomSampling, &out.RandomSampling
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingOptions.
+func (in *TracingOptions) DeepCopy() *TracingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TwemproxyConfig) DeepCopyInto(out *TwemproxyConfig) {
 	*out = *in
@@ -3707,13 +5128,57 @@ func (in *TwemproxyConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TwemproxyConfigPreview) DeepCopyInto(out *TwemproxyConfigPreview) {
+	*out = *in
+	if in.ServerPools != nil {
+		in, out := &in.ServerPools, &out.ServerPools
+		*out = make([]ServerPoolPreview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TwemproxyConfigPreview.
+func (in *TwemproxyConfigPreview) DeepCopy() *TwemproxyConfigPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(TwemproxyConfigPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TwemproxyConfigPreviewSpec) DeepCopyInto(out *TwemproxyConfigPreviewSpec) {
+	*out = *in
+	if in.ApprovedHash != nil {
+		in, out := &in.ApprovedHash, &out.ApprovedHash
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TwemproxyConfigPreviewSpec.
+func (in *TwemproxyConfigPreviewSpec) DeepCopy() *TwemproxyConfigPreviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TwemproxyConfigPreviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TwemproxyConfigSpec) DeepCopyInto(out *TwemproxyConfigSpec) {
 	*out = *in
-	if in.SentinelURIs != nil {
-		in, out := &in.SentinelURIs, &out.SentinelURIs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.SentinelRefs != nil {
+		in, out := &in.SentinelRefs, &out.SentinelRefs
+		*out = make([]SentinelReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ServerPools != nil {
 		in, out := &in.ServerPools, &out.ServerPools
@@ -3727,6 +5192,16 @@ func (in *TwemproxyConfigSpec) DeepCopyInto(out *TwemproxyConfigSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Preview != nil {
+		in, out := &in.Preview, &out.Preview
+		*out = new(TwemproxyConfigPreviewSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvoyRedisProxy != nil {
+		in, out := &in.EnvoyRedisProxy, &out.EnvoyRedisProxy
+		*out = new(EnvoyRedisProxySpec)
+		**out = **in
+	}
 	if in.GrafanaDashboard != nil {
 		in, out := &in.GrafanaDashboard, &out.GrafanaDashboard
 		*out = new(GrafanaDashboardSpec)
@@ -3754,6 +5229,18 @@ func (in *TwemproxyConfigStatus) DeepCopyInto(out *TwemproxyConfigStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.ServerPools != nil {
+		in, out := &in.ServerPools, &out.ServerPools
+		*out = make([]ServerPoolStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Preview != nil {
+		in, out := &in.Preview, &out.Preview
+		*out = new(TwemproxyConfigPreview)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TwemproxyConfigStatus.
@@ -3876,6 +5363,21 @@ func (in *VaultSecretReference) DeepCopy() *VaultSecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedUpstream) DeepCopyInto(out *WeightedUpstream) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedUpstream.
+func (in *WeightedUpstream) DeepCopy() *WeightedUpstream {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedUpstream)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 	*out = *in
@@ -3904,6 +5406,11 @@ func (in *WorkerConfig) DeepCopy() *WorkerConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerSpec) DeepCopyInto(out *WorkerSpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(WorkerConfig)
@@ -3956,6 +5463,7 @@ func (in *WorkerSpec) DeepCopyInto(out *WorkerSpec) {
 		*out = new(Canary)
 		(*in).DeepCopyInto(*out)
 	}
+	in.EnvironmentSpec.DeepCopyInto(&out.EnvironmentSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerSpec.
@@ -3968,13 +5476,80 @@ func (in *WorkerSpec) DeepCopy() *WorkerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
+	*out = *in
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(DeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CanaryWeight != nil {
+		in, out := &in.CanaryWeight, &out.CanaryWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(BlueGreenStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadStatus.
+func (in *WorkloadStatus) DeepCopy() *WorkloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadsStatus) DeepCopyInto(out *WorkloadsStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]WorkloadStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CanaryAnalyses != nil {
+		in, out := &in.CanaryAnalyses, &out.CanaryAnalyses
+		*out = make([]CanaryAnalysisStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadsStatus.
+func (in *WorkloadsStatus) DeepCopy() *WorkloadsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Zync) DeepCopyInto(out *Zync) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Zync.
@@ -4086,6 +5661,12 @@ func (in *ZyncRailsConsoleSpec) DeepCopyInto(out *ZyncRailsConsoleSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(StatefulSetRolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.EnvironmentSpec.DeepCopyInto(&out.EnvironmentSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZyncRailsConsoleSpec.
@@ -4157,6 +5738,11 @@ func (in *ZyncSpec) DeepCopyInto(out *ZyncSpec) {
 		*out = new(ZyncRailsConsoleSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DatabaseMigration != nil {
+		in, out := &in.DatabaseMigration, &out.DatabaseMigration
+		*out = new(DatabaseMigrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZyncSpec.
@@ -4172,6 +5758,12 @@ func (in *ZyncSpec) DeepCopy() *ZyncSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZyncStatus) DeepCopyInto(out *ZyncStatus) {
 	*out = *in
+	in.WorkloadsStatus.DeepCopyInto(&out.WorkloadsStatus)
+	if in.DatabaseMigrations != nil {
+		in, out := &in.DatabaseMigrations, &out.DatabaseMigrations
+		*out = make([]DatabaseMigrationStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZyncStatus.
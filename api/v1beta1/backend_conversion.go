@@ -0,0 +1,66 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this Backend to the Hub version (v1alpha1)
+func (src *Backend) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Backend)
+	dst.ObjectMeta = src.ObjectMeta
+	listener, worker := src.Spec.Listener.DeepCopy(), src.Spec.Worker.DeepCopy()
+	convertCanaryTo(listener.Canary)
+	if worker != nil {
+		convertCanaryTo(worker.Canary)
+	}
+	dst.Spec = v1alpha1.BackendSpec{
+		Image:            src.Spec.Image,
+		Config:           src.Spec.Config,
+		GrafanaDashboard: src.Spec.GrafanaDashboard,
+		Listener:         *listener,
+		Worker:           worker,
+		Cron:             src.Spec.Cron,
+		Twemproxy:        src.Spec.Twemproxy.convertTo(),
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *Backend) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Backend)
+	dst.ObjectMeta = src.ObjectMeta
+	listener, worker := src.Spec.Listener.DeepCopy(), src.Spec.Worker.DeepCopy()
+	convertCanaryFrom(listener.Canary)
+	if worker != nil {
+		convertCanaryFrom(worker.Canary)
+	}
+	dst.Spec = BackendSpec{
+		Image:            src.Spec.Image,
+		Config:           src.Spec.Config,
+		GrafanaDashboard: src.Spec.GrafanaDashboard,
+		Listener:         *listener,
+		Worker:           worker,
+		Cron:             src.Spec.Cron,
+		Twemproxy:        convertFromTwemproxySpec(src.Spec.Twemproxy),
+	}
+	dst.Status = src.Status
+	return nil
+}
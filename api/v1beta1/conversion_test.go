@@ -0,0 +1,237 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+const fuzzIterations = 100
+
+func TestFuzzyConversion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	f := fuzzer.FuzzerFor(metafuzzer.Funcs, rand.NewSource(rand.Int63()), serializer.NewCodecFactory(scheme))
+
+	tests := []struct {
+		name  string
+		hub   conversion.Hub
+		spoke conversion.Convertible
+	}{
+		{
+			name:  "Apicast",
+			hub:   &v1alpha1.Apicast{},
+			spoke: &Apicast{},
+		},
+		{
+			name:  "AutoSSL",
+			hub:   &v1alpha1.AutoSSL{},
+			spoke: &AutoSSL{},
+		},
+		{
+			name:  "Backend",
+			hub:   &v1alpha1.Backend{},
+			spoke: &Backend{},
+		},
+		{
+			name:  "CORSProxy",
+			hub:   &v1alpha1.CORSProxy{},
+			spoke: &CORSProxy{},
+		},
+		{
+			name:  "EchoAPI",
+			hub:   &v1alpha1.EchoAPI{},
+			spoke: &EchoAPI{},
+		},
+		{
+			name:  "MappingService",
+			hub:   &v1alpha1.MappingService{},
+			spoke: &MappingService{},
+		},
+		{
+			name:  "RedisShard",
+			hub:   &v1alpha1.RedisShard{},
+			spoke: &RedisShard{},
+		},
+		{
+			name:  "Sentinel",
+			hub:   &v1alpha1.Sentinel{},
+			spoke: &Sentinel{},
+		},
+		{
+			name:  "ShardedRedisBackup",
+			hub:   &v1alpha1.ShardedRedisBackup{},
+			spoke: &ShardedRedisBackup{},
+		},
+		{
+			name:  "System",
+			hub:   &v1alpha1.System{},
+			spoke: &System{},
+		},
+		{
+			name:  "TwemproxyConfig",
+			hub:   &v1alpha1.TwemproxyConfig{},
+			spoke: &TwemproxyConfig{},
+		},
+		{
+			name:  "Zync",
+			hub:   &v1alpha1.Zync{},
+			spoke: &Zync{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"/hub-spoke-hub", func(t *testing.T) {
+			for i := 0; i < fuzzIterations; i++ {
+				hubBefore := tt.hub.DeepCopyObject().(conversion.Hub)
+				f.Fuzz(hubBefore)
+				spoke := tt.spoke.DeepCopyObject().(conversion.Convertible)
+				if err := spoke.ConvertFrom(hubBefore); err != nil {
+					t.Fatalf("ConvertFrom() error = %v", err)
+				}
+				hubAfter := tt.hub.DeepCopyObject().(conversion.Hub)
+				if err := spoke.ConvertTo(hubAfter); err != nil {
+					t.Fatalf("ConvertTo() error = %v", err)
+				}
+				if !apiequality.Semantic.DeepEqual(hubBefore, hubAfter) {
+					t.Fatalf("round trip got diff: %v", deep.Equal(hubBefore, hubAfter))
+				}
+			}
+		})
+		t.Run(tt.name+"/spoke-hub-spoke", func(t *testing.T) {
+			for i := 0; i < fuzzIterations; i++ {
+				spokeBefore := tt.spoke.DeepCopyObject().(conversion.Convertible)
+				f.Fuzz(spokeBefore)
+				hub := tt.hub.DeepCopyObject().(conversion.Hub)
+				if err := spokeBefore.ConvertTo(hub); err != nil {
+					t.Fatalf("ConvertTo() error = %v", err)
+				}
+				spokeAfter := tt.spoke.DeepCopyObject().(conversion.Convertible)
+				if err := spokeAfter.ConvertFrom(hub); err != nil {
+					t.Fatalf("ConvertFrom() error = %v", err)
+				}
+				if !apiequality.Semantic.DeepEqual(spokeBefore, spokeAfter) {
+					t.Fatalf("round trip got diff: %v", deep.Equal(spokeBefore, spokeAfter))
+				}
+			}
+		})
+	}
+}
+
+func TestBackend_ConvertFrom(t *testing.T) {
+	hub := &v1alpha1.Backend{}
+	if err := json.Unmarshal([]byte(`{"spec":{"twemproxy":{"twemproxyConfigRef":"tmc","options":{"metricsAddress":9000}}}}`), hub); err != nil {
+		t.Fatal(err)
+	}
+	spoke := &Backend{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("Backend.ConvertFrom() error = %v", err)
+	}
+	got, err := json.Marshal(spoke.Spec.Twemproxy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"twemproxyConfigRef":"tmc","options":{"metricsPort":9000}}`; string(got) != want {
+		t.Errorf("Backend.ConvertFrom() got = %s, want %s", got, want)
+	}
+}
+
+func TestSystem_ConvertTo(t *testing.T) {
+	spoke := &System{}
+	if err := json.Unmarshal([]byte(`{"spec":{
+		"app":{"canary":{"patches":["[{\"op\":\"replace\",\"path\":\"/twemproxy/options/metricsPort\",\"value\":9000}]"]}},
+		"sidekiqPools":[{"name":"pool","canary":{"patches":["[{\"op\":\"replace\",\"path\":\"/image/tag\",\"value\":\"v2\"}]"]}}]
+	}}`), spoke); err != nil {
+		t.Fatal(err)
+	}
+	hub := &v1alpha1.System{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatalf("System.ConvertTo() error = %v", err)
+	}
+	want := []string{`[{"op":"replace","path":"/twemproxy/options/metricsAddress","value":9000}]`}
+	if diff := deep.Equal(hub.Spec.App.Canary.Patches, want); len(diff) > 0 {
+		t.Errorf("System.ConvertTo() got diff %v", diff)
+	}
+	want = []string{`[{"op":"replace","path":"/image/tag","value":"v2"}]`}
+	if diff := deep.Equal(hub.Spec.SidekiqPools[0].Canary.Patches, want); len(diff) > 0 {
+		t.Errorf("System.ConvertTo() got diff %v", diff)
+	}
+	if got := spoke.Spec.App.Canary.Patches[0]; got != `[{"op":"replace","path":"/twemproxy/options/metricsPort","value":9000}]` {
+		t.Errorf("System.ConvertTo() modified the source patch = %s", got)
+	}
+}
+
+func Test_renameTwemproxyOption(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name:  "Renames the option in the path",
+			patch: `[{"op": "replace", "path": "/twemproxy/options/metricsPort", "value": 9000}]`,
+			want:  `[{"op":"replace","path":"/twemproxy/options/metricsAddress","value":9000}]`,
+		},
+		{
+			name:  "Renames the option in the from path",
+			patch: `[{"op": "copy", "from": "/twemproxy/options/metricsPort", "path": "/twemproxy/options/logLevel"}]`,
+			want:  `[{"from":"/twemproxy/options/metricsAddress","op":"copy","path":"/twemproxy/options/logLevel"}]`,
+		},
+		{
+			name:  "Renames the option in the value of the options",
+			patch: `[{"op": "replace", "path": "/twemproxy/options", "value": {"metricsPort": 9000}}]`,
+			want:  `[{"op":"replace","path":"/twemproxy/options","value":{"metricsAddress":9000}}]`,
+		},
+		{
+			name:  "Renames the option in the value of the twemproxy spec",
+			patch: `[{"op": "add", "path": "/twemproxy", "value": {"twemproxyConfigRef": "tmc", "options": {"metricsPort": 9000}}}]`,
+			want:  `[{"op":"add","path":"/twemproxy","value":{"options":{"metricsAddress":9000},"twemproxyConfigRef":"tmc"}}]`,
+		},
+		{
+			name:  "Leaves other patches unchanged",
+			patch: `[{"op": "replace", "path": "/twemproxy/options/logLevel", "value": 9}, {"op": "replace", "path": "/listener/metricsPort", "value": 9000}]`,
+			want:  `[{"op": "replace", "path": "/twemproxy/options/logLevel", "value": 9}, {"op": "replace", "path": "/listener/metricsPort", "value": 9000}]`,
+		},
+		{
+			name:  "Leaves invalid patches unchanged",
+			patch: `{"op": "replace"`,
+			want:  `{"op": "replace"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renameTwemproxyOption(tt.patch, metricsPortField, hubMetricsPortField); got != tt.want {
+				t.Errorf("renameTwemproxyOption() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
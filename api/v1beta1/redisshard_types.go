@@ -0,0 +1,49 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// RedisShard is the Schema for the redisshards API
+// +kubebuilder:printcolumn:JSONPath=".status.shardNodes.master",name=Master,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.shardNodes.slaves",name=Slaves,type=string
+type RedisShard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   v1alpha1.RedisShardSpec   `json:"spec,omitempty"`
+	Status v1alpha1.RedisShardStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RedisShardList contains a list of RedisShard
+type RedisShardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedisShard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedisShard{}, &RedisShardList{})
+}
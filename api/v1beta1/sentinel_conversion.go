@@ -0,0 +1,66 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this Sentinel to the Hub version (v1alpha1)
+func (src *Sentinel) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Sentinel)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.SentinelSpec{
+		Image:            src.Spec.Image,
+		Replicas:         src.Spec.Replicas,
+		PDB:              src.Spec.PDB,
+		Resources:        src.Spec.Resources,
+		LivenessProbe:    src.Spec.LivenessProbe,
+		ReadinessProbe:   src.Spec.ReadinessProbe,
+		GrafanaDashboard: src.Spec.GrafanaDashboard,
+		NodeAffinity:     src.Spec.NodeAffinity,
+		Tolerations:      src.Spec.Tolerations,
+		// the configs only differ in the json tags
+		Config:  (*v1alpha1.SentinelConfig)(src.Spec.Config),
+		Rollout: src.Spec.Rollout,
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *Sentinel) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Sentinel)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = SentinelSpec{
+		Image:            src.Spec.Image,
+		Replicas:         src.Spec.Replicas,
+		PDB:              src.Spec.PDB,
+		Resources:        src.Spec.Resources,
+		LivenessProbe:    src.Spec.LivenessProbe,
+		ReadinessProbe:   src.Spec.ReadinessProbe,
+		GrafanaDashboard: src.Spec.GrafanaDashboard,
+		NodeAffinity:     src.Spec.NodeAffinity,
+		Tolerations:      src.Spec.Tolerations,
+		// the configs only differ in the json tags
+		Config:  (*SentinelConfig)(src.Spec.Config),
+		Rollout: src.Spec.Rollout,
+	}
+	dst.Status = src.Status
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SentinelConfig defines configuration options for the component
+type SentinelConfig struct {
+	// Monitored shards indicates the redis servers that form
+	// part of each shard monitored by sentinel
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MonitoredShards map[string][]string `json:"monitoredShards,omitempty"`
+	// ClusterTopology indicates the redis servers that form
+	// part of each shard monitored by sentinel
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ClusterTopology map[string]map[string]string `json:"clusterTopology,omitempty"`
+	// StorageClass is the storage class to be used for
+	// the persistent sentinel config file where the shards
+	// state is stored
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	StorageClass *string `json:"storageClass,omitempty"`
+	// StorageSize is the storage size to  provision for
+	// the persistent sentinel config file where the shards
+	// state is stored
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	StorageSize *resource.Quantity `json:"storageSize,omitempty"`
+	// MetricsRefreshInterval determines the refresh interval for gahtering
+	// metrics from sentinel
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MetricsRefreshInterval *time.Duration `json:"metricsRefreshInterval,omitempty"`
+}
+
+// SentinelSpec defines the desired state of Sentinel
+type SentinelSpec struct {
+	// Image specification for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Image *v1alpha1.ImageSpec `json:"image,omitempty"`
+	// Number of replicas (ignored if hpa is enabled) for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Pod Disruption Budget for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	PDB *v1alpha1.PodDisruptionBudgetSpec `json:"pdb,omitempty"`
+	// Resource requirements for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Resources *v1alpha1.ResourceRequirementsSpec `json:"resources,omitempty"`
+	// Liveness probe for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	LivenessProbe *v1alpha1.ProbeSpec `json:"livenessProbe,omitempty"`
+	// Readiness probe for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ReadinessProbe *v1alpha1.ProbeSpec `json:"readinessProbe,omitempty"`
+	// Configures the Grafana Dashboard for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	GrafanaDashboard *v1alpha1.GrafanaDashboardSpec `json:"grafanaDashboard,omitempty"`
+	// Describes node affinity scheduling rules for the pod.
+	// +optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty" protobuf:"bytes,1,opt,name=nodeAffinity"`
+	// If specified, the pod's tolerations.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty" protobuf:"bytes,22,opt,name=tolerations"`
+	// Config configures the sentinel process
+	Config *SentinelConfig `json:"config"`
+	// Configures how changes are rolled out to the pods of the StatefulSet
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Rollout *v1alpha1.StatefulSetRolloutSpec `json:"rollout,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.sentinels",name=Sentinels,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.monitoredShards",name=Shards,type=string
+// Sentinel is the Schema for the sentinels API
+type Sentinel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SentinelSpec            `json:"spec,omitempty"`
+	Status v1alpha1.SentinelStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SentinelList contains a list of Sentinel
+type SentinelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Sentinel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Sentinel{}, &SentinelList{})
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this System to the Hub version (v1alpha1)
+func (src *System) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.System)
+	dst.ObjectMeta = src.ObjectMeta
+	spec := src.Spec.DeepCopy()
+	dst.Spec = v1alpha1.SystemSpec{
+		Config:            spec.Config,
+		Image:             spec.Image,
+		App:               spec.App,
+		SidekiqDefault:    spec.SidekiqDefault,
+		SidekiqBilling:    spec.SidekiqBilling,
+		SidekiqLow:        spec.SidekiqLow,
+		SidekiqPools:      spec.SidekiqPools,
+		Searchd:           spec.Searchd,
+		DatabaseMigration: spec.DatabaseMigration,
+		Console:           spec.Console,
+		Tasks:             spec.Tasks,
+		GrafanaDashboard:  spec.GrafanaDashboard,
+		Twemproxy:         spec.Twemproxy.convertTo(),
+	}
+	for _, canary := range systemCanaries(&dst.Spec) {
+		convertCanaryTo(canary)
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *System) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.System)
+	dst.ObjectMeta = src.ObjectMeta
+	spec := src.Spec.DeepCopy()
+	for _, canary := range systemCanaries(spec) {
+		convertCanaryFrom(canary)
+	}
+	dst.Spec = SystemSpec{
+		Config:            spec.Config,
+		Image:             spec.Image,
+		App:               spec.App,
+		SidekiqDefault:    spec.SidekiqDefault,
+		SidekiqBilling:    spec.SidekiqBilling,
+		SidekiqLow:        spec.SidekiqLow,
+		SidekiqPools:      spec.SidekiqPools,
+		Searchd:           spec.Searchd,
+		DatabaseMigration: spec.DatabaseMigration,
+		Console:           spec.Console,
+		Tasks:             spec.Tasks,
+		GrafanaDashboard:  spec.GrafanaDashboard,
+		Twemproxy:         convertFromTwemproxySpec(spec.Twemproxy),
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// systemCanaries returns the canaries of the Deployments of the System spec
+func systemCanaries(spec *v1alpha1.SystemSpec) []*v1alpha1.Canary {
+	canaries := []*v1alpha1.Canary{}
+	if spec.App != nil {
+		canaries = append(canaries, spec.App.Canary)
+	}
+	for _, sidekiq := range []*v1alpha1.SystemSidekiqSpec{spec.SidekiqDefault, spec.SidekiqBilling, spec.SidekiqLow} {
+		if sidekiq != nil {
+			canaries = append(canaries, sidekiq.Canary)
+		}
+	}
+	for i := range spec.SidekiqPools {
+		canaries = append(canaries, spec.SidekiqPools[i].Canary)
+	}
+	return canaries
+}
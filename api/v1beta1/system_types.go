@@ -0,0 +1,112 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SystemSpec defines the desired state of System
+type SystemSpec struct {
+	// Application specific configuration options for System components
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Config v1alpha1.SystemConfig `json:"config"`
+	// Image specification for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Image *v1alpha1.ImageSpec `json:"image,omitempty"`
+	// Application specific configuration options
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	App *v1alpha1.SystemAppSpec `json:"app,omitempty"`
+	// Sidekiq Default specific configuration options
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SidekiqDefault *v1alpha1.SystemSidekiqSpec `json:"sidekiqDefault,omitempty"`
+	// Sidekiq Billing specific configuration options
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SidekiqBilling *v1alpha1.SystemSidekiqSpec `json:"sidekiqBilling,omitempty"`
+	// Sidekiq Low specific configuration options
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SidekiqLow *v1alpha1.SystemSidekiqSpec `json:"sidekiqLow,omitempty"`
+	// Additional named Sidekiq pools. Each pool is deployed as a separate
+	// "system-sidekiq-<name>" Deployment. The names "default", "billing" and "low"
+	// are reserved for the sidekiqDefault, sidekiqBilling and sidekiqLow pools.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SidekiqPools []v1alpha1.SystemSidekiqPoolSpec `json:"sidekiqPools,omitempty"`
+	// Searchd specific configuration options
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Searchd *v1alpha1.SystemSearchdSpec `json:"searchd,omitempty"`
+	// Configures the Job that runs the database migrations before the
+	// app and sidekiq Deployments are rolled out to a new image
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	DatabaseMigration *v1alpha1.SystemDatabaseMigrationSpec `json:"databaseMigration,omitempty"`
+	// Console specific configuration options
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Console *v1alpha1.SystemRailsConsoleSpec `json:"console,omitempty"`
+	// Configures the Tekton Tasks for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Tasks []v1alpha1.SystemTektonTaskSpec `json:"tasks,omitempty"`
+	// Configures the Grafana Dashboard for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	GrafanaDashboard *v1alpha1.GrafanaDashboardSpec `json:"grafanaDashboard,omitempty"`
+	// Configures twemproxy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Twemproxy *TwemproxySpec `json:"twemproxy,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Ready")].status`,name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Progressing")].status`,name=Progressing,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,name=Degraded,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.workloads[0].deployment.image`,name=Image,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name=Age,type=date
+
+// System is the Schema for the systems API
+type System struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SystemSpec            `json:"spec,omitempty"`
+	Status v1alpha1.SystemStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SystemList contains a list of System
+type SystemList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []System `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&System{}, &SystemList{})
+}
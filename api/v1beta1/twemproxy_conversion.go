@@ -0,0 +1,169 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+)
+
+const (
+	// metricsPortField is the json name of the metrics port
+	// of the twemproxy options in this version
+	metricsPortField string = "metricsPort"
+	// hubMetricsPortField is the json name of the metrics port
+	// of the twemproxy options in the Hub version (v1alpha1)
+	hubMetricsPortField string = "metricsAddress"
+)
+
+// twemproxyOptionsPath is the location of the twemproxy options in the
+// specs that embed them, which is the document canary patches apply to
+var twemproxyOptionsPath = []string{"twemproxy", "options"}
+
+// convertTo converts the twemproxy spec to the Hub version (v1alpha1).
+// The patches of the canaries of the resource, which might target the
+// twemproxy options, are converted separately with convertCanaryTo.
+func (spec *TwemproxySpec) convertTo() *v1alpha1.TwemproxySpec {
+	if spec == nil {
+		return nil
+	}
+	return &v1alpha1.TwemproxySpec{
+		Image:              spec.Image,
+		Resources:          spec.Resources,
+		LivenessProbe:      spec.LivenessProbe,
+		ReadinessProbe:     spec.ReadinessProbe,
+		TwemproxyConfigRef: spec.TwemproxyConfigRef,
+		// the options only differ in the json tags
+		Options: (*v1alpha1.TwemproxyOptions)(spec.Options),
+	}
+}
+
+// convertFromTwemproxySpec converts the twemproxy spec from the Hub version (v1alpha1)
+func convertFromTwemproxySpec(spec *v1alpha1.TwemproxySpec) *TwemproxySpec {
+	if spec == nil {
+		return nil
+	}
+	return &TwemproxySpec{
+		Image:              spec.Image,
+		Resources:          spec.Resources,
+		LivenessProbe:      spec.LivenessProbe,
+		ReadinessProbe:     spec.ReadinessProbe,
+		TwemproxyConfigRef: spec.TwemproxyConfigRef,
+		Options:            (*TwemproxyOptions)(spec.Options),
+	}
+}
+
+// convertCanaryTo converts the twemproxy options in the patches of the canary
+// to the Hub version (v1alpha1). The canary is modified in place, so it must
+// belong to a copy of the spec being converted.
+func convertCanaryTo(canary *v1alpha1.Canary) {
+	convertCanaryPatches(canary, metricsPortField, hubMetricsPortField)
+}
+
+// convertCanaryFrom converts the twemproxy options in the patches of the canary
+// from the Hub version (v1alpha1). The canary is modified in place, so it must
+// belong to a copy of the spec being converted.
+func convertCanaryFrom(canary *v1alpha1.Canary) {
+	convertCanaryPatches(canary, hubMetricsPortField, metricsPortField)
+}
+
+func convertCanaryPatches(canary *v1alpha1.Canary, from, to string) {
+	if canary == nil {
+		return
+	}
+	for i, patch := range canary.Patches {
+		canary.Patches[i] = renameTwemproxyOption(patch, from, to)
+	}
+}
+
+// renameTwemproxyOption renames the given twemproxy option in the paths and values
+// of the operations of a JSON patch. The patch is returned unchanged if it does not
+// reference the option or cannot be decoded, in which case it is left to the
+// validation of the canary to report the error.
+func renameTwemproxyOption(patch string, from, to string) string {
+	ops := []map[string]interface{}{}
+	if err := json.Unmarshal([]byte(patch), &ops); err != nil {
+		return patch
+	}
+
+	changed := false
+	for _, op := range ops {
+		for _, key := range []string{"from", "path"} {
+			path, ok := op[key].(string)
+			if !ok {
+				continue
+			}
+			tokens := strings.Split(path, "/")[1:]
+			if len(tokens) > len(twemproxyOptionsPath) && isPrefix(twemproxyOptionsPath, tokens) &&
+				tokens[len(twemproxyOptionsPath)] == from {
+				tokens[len(twemproxyOptionsPath)] = to
+				op[key] = "/" + strings.Join(tokens, "/")
+				changed = true
+			}
+		}
+
+		// the value of the operation might contain the options,
+		// for example when it replaces the whole twemproxy spec
+		path, ok := op["path"].(string)
+		if !ok || op["value"] == nil {
+			continue
+		}
+		tokens := []string{}
+		if path != "" {
+			tokens = strings.Split(path, "/")[1:]
+		}
+		if !isPrefix(tokens, twemproxyOptionsPath) {
+			continue
+		}
+		options, ok := op["value"].(map[string]interface{})
+		for _, token := range twemproxyOptionsPath[len(tokens):] {
+			if !ok {
+				break
+			}
+			options, ok = options[token].(map[string]interface{})
+		}
+		if v, found := options[from]; ok && found {
+			delete(options, from)
+			options[to] = v
+			changed = true
+		}
+	}
+
+	if !changed {
+		return patch
+	}
+	out, err := json.Marshal(ops)
+	if err != nil {
+		return patch
+	}
+	return string(out)
+}
+
+// isPrefix returns true if the prefix tokens are the first tokens of the path
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
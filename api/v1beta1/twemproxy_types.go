@@ -0,0 +1,66 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TwemproxySpec configures twemproxy sidecars
+// to access a sharded redis
+type TwemproxySpec struct {
+	// Image specification for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Image *v1alpha1.ImageSpec `json:"image,omitempty"`
+	// Resource requirements for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Resources *v1alpha1.ResourceRequirementsSpec `json:"resources,omitempty"`
+	// Liveness probe for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	LivenessProbe *v1alpha1.ProbeSpec `json:"livenessProbe,omitempty"`
+	// Readiness probe for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ReadinessProbe *v1alpha1.ProbeSpec `json:"readinessProbe,omitempty"`
+	// TwemproxyConfigRef is a reference to a TwemproxyConfig
+	// resource in the same Namespace
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TwemproxyConfigRef string `json:"twemproxyConfigRef"`
+	// Options
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Options *TwemproxyOptions `json:"options,omitempty"`
+}
+
+type TwemproxyOptions struct {
+	// Set logging level to N. (default: 5, min: 0, max: 11)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	LogLevel *int32 `json:"logLevel,omitempty"`
+	// Set stats monitoring port to port.  (default: 9151)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MetricsPort *int32 `json:"metricsPort,omitempty"`
+	// Set stats aggregation interval in msec to interval.  (default: 30s)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	StatsInterval *metav1.Duration `json:"statsInterval,omitempty"`
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/3scale-ops/saas-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TwemproxyConfigSpec defines the desired state of TwemproxyConfig
+type TwemproxyConfigSpec struct {
+	// SentinelRefs is the list of Sentinel resources that monitor the
+	// shards targeted by the server pools. The Sentinel endpoints are
+	// resolved from the status of each of the Sentinel resources. Several
+	// references can be used in split clusters, where each Sentinel set
+	// monitors a different subset of the shards. If not set, the controller
+	// will try to autodiscover a Sentinel resource within the namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SentinelRefs []v1alpha1.SentinelReference `json:"sentinelRefs,omitempty"`
+	// ServerPools is the list of Twemproxy server pools
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ServerPools []v1alpha1.TwemproxyServerPool `json:"serverPools"`
+	// ReconcileServerPools is a flag that allows to deactivate
+	// the reconcile of the contents of the managed ConfigMap. This is
+	// useful in an emergency, to fix something manually. The re-sync
+	// logic will still work whenever the contents of the ConfigMap
+	// are changed, even if they are manually changed.
+	// This switch defaults to "true".
+	ReconcileServerPools *bool `json:"reconcileServerPools,omitempty"`
+	// Preview configures the preview mode. When the preview mode is enabled,
+	// changes in the server pools are not directly applied to the managed
+	// ConfigMap. A preview of the changes is published in the status instead
+	// and the changes are only promoted to the ConfigMap once approved. Changes
+	// in the targeted servers due to Sentinel failovers are always applied.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Preview *v1alpha1.TwemproxyConfigPreviewSpec `json:"preview,omitempty"`
+	// EnvoyRedisProxy enables the generation of an Envoy configuration equivalent
+	// to the twemproxy one, as an alternative to twemproxy sidecars. Each server pool
+	// is rendered as a listener using the Envoy redis_proxy filter and a cluster
+	// with the targeted servers. The configuration is delivered through a marin3r
+	// EnvoyConfig resource with the same name as the TwemproxyConfig.
+	// NOTE: Envoy does not hash keys in the same way twemproxy does, so keys are
+	// distributed differently across the logical shards of a server pool.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	EnvoyRedisProxy *v1alpha1.EnvoyRedisProxySpec `json:"envoyRedisProxy,omitempty"`
+	// Configures the Grafana Dashboard for the component
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	GrafanaDashboard *v1alpha1.GrafanaDashboardSpec `json:"grafanaDashboard,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=`.status.targets`,name=Selected Targets,type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.preview.hash`,name=Pending Preview,type=string
+// TwemproxyConfig is the Schema for the twemproxyconfigs API
+type TwemproxyConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TwemproxyConfigSpec            `json:"spec,omitempty"`
+	Status v1alpha1.TwemproxyConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TwemproxyConfigList contains a list of TwemproxyConfig
+type TwemproxyConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TwemproxyConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TwemproxyConfig{}, &TwemproxyConfigList{})
+}
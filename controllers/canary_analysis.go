@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/3scale-ops/saas-operator/pkg/canary"
+	deployment_workload "github.com/3scale-ops/saas-operator/pkg/workloads/deployment"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileCanaryAnalysis evaluates the analysis of the canaries of the given workloads and
+// stores the results in the status of the custom resource. Canaries that fail the analysis
+// are removed from the custom resource. An Event is emitted each time an analysis finishes.
+// It returns the time until the analyses need to be evaluated again.
+func reconcileCanaryAnalysis(ctx context.Context, cl client.Client, recorder record.EventRecorder,
+	instance objectWithWorkloadsStatus, workloads ...deployment_workload.WithCanary) (time.Duration, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	status := instance.GetWorkloadsStatus()
+	desired := status.DeepCopy()
+	remove := []deployment_workload.WithCanary{}
+	var requeue time.Duration
+
+	for _, w := range workloads {
+		if w.Spec == nil || w.Spec.Analysis == nil {
+			continue
+		}
+
+		key := w.Canary.GetKey()
+		dep := &appsv1.Deployment{}
+		if err := cl.Get(ctx, key, dep); err != nil {
+			if apierrors.IsNotFound(err) {
+				// the analysis starts once the canary Deployment is created
+				continue
+			}
+			return 0, err
+		}
+
+		current := findCanaryAnalysis(desired.CanaryAnalyses, w.Main.GetKey().Name).DeepCopy()
+		// the analysis starts again each time the pod template of the canary changes
+		result, next := canary.Analyze(ctx, w.Spec.Analysis, key, util.Hash(dep.Spec.Template), current, time.Now())
+		result.Workload = w.Main.GetKey().Name
+		setCanaryAnalysis(&desired.CanaryAnalyses, *result)
+
+		if current == nil || current.Phase != result.Phase || current.Revision != result.Revision {
+			switch result.Phase {
+			case saasv1alpha1.CanaryAnalysisFailed:
+				recorder.Eventf(instance, corev1.EventTypeWarning, "CanaryAnalysisFailed",
+					"canary %s removed: %s", key.Name, result.Message)
+			case saasv1alpha1.CanaryAnalysisSucceeded:
+				recorder.Eventf(instance, corev1.EventTypeNormal, "CanaryAnalysisSucceeded",
+					"canary %s: %s", key.Name, result.Message)
+			}
+		}
+
+		if result.Phase == saasv1alpha1.CanaryAnalysisFailed {
+			remove = append(remove, w)
+		}
+		if next > 0 && (requeue == 0 || next < requeue) {
+			requeue = next
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(status, desired) {
+		*status = *desired
+		if err := cl.Status().Update(ctx, instance); err != nil {
+			logger.Error(err, "unable to update status")
+			return 0, err
+		}
+	}
+
+	if len(remove) > 0 {
+		if err := removeCanaries(ctx, cl, instance, remove...); err != nil {
+			logger.Error(err, "unable to remove failed canaries")
+			return 0, err
+		}
+		paths := make([]string, 0, len(remove))
+		for _, w := range remove {
+			paths = append(paths, w.SpecPath)
+		}
+		logger.Info("failed canaries removed", "paths", paths)
+	}
+
+	return requeue, nil
+}
+
+// removeCanaries removes the canary configuration of the given workloads from the custom
+// resource. The patch fails if any of the SpecTests of the workloads does not hold.
+func removeCanaries(ctx context.Context, cl client.Client, instance client.Object, workloads ...deployment_workload.WithCanary) error {
+	ops := make([]map[string]string, 0, len(workloads))
+	for _, w := range workloads {
+		for _, test := range w.SpecTests {
+			ops = append(ops, map[string]string{"op": "test", "path": test.Path, "value": test.Value})
+		}
+		ops = append(ops, map[string]string{"op": "remove", "path": w.SpecPath})
+	}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return err
+	}
+	return cl.Patch(ctx, instance, client.RawPatch(types.JSONPatchType, patch))
+}
+
+func findCanaryAnalysis(list []saasv1alpha1.CanaryAnalysisStatus, workload string) *saasv1alpha1.CanaryAnalysisStatus {
+	for i := range list {
+		if list[i].Workload == workload {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+func setCanaryAnalysis(list *[]saasv1alpha1.CanaryAnalysisStatus, status saasv1alpha1.CanaryAnalysisStatus) {
+	if current := findCanaryAnalysis(*list, status.Workload); current != nil {
+		*current = status
+		return
+	}
+	*list = append(*list, status)
+}
+
+// minRequeue returns the shortest of the given non-zero durations
+func minRequeue(durations ...time.Duration) time.Duration {
+	var min time.Duration
+	for _, d := range durations {
+		if d > 0 && (min == 0 || d < min) {
+			min = d
+		}
+	}
+	return min
+}
@@ -71,11 +71,9 @@ func (r *CORSProxyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	result = r.ReconcileOwnedResources(ctx, instance, resources)
-	if result.ShouldReturn() {
-		return result.Values()
-	}
 
-	return ctrl.Result{}, nil
+	return reconcileWorkloads(ctx, r.Client, nil, instance, result,
+		result.Error, gen.Workloads()...)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/3scale-ops/basereconciler/resource"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	migration_workload "github.com/3scale-ops/saas-operator/pkg/workloads/migration"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// objectWithDatabaseMigrations is a custom resource that runs the database
+// migrations before its Deployments are rolled out to a new image
+type objectWithDatabaseMigrations interface {
+	client.Object
+	GetDatabaseMigrationsStatus() *[]saasv1alpha1.DatabaseMigrationStatus
+}
+
+// reconcileDatabaseMigrations evaluates the given migration gates and updates the status
+// of the custom resource with the status of the pending migrations. It returns the
+// templates of the migration Jobs, which must be reconciled along with the rest of the
+// owned resources. The gates modify the images of the generators of the Deployments, so
+// this must be called before the templates of the Deployments are generated.
+// The Job of a migration whose image is no longer desired is kept until it completes,
+// and no new migration Job is created in the meantime. A failed migration is retried
+// by setting the saas.3scale.net/database-migration-retry annotation to a new value.
+func reconcileDatabaseMigrations(ctx context.Context, cl client.Client, instance objectWithDatabaseMigrations,
+	gates ...migration_workload.Gate) ([]resource.TemplateInterface, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	templates := []resource.TemplateInterface{}
+	migrations := []saasv1alpha1.DatabaseMigrationStatus{}
+
+	desired := map[string]bool{}
+	for _, gate := range gates {
+		desired[gate.Desired()] = true
+	}
+	for _, m := range *instance.GetDatabaseMigrationsStatus() {
+		if len(gates) == 0 || desired[m.Image] || m.Phase != saasv1alpha1.DatabaseMigrationRunning {
+			continue
+		}
+		template, status, err := migration_workload.InFlight(ctx, cl, gates[0].Workload, m)
+		if err != nil {
+			return nil, err
+		}
+		if status == nil {
+			continue
+		}
+		templates = append(templates, template)
+		migrations = append(migrations, *status)
+	}
+
+	inFlight := len(migrations) > 0
+	for _, gate := range gates {
+		gate.Retry = instance.GetAnnotations()[saasv1alpha1.DatabaseMigrationRetryAnnotation]
+		gate.Hold = inFlight
+		template, status, err := gate.Reconcile(ctx, cl)
+		if err != nil {
+			return nil, err
+		}
+		if status == nil || containsMigration(migrations, status.Image) {
+			continue
+		}
+		if template != nil {
+			templates = append(templates, template)
+		}
+		migrations = append(migrations, *status)
+	}
+
+	status := instance.GetDatabaseMigrationsStatus()
+	if len(migrations) == 0 {
+		migrations = nil
+	}
+	if !equality.Semantic.DeepEqual(*status, migrations) {
+		*status = migrations
+		if err := cl.Status().Update(ctx, instance); err != nil {
+			logger.Error(err, "unable to update status")
+			return nil, err
+		}
+		logger.Info("status updated")
+	}
+
+	return templates, nil
+}
+
+// failedDatabaseMigrations returns an error describing the failed database
+// migrations of the custom resource, or nil if none has failed
+func failedDatabaseMigrations(instance objectWithDatabaseMigrations) error {
+	failures := []string{}
+	for _, m := range *instance.GetDatabaseMigrationsStatus() {
+		if m.Phase == saasv1alpha1.DatabaseMigrationFailed {
+			failures = append(failures, fmt.Sprintf("%s: %s", m.Job, m.Message))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("database migrations failed, rollout blocked (set a new value in the %s annotation to retry): %s",
+		saasv1alpha1.DatabaseMigrationRetryAnnotation, strings.Join(failures, "; "))
+}
+
+func containsMigration(migrations []saasv1alpha1.DatabaseMigrationStatus, image string) bool {
+	for _, m := range migrations {
+		if m.Image == image {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/factory"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// recordEnvoyConfigErrors emits a warning Event for the custom resource if the given
+// error was caused by an invalid Envoy dynamic configuration. The error is also reported
+// in the Degraded condition of the status by reconcileWorkloadsStatus.
+func recordEnvoyConfigErrors(recorder record.EventRecorder, instance client.Object, err error) {
+	var verr *factory.ValidationError
+	if errors.As(err, &verr) {
+		recorder.Event(instance, corev1.EventTypeWarning, "InvalidEnvoyConfig", verr.Error())
+	}
+}
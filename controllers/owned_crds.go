@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ownsIfInstalled configures the controller to watch the owned resources of the type of
+// the given object, but only if its CRD is installed in the cluster. This is used for
+// the APIs of optional dependencies, like cert-manager, so the controller can still
+// start in clusters where they are not installed.
+func ownsIfInstalled(mgr ctrl.Manager, b *builder.Builder, obj client.Object) (*builder.Builder, error) {
+	gvk, err := apiutil.GVKForObject(obj, mgr.GetScheme())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			ctrl.Log.WithName("setup").Info("CRD not installed, not watching owned resources", "gvk", gvk.String())
+			return b, nil
+		}
+		return nil, err
+	}
+	return b.Owns(obj), nil
+}
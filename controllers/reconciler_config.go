@@ -88,6 +88,16 @@ func init() {
 				"spec.template.spec.initContainers[*].terminationMessagePolicy",
 			},
 		})
+	config.SetDefaultReconcileConfigForGVK(
+		// the spec of a Job is immutable, so the Jobs are named
+		// after their spec instead of being updated
+		schema.FromAPIVersionAndKind("batch/v1", "Job"),
+		config.ReconcileConfigForGVK{
+			EnsureProperties: []string{
+				"metadata.annotations",
+				"metadata.labels",
+			},
+		})
 	config.SetDefaultReconcileConfigForGVK(
 		schema.FromAPIVersionAndKind("autoscaling/v2", "HorizontalPodAutoscaler"),
 		config.ReconcileConfigForGVK{
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// objectWithSentinelRefs is a custom resource that resolves
+// its redis endpoints from the status of Sentinel resources
+type objectWithSentinelRefs interface {
+	SentinelKeys() []types.NamespacedName
+	UsesSentinel(key types.NamespacedName) bool
+}
+
+// getSentinelStatuses returns the status of the Sentinel resources referenced by the
+// custom resource, keyed by their namespaced name. Sentinels that are not found are left out of the
+// map so the generators can report the unresolved reference.
+func getSentinelStatuses(ctx context.Context, cl client.Client,
+	instance objectWithSentinelRefs) (map[types.NamespacedName]saasv1alpha1.SentinelStatus, error) {
+
+	keys := instance.SentinelKeys()
+	statuses := make(map[types.NamespacedName]saasv1alpha1.SentinelStatus, len(keys))
+	for _, key := range keys {
+		sentinel := &saasv1alpha1.Sentinel{}
+		if err := cl.Get(ctx, key, sentinel); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		statuses[key] = sentinel.Status
+	}
+	return statuses, nil
+}
+
+// usesSentinelRef filters events of Sentinel resources so only the custom resources
+// that resolve their redis endpoints from the Sentinel get reconciled. This ensures
+// that changes in the sentinel endpoints are propagated to the workloads.
+func usesSentinelRef(event client.Object, o client.Object) bool {
+	return o.(objectWithSentinelRefs).UsesSentinel(client.ObjectKeyFromObject(event))
+}
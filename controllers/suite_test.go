@@ -19,7 +19,10 @@ package controllers
 import (
 	"context"
 	"crypto/rand"
+	"crypto/tls"
+	"fmt"
 	"math/big"
+	"net"
 	"path/filepath"
 	"testing"
 	"time"
@@ -41,6 +44,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	saasv1beta1 "github.com/3scale-ops/saas-operator/api/v1beta1"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	externalsecretsv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -75,18 +80,26 @@ var _ = BeforeSuite(func() {
 			filepath.Join("..", "config", "crd", "bases"),
 			filepath.Join("..", "config", "test", "external-apis"),
 		},
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "config", "webhook")},
+		},
 	}
 
 	nBig, err := rand.Int(rand.Reader, big.NewInt(1000000))
 	Expect(err).NotTo(HaveOccurred())
 	nameGenerator = namegenerator.NewNameGenerator(nBig.Int64())
 
+	// the saas API versions need to be registered before starting the
+	// environment so the CRDs get configured with the conversion webhook
+	err = saasv1alpha1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+	err = saasv1beta1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	cfg, err := testEnv.Start()
 	Expect(err).NotTo(HaveOccurred())
 	Expect(cfg).NotTo(BeNil())
 
-	err = saasv1alpha1.AddToScheme(scheme.Scheme)
-	Expect(err).NotTo(HaveOccurred())
 	err = monitoringv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 	err = grafanav1alpha1.AddToScheme(scheme.Scheme)
@@ -97,18 +110,37 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	err = pipelinev1beta1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = certmanagerv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
 
+	webhookInstallOptions := &testEnv.WebhookInstallOptions
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme.Scheme,
 		// Disable the metrics port to allow running the
 		// test suite in parallel
 		MetricsBindAddress: "0",
+		Host:               webhookInstallOptions.LocalServingHost,
+		Port:               webhookInstallOptions.LocalServingPort,
+		CertDir:            webhookInstallOptions.LocalServingCertDir,
 	})
 	Expect(err).ToNot(HaveOccurred())
 
 	k8sClient = mgr.GetClient()
 	Expect(k8sClient).ToNot(BeNil())
 
+	// Add webhooks for testing
+	for _, o := range []interface {
+		SetupWebhookWithManager(ctrl.Manager) error
+	}{
+		&saasv1alpha1.AutoSSL{}, &saasv1alpha1.Apicast{}, &saasv1alpha1.EchoAPI{},
+		&saasv1alpha1.MappingService{}, &saasv1alpha1.CORSProxy{}, &saasv1alpha1.Backend{},
+		&saasv1alpha1.System{}, &saasv1alpha1.Zync{}, &saasv1alpha1.Sentinel{},
+		&saasv1alpha1.RedisShard{}, &saasv1alpha1.TwemproxyConfig{}, &saasv1alpha1.ShardedRedisBackup{},
+	} {
+		err = o.SetupWebhookWithManager(mgr)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
 	ctx, cancel = context.WithCancel(context.Background())
 
 	go func() {
@@ -117,24 +149,38 @@ var _ = BeforeSuite(func() {
 		Expect(err).ToNot(HaveOccurred())
 	}()
 
+	// Wait for the webhook server to be ready
+	dialer := &net.Dialer{Timeout: time.Second}
+	addrPort := fmt.Sprintf("%s:%d", webhookInstallOptions.LocalServingHost, webhookInstallOptions.LocalServingPort)
+	Eventually(func() error {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addrPort, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}, timeout, poll).Should(Succeed())
+
 	redisPool := redis.NewServerPool()
 
 	// Add controllers for testing
 	err = (&AutoSSLReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("AutoSSL")),
+		Recorder: mgr.GetEventRecorderFor("autossl-controller"),
 	}).SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&ApicastReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("Apicast")),
+		Recorder: mgr.GetEventRecorderFor("apicast-controller"),
 	}).SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&EchoAPIReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("EchoAPI")),
+		Recorder: mgr.GetEventRecorderFor("echoapi-controller"),
 	}).SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -153,6 +199,7 @@ var _ = BeforeSuite(func() {
 	err = (&BackendReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("Backend")),
+		Recorder: mgr.GetEventRecorderFor("backend-controller"),
 	}).SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -175,6 +222,7 @@ var _ = BeforeSuite(func() {
 	err = (&SystemReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("System")),
+		Recorder: mgr.GetEventRecorderFor("system-controller"),
 	}).SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 
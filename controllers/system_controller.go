@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"errors"
 
 	"github.com/3scale-ops/basereconciler/reconciler"
 	"github.com/3scale-ops/basereconciler/util"
@@ -29,9 +30,11 @@ import (
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -39,14 +42,18 @@ import (
 // SystemReconciler reconciles a System object
 type SystemReconciler struct {
 	*reconciler.Reconciler
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=saas.3scale.net,namespace=placeholder,resources=systems,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=saas.3scale.net,namespace=placeholder,resources=systems/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=saas.3scale.net,namespace=placeholder,resources=systems/finalizers,verbs=update
+// +kubebuilder:rbac:groups=saas.3scale.net,namespace=placeholder,resources=sentinels,verbs=get;list;watch
 // +kubebuilder:rbac:groups="core",namespace=placeholder,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="core",namespace=placeholder,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="apps",namespace=placeholder,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="apps",namespace=placeholder,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="batch",namespace=placeholder,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="monitoring.coreos.com",namespace=placeholder,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="autoscaling",namespace=placeholder,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="policy",namespace=placeholder,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
@@ -67,21 +74,34 @@ func (r *SystemReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return result.Values()
 	}
 
-	gen, err := system.NewGenerator(instance.GetName(), instance.GetNamespace(), instance.Spec)
+	sentinels, err := getSentinelStatuses(ctx, r.Client, instance)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	resources, err := gen.Resources()
+
+	gen, err := system.NewGenerator(instance.GetName(), instance.GetNamespace(), instance.Spec, sentinels)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	result = r.ReconcileOwnedResources(ctx, instance, resources)
-	if result.ShouldReturn() {
-		return result.Values()
+	// Hold the rollout of the Deployments until the database migrations have run with the new image
+	migrations, err := reconcileDatabaseMigrations(ctx, r.Client, instance,
+		gen.DatabaseMigrations()...)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	resources, err := gen.Resources()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result = r.ReconcileOwnedResources(ctx, instance, append(resources, migrations...))
+
+	// The failed database migrations that block the rollout
+	// are reported in the status with the reconcile errors
+	return reconcileWorkloads(ctx, r.Client, r.Recorder, instance, result,
+		errors.Join(result.Error, failedDatabaseMigrations(instance)), gen.Workloads()...)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -90,6 +110,7 @@ func (r *SystemReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&saasv1alpha1.System{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&batchv1.Job{}).
 		Owns(&corev1.Service{}).
 		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
@@ -100,5 +121,7 @@ func (r *SystemReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&pipelinev1beta1.Task{}).
 		Watches(&source.Kind{Type: &corev1.Secret{TypeMeta: metav1.TypeMeta{Kind: "Secret"}}},
 			r.FilteredEventHandler(&saasv1alpha1.SystemList{}, nil, r.Log)).
+		Watches(&source.Kind{Type: &saasv1alpha1.Sentinel{}},
+			r.FilteredEventHandler(&saasv1alpha1.SystemList{}, usesSentinelRef, r.Log)).
 		Complete(r)
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/3scale-ops/basereconciler/reconciler"
 	"github.com/3scale-ops/basereconciler/resource"
 	"github.com/3scale-ops/basereconciler/util"
+	marin3rv1alpha1 "github.com/3scale-ops/marin3r/apis/marin3r/v1alpha1"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	"github.com/3scale-ops/saas-operator/pkg/generators/twemproxyconfig"
 	"github.com/3scale-ops/saas-operator/pkg/reconcilers/threads"
@@ -37,6 +38,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -55,9 +57,11 @@ type TwemproxyConfigReconciler struct {
 // +kubebuilder:rbac:groups=saas.3scale.net,namespace=placeholder,resources=twemproxyconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=saas.3scale.net,namespace=placeholder,resources=twemproxyconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=saas.3scale.net,namespace=placeholder,resources=twemproxyconfigs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=saas.3scale.net,namespace=placeholder,resources=sentinels,verbs=get;list;watch
 // +kubebuilder:rbac:groups="core",namespace=placeholder,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="core",namespace=placeholder,resources=pods,verbs=list;patch
 // +kubebuilder:rbac:groups="integreatly.org",namespace=placeholder,resources=grafanadashboards,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="marin3r.3scale.net",namespace=placeholder,resources=envoyconfigs,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -82,6 +86,12 @@ func (r *TwemproxyConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	// In preview mode, changes in the server pools are held until approved
+	preview, err := r.reconcilePreview(ctx, instance, &gen, logger)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	cm, err := gen.ConfigMap().Build(ctx, r.Client, nil)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -102,8 +112,8 @@ func (r *TwemproxyConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// Reconcile sentinel event watchers
-	eventWatchers := make([]threads.RunnableThread, 0, len(gen.Spec.SentinelURIs))
-	for _, uri := range gen.Spec.SentinelURIs {
+	eventWatchers := make([]threads.RunnableThread, 0, len(gen.SentinelURIs()))
+	for _, uri := range gen.SentinelURIs() {
 		watcher, err := events.NewSentinelEventWatcher(uri, instance, nil, false, r.Pool)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -116,8 +126,14 @@ func (r *TwemproxyConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	// Reconcile the Envoy redis_proxy config. It is generated from the same
+	// targets as the ConfigMap, so it also gets updated on sentinel events.
+	if _, err := resource.CreateOrUpdate(ctx, r.Client, r.Scheme, instance, gen.EnvoyConfig()); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Reconcile status of the TwemproxyConfig resource
-	if err := r.reconcileStatus(ctx, &gen, instance, logger); err != nil {
+	if err := r.reconcileStatus(ctx, &gen, instance, preview, logger); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -125,6 +141,54 @@ func (r *TwemproxyConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 }
 
+// reconcilePreview computes the preview of the changes in the server pools when the preview
+// mode is enabled. Unless the preview has been approved, the generator is configured to keep
+// using the server pools currently applied to the ConfigMap.
+func (r *TwemproxyConfigReconciler) reconcilePreview(ctx context.Context, instance *saasv1alpha1.TwemproxyConfig,
+	gen *twemproxyconfig.Generator, log logr.Logger) (*saasv1alpha1.TwemproxyConfigPreview, error) {
+
+	if !instance.Spec.Preview.IsEnabled() {
+		return nil, nil
+	}
+
+	current := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: gen.GetInstanceName(), Namespace: gen.GetNamespace()}
+	if err := r.Client.Get(ctx, key, current); err != nil {
+		if apierrors.IsNotFound(err) {
+			// nothing has been applied yet
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	applied, err := twemproxyconfig.AppliedServerPools(current)
+	if err != nil {
+		return nil, err
+	}
+
+	if applied == nil {
+		// The ConfigMap does not store the server pools used to generate it, so
+		// the server pools currently applied are resolved from the config held in
+		// the ConfigMap
+		if applied, err = gen.ServerPoolsFromConfigMap(current); err != nil {
+			return nil, err
+		}
+	}
+
+	preview := gen.Preview(applied)
+	if preview == nil {
+		return nil, nil
+	}
+
+	if instance.Spec.Preview.IsApproved(preview.Hash) {
+		log.Info("promoting approved preview", "hash", preview.Hash)
+		return nil, nil
+	}
+
+	gen.HoldServerPools(applied)
+	return preview, nil
+}
+
 func (r *TwemproxyConfigReconciler) reconcileConfigMap(ctx context.Context, owner client.Object,
 	desired *corev1.ConfigMap, reconcileData bool, log logr.Logger) (string, error) {
 	logger := log.WithValues("kind", "ConfigMap", "resource", desired.GetName())
@@ -150,9 +214,14 @@ func (r *TwemproxyConfigReconciler) reconcileConfigMap(ctx context.Context, owne
 		// Compare .data field of both ConfigMaps and patch if required.
 		// We use patch to avoid failures due to having an older version
 		// of the configmap so the config changes are propagated faster.
-		if !reflect.DeepEqual(desired.Data, current.Data) {
+		// The annotation that stores the applied server pools is kept in sync
+		// with the .data field.
+		if !reflect.DeepEqual(desired.Data, current.Data) ||
+			desired.GetAnnotations()[saasv1alpha1.TwemproxyServerPoolsAnnotationKey] !=
+				current.GetAnnotations()[saasv1alpha1.TwemproxyServerPoolsAnnotationKey] {
 			patch := client.MergeFrom(current.DeepCopy())
 			current.Data = desired.Data
+			current.SetAnnotations(util.MergeMaps(map[string]string{}, current.GetAnnotations(), desired.GetAnnotations()))
 			if err := r.Client.Patch(ctx, current, patch); err != nil {
 				logger.Error(err, "unable to patch ConfigMap")
 				return "", err
@@ -228,20 +297,25 @@ func (r *TwemproxyConfigReconciler) syncPod(ctx context.Context, pod corev1.Pod,
 }
 
 func (r *TwemproxyConfigReconciler) reconcileStatus(ctx context.Context, gen *twemproxyconfig.Generator,
-	instance *saasv1alpha1.TwemproxyConfig, log logr.Logger) error {
-	selectedTargets := map[string]saasv1alpha1.TargetServer{}
-
-	// The TwemproxyConfig api was initially conceived to support several server pools
-	// but this is actually not used, so just assume there's only one pool for simplicity
-	for pshard, server := range gen.GetTargets(gen.Spec.ServerPools[0].Name) {
-		selectedTargets[pshard] = saasv1alpha1.TargetServer{
-			ServerAlias:   util.Pointer(server.Alias()),
-			ServerAddress: server.Address,
+	instance *saasv1alpha1.TwemproxyConfig, preview *saasv1alpha1.TwemproxyConfigPreview, log logr.Logger) error {
+	pools := make([]saasv1alpha1.ServerPoolStatus, 0, len(gen.ServerPools()))
+	for _, pool := range gen.ServerPools() {
+		selectedTargets := map[string]saasv1alpha1.TargetServer{}
+		for pshard, server := range gen.GetTargets(pool.Name) {
+			selectedTargets[pshard] = saasv1alpha1.TargetServer{
+				ServerAlias:   util.Pointer(server.Alias()),
+				ServerAddress: server.Address,
+			}
 		}
+		pools = append(pools, saasv1alpha1.ServerPoolStatus{Name: pool.Name, SelectedTargets: selectedTargets})
 	}
 
 	status := saasv1alpha1.TwemproxyConfigStatus{
-		SelectedTargets: selectedTargets,
+		ServerPools: pools,
+		Preview:     preview,
+	}
+	if len(pools) > 0 {
+		status.SelectedTargets = pools[0].SelectedTargets
 	}
 	if !equality.Semantic.DeepEqual(status, instance.Status) {
 		instance.Status = status
@@ -260,7 +334,10 @@ func (r *TwemproxyConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&saasv1alpha1.TwemproxyConfig{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&grafanav1alpha1.GrafanaDashboard{}).
+		Owns(&marin3rv1alpha1.EnvoyConfig{}).
 		Watches(&source.Channel{Source: r.SentinelEvents.GetChannel()}, &handler.EnqueueRequestForObject{}).
+		Watches(&source.Kind{Type: &saasv1alpha1.Sentinel{}},
+			r.FilteredEventHandler(&saasv1alpha1.TwemproxyConfigList{}, usesSentinel, r.Log)).
 		WithOptions(controller.Options{
 			RateLimiter: AggressiveRateLimiter(),
 			// this allows for different resources to be reconciled in parallel
@@ -268,3 +345,10 @@ func (r *TwemproxyConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		}).
 		Complete(r)
 }
+
+// usesSentinel filters events of Sentinel resources so only the TwemproxyConfigs
+// that use the Sentinel get reconciled. This ensures that changes in the Sentinel
+// status (ie topology changes) are propagated to the TwemproxyConfig.
+func usesSentinel(event client.Object, o client.Object) bool {
+	return o.(*saasv1alpha1.TwemproxyConfig).UsesSentinel(client.ObjectKeyFromObject(event))
+}
@@ -0,0 +1,264 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	saasv1beta1 "github.com/3scale-ops/saas-operator/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Admission webhooks", func() {
+	var namespace string
+
+	BeforeEach(func() {
+		// Create a namespace for each block
+		namespace = "test-ns-" + nameGenerator.Generate()
+
+		testNamespace := &corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		}
+
+		err := k8sClient.Create(context.Background(), testNamespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		n := &corev1.Namespace{}
+		Eventually(func() error {
+			return k8sClient.Get(context.Background(), types.NamespacedName{Name: namespace}, n)
+		}, timeout, poll).ShouldNot(HaveOccurred())
+	})
+
+	When("creating a resource", func() {
+
+		It("persists the defaults", func() {
+			echoapi := &saasv1alpha1.EchoAPI{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+				Spec: saasv1alpha1.EchoAPISpec{
+					Endpoint: saasv1alpha1.Endpoint{DNS: []string{"echo-api.example.com"}},
+				},
+			}
+			err := k8sClient.Create(context.Background(), echoapi)
+			Expect(err).ToNot(HaveOccurred())
+
+			stored := &saasv1alpha1.EchoAPI{}
+			Eventually(func() error {
+				return k8sClient.Get(context.Background(), types.NamespacedName{Name: "instance", Namespace: namespace}, stored)
+			}, timeout, poll).ShouldNot(HaveOccurred())
+
+			Expect(stored.Spec.Image).ToNot(BeNil())
+			Expect(stored.Spec.Image.Name).To(Equal(util.Pointer("quay.io/3scale/echoapi")))
+			Expect(stored.Spec.HPA).ToNot(BeNil())
+			Expect(stored.Spec.HPA.MinReplicas).ToNot(BeNil())
+			Expect(stored.Spec.PDB).ToNot(BeNil())
+		})
+
+		It("keeps the deactivated sections", func() {
+			echoapi := &saasv1alpha1.EchoAPI{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+				Spec: saasv1alpha1.EchoAPISpec{
+					Endpoint: saasv1alpha1.Endpoint{DNS: []string{"echo-api.example.com"}},
+					HPA:      &saasv1alpha1.HorizontalPodAutoscalerSpec{},
+				},
+			}
+			err := k8sClient.Create(context.Background(), echoapi)
+			Expect(err).ToNot(HaveOccurred())
+
+			stored := &saasv1alpha1.EchoAPI{}
+			Eventually(func() error {
+				return k8sClient.Get(context.Background(), types.NamespacedName{Name: "instance", Namespace: namespace}, stored)
+			}, timeout, poll).ShouldNot(HaveOccurred())
+
+			Expect(stored.Spec.HPA.IsDeactivated()).To(BeTrue())
+		})
+
+		It("rejects an HPA with minReplicas greater than maxReplicas", func() {
+			echoapi := &saasv1alpha1.EchoAPI{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+				Spec: saasv1alpha1.EchoAPISpec{
+					Endpoint: saasv1alpha1.Endpoint{DNS: []string{"echo-api.example.com"}},
+					HPA: &saasv1alpha1.HorizontalPodAutoscalerSpec{
+						MinReplicas: util.Pointer[int32](3),
+						MaxReplicas: util.Pointer[int32](2),
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), echoapi)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.hpa.minReplicas"))
+		})
+
+		It("rejects a PDB with both minAvailable and maxUnavailable", func() {
+			echoapi := &saasv1alpha1.EchoAPI{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+				Spec: saasv1alpha1.EchoAPISpec{
+					Endpoint: saasv1alpha1.Endpoint{DNS: []string{"echo-api.example.com"}},
+					PDB: &saasv1alpha1.PodDisruptionBudgetSpec{
+						MinAvailable:   util.Pointer(intstr.FromInt(1)),
+						MaxUnavailable: util.Pointer(intstr.FromInt(1)),
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), echoapi)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.pdb.maxUnavailable"))
+		})
+
+		It("rejects a secret reference with more than one source", func() {
+			ms := &saasv1alpha1.MappingService{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+				Spec: saasv1alpha1.MappingServiceSpec{
+					Config: saasv1alpha1.MappingServiceConfig{
+						APIHost: "example.com",
+						SystemAdminToken: saasv1alpha1.SecretReference{
+							Override:  util.Pointer("override"),
+							FromVault: &saasv1alpha1.VaultSecretReference{Path: "path", Key: "key"},
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), ms)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.config.systemAdminToken"))
+		})
+
+		It("rejects an invalid backup schedule", func() {
+			srb := &saasv1alpha1.ShardedRedisBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: namespace},
+				Spec: saasv1alpha1.ShardedRedisBackupSpec{
+					SentinelRef: "sentinel",
+					Schedule:    "every 30 minutes",
+					DBFile:      "/data/dump.rdb",
+					SSHOptions: saasv1alpha1.SSHOptions{
+						User:                "root",
+						PrivateKeySecretRef: corev1.LocalObjectReference{Name: "redis-ssh-private-key"},
+					},
+					S3Options: saasv1alpha1.S3Options{
+						Bucket:               "my-bucket",
+						Path:                 "backups",
+						Region:               "us-east-1",
+						CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-credentials"},
+						ServiceEndpoint:      util.Pointer("http://minio:9000"),
+					},
+					Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			}
+			err := k8sClient.Create(context.Background(), srb)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.schedule"))
+		})
+
+		It("rejects a topology with shards not monitored by Sentinel", func() {
+			sentinel := &saasv1alpha1.Sentinel{
+				ObjectMeta: metav1.ObjectMeta{Name: "sentinel", Namespace: namespace},
+				Spec: saasv1alpha1.SentinelSpec{
+					Config: &saasv1alpha1.SentinelConfig{
+						MonitoredShards: map[string][]string{
+							"shard01": {"redis://10.65.0.10:6379"},
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), sentinel)
+			Expect(err).ToNot(HaveOccurred())
+
+			tc := &saasv1alpha1.TwemproxyConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "twemproxy", Namespace: namespace},
+				Spec: saasv1alpha1.TwemproxyConfigSpec{
+					SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel"}},
+					ServerPools: []saasv1alpha1.TwemproxyServerPool{{
+						Name: "pool",
+						Topology: []saasv1alpha1.ShardedRedisTopology{
+							{ShardName: "l-shard01", PhysicalShard: "shard01"},
+							{ShardName: "l-shard02", PhysicalShard: "shard02"},
+						},
+						BindAddress: "0.0.0.0:22121",
+						Timeout:     5000,
+						TCPBacklog:  512,
+					}},
+				},
+			}
+			err = k8sClient.Create(context.Background(), tc)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.serverPools[0].topology[1].physicalShard"))
+
+			tc.Spec.ServerPools[0].Topology = tc.Spec.ServerPools[0].Topology[0:1]
+			err = k8sClient.Create(context.Background(), tc)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("updating a resource", func() {
+
+		It("validates the updated spec", func() {
+			echoapi := &saasv1alpha1.EchoAPI{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+				Spec: saasv1alpha1.EchoAPISpec{
+					Endpoint: saasv1alpha1.Endpoint{DNS: []string{"echo-api.example.com"}},
+				},
+			}
+			err := k8sClient.Create(context.Background(), echoapi)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() error {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "instance", Namespace: namespace}, echoapi); err != nil {
+					return err
+				}
+				echoapi.Spec.HPA.MaxReplicas = util.Pointer(*echoapi.Spec.HPA.MinReplicas - 1)
+				return k8sClient.Update(context.Background(), echoapi)
+			}, timeout, poll).Should(Satisfy(apierrors.IsInvalid))
+		})
+	})
+
+	When("using a different version of the API", func() {
+
+		It("stores v1beta1 resources in the storage version", func() {
+			echoapi := &saasv1beta1.EchoAPI{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+				Spec: saasv1alpha1.EchoAPISpec{
+					Endpoint: saasv1alpha1.Endpoint{DNS: []string{"echo-api.example.com"}},
+				},
+			}
+			err := k8sClient.Create(context.Background(), echoapi)
+			Expect(err).ToNot(HaveOccurred())
+
+			stored := &saasv1alpha1.EchoAPI{}
+			Eventually(func() error {
+				return k8sClient.Get(context.Background(), types.NamespacedName{Name: "instance", Namespace: namespace}, stored)
+			}, timeout, poll).ShouldNot(HaveOccurred())
+
+			Expect(stored.Spec.Endpoint.DNS).To(Equal([]string{"echo-api.example.com"}))
+			Expect(stored.Spec.Image).ToNot(BeNil())
+		})
+
+		It("serves v1alpha1 resources as v1beta1", func() {
+			sentinel := &saasv1alpha1.Sentinel{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+				Spec: saasv1alpha1.SentinelSpec{
+					Config: &saasv1alpha1.SentinelConfig{
+						MonitoredShards: map[string][]string{
+							"shard01": {"redis://10.65.0.10:6379"},
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), sentinel)
+			Expect(err).ToNot(HaveOccurred())
+
+			converted := &saasv1beta1.Sentinel{}
+			Eventually(func() error {
+				return k8sClient.Get(context.Background(), types.NamespacedName{Name: "instance", Namespace: namespace}, converted)
+			}, timeout, poll).ShouldNot(HaveOccurred())
+
+			Expect(converted.Spec.Config.MonitoredShards).To(Equal(sentinel.Spec.Config.MonitoredShards))
+		})
+	})
+})
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/reconciler"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	deployment_workload "github.com/3scale-ops/saas-operator/pkg/workloads/deployment"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// objectWithWorkloadsStatus is a custom resource that
+// deploys one or more Deployment based workloads
+type objectWithWorkloadsStatus interface {
+	client.Object
+	GetWorkloadsStatus() *saasv1alpha1.WorkloadsStatus
+}
+
+// objectWithCanaries is a custom resource whose
+// workloads can be configured with canaries
+type objectWithCanaries interface {
+	CanarySpecDiff(canary *saasv1alpha1.Canary) (string, error)
+}
+
+// reconcileWorkloadsStatus updates the status of the custom resource with the status of
+// its workloads. The error returned when reconciling the owned resources, if any, should
+// be passed so it gets reported in the Degraded condition.
+func reconcileWorkloadsStatus(ctx context.Context, cl client.Client, instance objectWithWorkloadsStatus,
+	reconcileError error, workloads ...deployment_workload.WithCanary) error {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	summary, conditions, err := deployment_workload.Status(ctx, cl, reconcileError, workloads...)
+	if err != nil {
+		return err
+	}
+
+	if o, ok := instance.(objectWithCanaries); ok {
+		for i, w := range workloads {
+			if w.Spec == nil {
+				continue
+			}
+			if summary[i].CanarySpecDiff, err = o.CanarySpecDiff(w.Spec); err != nil {
+				return err
+			}
+		}
+	}
+
+	status := instance.GetWorkloadsStatus()
+	deployment_workload.TrackSecretRollouts(status.Workloads, summary, time.Now())
+	desired := status.DeepCopy()
+	desired.ObservedGeneration = instance.GetGeneration()
+	desired.Workloads = summary
+	for _, condition := range conditions {
+		condition.ObservedGeneration = instance.GetGeneration()
+		meta.SetStatusCondition(&desired.Conditions, condition)
+	}
+
+	if !equality.Semantic.DeepEqual(status, desired) {
+		*status = *desired
+		if err := cl.Status().Update(ctx, instance); err != nil {
+			logger.Error(err, "unable to update status")
+			return err
+		}
+		logger.Info("status updated")
+	}
+
+	return nil
+}
+
+// reconcileWorkloads runs the steps that follow the reconcile of the resources of the
+// workloads: it updates the status of the custom resource, evaluates the analysis of the
+// canaries and requeues the reconcile when the next canary traffic shifting step, the end
+// of a blue/green rollback window or a change of the autoscalers' scaling profiles is due.
+// The recorder is only used to emit the events of the canary analyses, so it can be nil
+// for custom resources whose workloads cannot have canaries.
+func reconcileWorkloads(ctx context.Context, cl client.Client, recorder record.EventRecorder,
+	instance objectWithWorkloadsStatus, result reconciler.Result, reconcileError error,
+	workloads ...deployment_workload.WithCanary) (ctrl.Result, error) {
+
+	// Reconcile the status, which also reports errors reconciling the resources
+	if err := reconcileWorkloadsStatus(ctx, cl, instance, reconcileError, workloads...); err != nil {
+		return ctrl.Result{}, err
+	}
+	if result.ShouldReturn() {
+		return result.Values()
+	}
+
+	// Evaluate the analysis of the canaries
+	var analysis time.Duration
+	if recorder != nil {
+		var err error
+		if analysis, err = reconcileCanaryAnalysis(ctx, cl, recorder, instance, workloads...); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Requeue when the next step of the canary traffic shifting schedules is due
+	next, err := deployment_workload.NextCanaryStep(ctx, cl, workloads...)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Requeue when the rollback window of the blue/green workloads expires
+	rollback, err := deployment_workload.RollbackWindowEnd(ctx, cl, workloads...)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Requeue when the scaling profiles of the autoscalers are activated or expire
+	schedules := deployment_workload.NextHPAScheduleChange(workloads...)
+
+	return ctrl.Result{RequeueAfter: minRequeue(analysis, next, rollback, schedules)}, nil
+}
@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"errors"
 
 	"github.com/3scale-ops/basereconciler/reconciler"
 	"github.com/3scale-ops/basereconciler/util"
@@ -29,6 +30,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,6 +50,7 @@ type ZyncReconciler struct {
 // +kubebuilder:rbac:groups="core",namespace=placeholder,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="core",namespace=placeholder,resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="apps",namespace=placeholder,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="batch",namespace=placeholder,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="monitoring.coreos.com",namespace=placeholder,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="autoscaling",namespace=placeholder,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="policy",namespace=placeholder,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
@@ -67,17 +70,25 @@ func (r *ZyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	}
 
 	gen := zync.NewGenerator(instance.GetName(), instance.GetNamespace(), instance.Spec)
-	resources, err := gen.Resources()
+
+	// Hold the rollout of the Deployments until the database migrations have run with the new image
+	migrations, err := reconcileDatabaseMigrations(ctx, r.Client, instance,
+		gen.DatabaseMigrations()...)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	result = r.ReconcileOwnedResources(ctx, instance, resources)
-	if result.ShouldReturn() {
-		return result.Values()
+	resources, err := gen.Resources()
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	result = r.ReconcileOwnedResources(ctx, instance, append(resources, migrations...))
+
+	// The failed database migrations that block the rollout
+	// are reported in the status with the reconcile errors
+	return reconcileWorkloads(ctx, r.Client, nil, instance, result,
+		errors.Join(result.Error, failedDatabaseMigrations(instance)), gen.Workloads()...)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -86,6 +97,7 @@ func (r *ZyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&saasv1alpha1.Zync{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&batchv1.Job{}).
 		Owns(&corev1.Service{}).
 		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
@@ -31,6 +31,7 @@ import (
 	"github.com/3scale-ops/basereconciler/reconciler"
 	marin3rv1alpha1 "github.com/3scale-ops/marin3r/apis/marin3r/v1alpha1"
 	operatorutils "github.com/3scale-ops/saas-operator/pkg/util"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	externalsecretsv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -43,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	saasv1beta1 "github.com/3scale-ops/saas-operator/api/v1beta1"
 	"github.com/3scale-ops/saas-operator/controllers"
 	"github.com/3scale-ops/saas-operator/pkg/reconcilers/threads"
 	redis "github.com/3scale-ops/saas-operator/pkg/redis/server"
@@ -67,11 +69,13 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(saasv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(saasv1beta1.AddToScheme(scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	utilruntime.Must(grafanav1alpha1.AddToScheme(scheme))
 	utilruntime.Must(externalsecretsv1beta1.AddToScheme(scheme))
 	utilruntime.Must(marin3rv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(pipelinev1beta1.AddToScheme(scheme))
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -104,6 +108,7 @@ func main() {
 	options := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
+		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "256bc75b.3scale.net",
@@ -169,6 +174,7 @@ func main() {
 	if err = (&controllers.ApicastReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("Apicast")),
+		Recorder: mgr.GetEventRecorderFor("apicast-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Apicast")
 		os.Exit(1)
@@ -201,6 +207,7 @@ func main() {
 	if err = (&controllers.AutoSSLReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("AutoSSL")),
+		Recorder: mgr.GetEventRecorderFor("autossl-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutoSSL")
 		os.Exit(1)
@@ -209,6 +216,7 @@ func main() {
 	if err = (&controllers.EchoAPIReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("EchoAPI")),
+		Recorder: mgr.GetEventRecorderFor("echoapi-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "EchoAPI")
 		os.Exit(1)
@@ -217,6 +225,7 @@ func main() {
 	if err = (&controllers.BackendReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("Backend")),
+		Recorder: mgr.GetEventRecorderFor("backend-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Backend")
 		os.Exit(1)
@@ -225,11 +234,63 @@ func main() {
 	if err = (&controllers.SystemReconciler{
 		Reconciler: reconciler.NewFromManager(mgr).
 			WithLogger(ctrl.Log.WithName("controllers").WithName("System")),
+		Recorder: mgr.GetEventRecorderFor("system-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "System")
 		os.Exit(1)
 	}
 
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&saasv1alpha1.AutoSSL{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AutoSSL")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.Apicast{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Apicast")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.EchoAPI{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "EchoAPI")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.MappingService{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "MappingService")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.CORSProxy{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CORSProxy")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.Backend{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Backend")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.System{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "System")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.Zync{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Zync")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.Sentinel{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Sentinel")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.RedisShard{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RedisShard")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.TwemproxyConfig{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "TwemproxyConfig")
+			os.Exit(1)
+		}
+		if err = (&saasv1alpha1.ShardedRedisBackup{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ShardedRedisBackup")
+			os.Exit(1)
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
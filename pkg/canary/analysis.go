@@ -0,0 +1,190 @@
+package canary
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// queryTimeout is the maximum time to wait for the
+// queries of the metrics of an analysis
+var queryTimeout time.Duration = 10 * time.Second
+
+// Analyze evaluates the analysis of a canary and returns its updated status. The analysis
+// of a canary is tied to the revision of the canary pod template, so the current status is
+// reset, and the analysis starts again, if it belongs to a different canary Deployment or
+// revision. The metrics are evaluated at most once per interval. It also returns the time
+// until the analysis needs to be evaluated again, which is zero once the analysis has finished.
+func Analyze(ctx context.Context, analysis *saasv1alpha1.CanaryAnalysis, canary types.NamespacedName,
+	revision string, current *saasv1alpha1.CanaryAnalysisStatus, now time.Time) (*saasv1alpha1.CanaryAnalysisStatus, time.Duration) {
+
+	var status *saasv1alpha1.CanaryAnalysisStatus
+	if current == nil || current.Canary != canary.Name || current.Revision != revision {
+		status = &saasv1alpha1.CanaryAnalysisStatus{
+			Canary:    canary.Name,
+			Revision:  revision,
+			StartTime: metav1.NewTime(now),
+			Phase:     saasv1alpha1.CanaryAnalysisRunning,
+		}
+	} else {
+		status = current.DeepCopy()
+	}
+
+	if status.Phase != saasv1alpha1.CanaryAnalysisRunning {
+		return status, 0
+	}
+
+	interval := analysis.GetInterval()
+	if status.LastEvaluationTime != nil {
+		if elapsed := now.Sub(status.LastEvaluationTime.Time); elapsed < interval {
+			return status, interval - elapsed
+		}
+	}
+
+	metrics, failures, err := Evaluate(ctx, analysis, canary, now)
+	status.LastEvaluationTime = &metav1.Time{Time: now}
+	if err != nil {
+		// errors querying Prometheus do not make the analysis fail,
+		// the metrics are evaluated again in the next interval
+		status.Message = err.Error()
+		return status, interval
+	}
+	status.Metrics = metrics
+
+	if len(failures) > 0 {
+		status.Phase = saasv1alpha1.CanaryAnalysisFailed
+		status.Message = fmt.Sprintf("metrics out of threshold: %s", strings.Join(failures, ", "))
+		return status, 0
+	}
+
+	end := status.StartTime.Add(analysis.Duration.Duration)
+	if !now.Before(end) {
+		status.Phase = saasv1alpha1.CanaryAnalysisSucceeded
+		status.Message = "the canary can be promoted by applying its image and patches to the main spec"
+		return status, 0
+	}
+
+	status.Message = ""
+	if remaining := end.Sub(now); remaining < interval {
+		return status, remaining
+	}
+	return status, interval
+}
+
+// Evaluate runs the queries of the metrics of the analysis against Prometheus. It returns the
+// values of the metrics and the list of metrics that are out of their thresholds.
+func Evaluate(ctx context.Context, analysis *saasv1alpha1.CanaryAnalysis, canary types.NamespacedName,
+	now time.Time) ([]saasv1alpha1.CanaryMetricStatus, []string, error) {
+
+	client, err := promapi.NewClient(promapi.Config{Address: analysis.PrometheusAddress})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create prometheus client: '%s'", err.Error())
+	}
+	api := promv1.NewAPI(client)
+
+	// do not block the reconcile if Prometheus does not answer
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	metrics := make([]saasv1alpha1.CanaryMetricStatus, 0, len(analysis.Metrics))
+	failures := []string{}
+
+	for _, metric := range analysis.Metrics {
+		query, err := renderQuery(metric.Query, canary)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid query for metric %s: '%s'", metric.Name, err.Error())
+		}
+
+		result, _, err := api.Query(ctx, query, now)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to query metric %s: '%s'", metric.Name, err.Error())
+		}
+
+		value, err := singleValue(result)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to get value for metric %s: '%s'", metric.Name, err.Error())
+		}
+		metrics = append(metrics, saasv1alpha1.CanaryMetricStatus{
+			Name:  metric.Name,
+			Value: strconv.FormatFloat(value, 'f', -1, 64),
+		})
+
+		ok, err := inThreshold(metric, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid threshold for metric %s: '%s'", metric.Name, err.Error())
+		}
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%s=%s", metric.Name, strconv.FormatFloat(value, 'f', -1, 64)))
+		}
+	}
+
+	return metrics, failures, nil
+}
+
+func renderQuery(query string, canary types.NamespacedName) (string, error) {
+	tmpl, err := template.New("query").Parse(query)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, struct{ Deployment, Namespace string }{canary.Name, canary.Namespace}); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func singleValue(result model.Value) (float64, error) {
+	var value float64
+
+	switch v := result.(type) {
+	case *model.Scalar:
+		value = float64(v.Value)
+	case model.Vector:
+		if len(v) != 1 {
+			return 0, fmt.Errorf("query returned %d values, expected 1", len(v))
+		}
+		value = float64(v[0].Value)
+	default:
+		return 0, fmt.Errorf("unsupported result type %s", result.Type())
+	}
+
+	if math.IsNaN(value) {
+		return 0, fmt.Errorf("query returned NaN")
+	}
+	return value, nil
+}
+
+func inThreshold(metric saasv1alpha1.CanaryMetric, value float64) (bool, error) {
+	if metric.Min != nil {
+		min, err := strconv.ParseFloat(*metric.Min, 64)
+		if err != nil {
+			return false, err
+		}
+		if value < min {
+			return false, nil
+		}
+	}
+	if metric.Max != nil {
+		max, err := strconv.ParseFloat(*metric.Max, 64)
+		if err != nil {
+			return false, err
+		}
+		if value > max {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,267 @@
+package canary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// testPrometheus returns a stub server that implements the Prometheus query API,
+// answering each query with the value configured in the values map
+func testPrometheus(t *testing.T, values map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		value, ok := values[r.Form.Get("query")]
+		if !ok {
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"%s"]}]}}`, value)
+	}))
+}
+
+func testAnalysis(address string) *saasv1alpha1.CanaryAnalysis {
+	return &saasv1alpha1.CanaryAnalysis{
+		PrometheusAddress: address,
+		Duration:          metav1.Duration{Duration: 10 * time.Minute},
+		Interval:          &metav1.Duration{Duration: time.Minute},
+		Metrics: []saasv1alpha1.CanaryMetric{
+			{Name: "error-rate", Query: `errors{deployment="{{ .Deployment }}",namespace="{{ .Namespace }}"}`, Max: util.Pointer("0.01")},
+			{Name: "success-rate", Query: `success{deployment="{{ .Deployment }}"}`, Min: util.Pointer("0.95")},
+		},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	canary := types.NamespacedName{Name: "canary", Namespace: "ns"}
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name        string
+		values      map[string]string
+		current     *saasv1alpha1.CanaryAnalysisStatus
+		wantPhase   saasv1alpha1.CanaryAnalysisPhase
+		wantMetrics []saasv1alpha1.CanaryMetricStatus
+		wantRequeue time.Duration
+		wantStart   time.Time
+	}{
+		{
+			name: "Analysis starts and metrics are within thresholds",
+			values: map[string]string{
+				`errors{deployment="canary",namespace="ns"}`: "0.001",
+				`success{deployment="canary"}`:               "0.99",
+			},
+			current:   nil,
+			wantPhase: saasv1alpha1.CanaryAnalysisRunning,
+			wantMetrics: []saasv1alpha1.CanaryMetricStatus{
+				{Name: "error-rate", Value: "0.001"},
+				{Name: "success-rate", Value: "0.99"},
+			},
+			wantRequeue: time.Minute,
+			wantStart:   now,
+		},
+		{
+			name: "Analysis fails when a metric is out of threshold",
+			values: map[string]string{
+				`errors{deployment="canary",namespace="ns"}`: "0.2",
+				`success{deployment="canary"}`:               "0.99",
+			},
+			current:   nil,
+			wantPhase: saasv1alpha1.CanaryAnalysisFailed,
+			wantMetrics: []saasv1alpha1.CanaryMetricStatus{
+				{Name: "error-rate", Value: "0.2"},
+				{Name: "success-rate", Value: "0.99"},
+			},
+			wantRequeue: 0,
+			wantStart:   now,
+		},
+		{
+			name: "Analysis succeeds after its duration",
+			values: map[string]string{
+				`errors{deployment="canary",namespace="ns"}`: "0",
+				`success{deployment="canary"}`:               "1",
+			},
+			current: &saasv1alpha1.CanaryAnalysisStatus{
+				Canary:    "canary",
+				Revision:  "rev1",
+				StartTime: metav1.NewTime(now.Add(-11 * time.Minute)),
+				Phase:     saasv1alpha1.CanaryAnalysisRunning,
+			},
+			wantPhase: saasv1alpha1.CanaryAnalysisSucceeded,
+			wantMetrics: []saasv1alpha1.CanaryMetricStatus{
+				{Name: "error-rate", Value: "0"},
+				{Name: "success-rate", Value: "1"},
+			},
+			wantRequeue: 0,
+			wantStart:   now.Add(-11 * time.Minute),
+		},
+		{
+			name:   "Analysis keeps running if the query returns no data",
+			values: map[string]string{},
+			current: &saasv1alpha1.CanaryAnalysisStatus{
+				Canary:    "canary",
+				Revision:  "rev1",
+				StartTime: metav1.NewTime(now.Add(-2 * time.Minute)),
+				Phase:     saasv1alpha1.CanaryAnalysisRunning,
+				Metrics:   []saasv1alpha1.CanaryMetricStatus{{Name: "error-rate", Value: "0"}},
+			},
+			wantPhase:   saasv1alpha1.CanaryAnalysisRunning,
+			wantMetrics: []saasv1alpha1.CanaryMetricStatus{{Name: "error-rate", Value: "0"}},
+			wantRequeue: time.Minute,
+			wantStart:   now.Add(-2 * time.Minute),
+		},
+		{
+			name:   "Metrics are not evaluated before the interval",
+			values: map[string]string{},
+			current: &saasv1alpha1.CanaryAnalysisStatus{
+				Canary:             "canary",
+				Revision:           "rev1",
+				StartTime:          metav1.NewTime(now.Add(-2 * time.Minute)),
+				Phase:              saasv1alpha1.CanaryAnalysisRunning,
+				LastEvaluationTime: &metav1.Time{Time: now.Add(-20 * time.Second)},
+			},
+			wantPhase:   saasv1alpha1.CanaryAnalysisRunning,
+			wantMetrics: nil,
+			wantRequeue: 40 * time.Second,
+			wantStart:   now.Add(-2 * time.Minute),
+		},
+		{
+			name:   "Analysis is reset for a new canary Deployment",
+			values: map[string]string{},
+			current: &saasv1alpha1.CanaryAnalysisStatus{
+				Canary:    "other",
+				Revision:  "rev1",
+				StartTime: metav1.NewTime(now.Add(-1 * time.Hour)),
+				Phase:     saasv1alpha1.CanaryAnalysisFailed,
+			},
+			wantPhase:   saasv1alpha1.CanaryAnalysisRunning,
+			wantMetrics: nil,
+			wantRequeue: time.Minute,
+			wantStart:   now,
+		},
+		{
+			name:   "Analysis is reset when the canary pod template changes",
+			values: map[string]string{},
+			current: &saasv1alpha1.CanaryAnalysisStatus{
+				Canary:    "canary",
+				Revision:  "rev0",
+				StartTime: metav1.NewTime(now.Add(-1 * time.Hour)),
+				Phase:     saasv1alpha1.CanaryAnalysisSucceeded,
+			},
+			wantPhase:   saasv1alpha1.CanaryAnalysisRunning,
+			wantMetrics: nil,
+			wantRequeue: time.Minute,
+			wantStart:   now,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := testPrometheus(t, tt.values)
+			defer srv.Close()
+
+			got, requeue := Analyze(context.TODO(), testAnalysis(srv.URL), canary, "rev1", tt.current, now)
+			if got.Phase != tt.wantPhase {
+				t.Errorf("Analyze() phase = %v, want %v (message: %s)", got.Phase, tt.wantPhase, got.Message)
+			}
+			if diff := deep.Equal(got.Metrics, tt.wantMetrics); len(diff) > 0 {
+				t.Errorf("Analyze() metrics = diff %v", diff)
+			}
+			if requeue != tt.wantRequeue {
+				t.Errorf("Analyze() requeue = %v, want %v", requeue, tt.wantRequeue)
+			}
+			if !got.StartTime.Time.Equal(tt.wantStart) {
+				t.Errorf("Analyze() start time = %v, want %v", got.StartTime, tt.wantStart)
+			}
+			if got.Revision != "rev1" {
+				t.Errorf("Analyze() revision = %v, want rev1", got.Revision)
+			}
+		})
+	}
+}
+
+func TestEvaluate_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	defer func(timeout time.Duration) { queryTimeout = timeout }(queryTimeout)
+	queryTimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	_, _, err := Evaluate(context.TODO(), testAnalysis(srv.URL), types.NamespacedName{Name: "canary", Namespace: "ns"}, time.Now())
+	if err == nil {
+		t.Errorf("Evaluate() expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Evaluate() took %v, want it to time out", elapsed)
+	}
+}
+
+func Test_inThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  saasv1alpha1.CanaryMetric
+		value   float64
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "No thresholds",
+			metric: saasv1alpha1.CanaryMetric{},
+			value:  10,
+			want:   true,
+		},
+		{
+			name:   "Below max",
+			metric: saasv1alpha1.CanaryMetric{Max: util.Pointer("0.5")},
+			value:  0.5,
+			want:   true,
+		},
+		{
+			name:   "Above max",
+			metric: saasv1alpha1.CanaryMetric{Max: util.Pointer("0.5")},
+			value:  0.6,
+			want:   false,
+		},
+		{
+			name:   "Below min",
+			metric: saasv1alpha1.CanaryMetric{Min: util.Pointer("1"), Max: util.Pointer("2")},
+			value:  0.6,
+			want:   false,
+		},
+		{
+			name:    "Invalid threshold",
+			metric:  saasv1alpha1.CanaryMetric{Min: util.Pointer("x")},
+			value:   0.6,
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := inThreshold(tt.metric, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("inThreshold() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("inThreshold() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
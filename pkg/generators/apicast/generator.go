@@ -102,6 +102,7 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.ApicastSpec) (Ge
 			Spec:    canarySpec.Staging,
 			Options: config.NewEnvOptions(canarySpec.Staging, "staging"),
 			Traffic: canarySpec.Staging.Canary.SendTraffic,
+			Weights: spec.Staging.Canary,
 		}
 		// Disable PDB and HPA for the canary Deployment
 		generator.CanaryStaging.Spec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
@@ -127,6 +128,7 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.ApicastSpec) (Ge
 			Spec:    canarySpec.Production,
 			Options: config.NewEnvOptions(canarySpec.Production, "production"),
 			Traffic: canarySpec.Production.Canary.SendTraffic,
+			Weights: spec.Production.Canary,
 		}
 		// Disable PDB and HPA for the canary Deployment
 		generator.CanaryProduction.Spec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
@@ -136,6 +138,14 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.ApicastSpec) (Ge
 	return generator, nil
 }
 
+// Workloads returns the list of Deployment based workloads
+func (gen *Generator) Workloads() []deployment_workload.WithCanary {
+	return []deployment_workload.WithCanary{
+		{Main: &gen.Staging, Canary: gen.CanaryStaging, Spec: gen.Staging.Spec.Canary, SpecPath: "/spec/staging/canary"},
+		{Main: &gen.Production, Canary: gen.CanaryProduction, Spec: gen.Production.Spec.Canary, SpecPath: "/spec/production/canary"},
+	}
+}
+
 // Resources returns the list of resource templates
 func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 	staging, err := deployment_workload.New(&gen.Staging, gen.CanaryStaging)
@@ -169,6 +179,7 @@ type EnvGenerator struct {
 	Spec    saasv1alpha1.ApicastEnvironmentSpec
 	Options config.EnvOptions
 	Traffic bool
+	Weights *saasv1alpha1.Canary
 }
 
 // Validate that EnvGenerator implements deployment_workload.DeploymentWorkload interface
@@ -180,6 +191,12 @@ var _ deployment_workload.WithTraffic = &EnvGenerator{}
 // Validate that EnvGenerator implements deployment_workload.WithEnvoySidecar interface
 var _ deployment_workload.WithEnvoySidecar = &EnvGenerator{}
 
+// Validate that EnvGenerator implements deployment_workload.WithWeightedTraffic interface
+var _ deployment_workload.WithWeightedTraffic = &EnvGenerator{}
+
+// Validate that EnvGenerator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &EnvGenerator{}
+
 func (gen *EnvGenerator) Labels() map[string]string {
 	return gen.GetLabels()
 }
@@ -188,6 +205,10 @@ func (gen *EnvGenerator) Deployment() *resource.Template[*appsv1.Deployment] {
 		WithMutation(mutators.SetDeploymentReplicas(gen.Spec.HPA.IsDeactivated()))
 }
 
+func (gen *EnvGenerator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.Spec.DeploymentStrategy
+}
+
 func (gen *EnvGenerator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
 	return gen.Spec.HPA
 }
@@ -217,3 +238,4 @@ func (gen *EnvGenerator) TrafficSelector() map[string]string {
 func (gen *EnvGenerator) EnvoyDynamicConfigurations() []descriptor.EnvoyDynamicConfigDescriptor {
 	return gen.Spec.Marin3r.EnvoyDynamicConfig.AsList()
 }
+func (gen *EnvGenerator) TrafficWeights() *saasv1alpha1.Canary { return gen.Weights }
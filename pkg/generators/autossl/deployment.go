@@ -14,13 +14,7 @@ func (gen *Generator) deployment() *appsv1.Deployment {
 	return &appsv1.Deployment{
 		Spec: appsv1.DeploymentSpec{
 			Replicas: gen.Spec.Replicas,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: util.Pointer(intstr.FromInt(0)),
-					MaxSurge:       util.Pointer(intstr.FromInt(1)),
-				},
-			},
+			Strategy: gen.Spec.DeploymentStrategy.DeploymentStrategy(),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
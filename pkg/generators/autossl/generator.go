@@ -83,6 +83,13 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.AutoSSLSpec) (Ge
 	return generator, nil
 }
 
+// Workloads returns the list of Deployment based workloads
+func (gen *Generator) Workloads() []deployment_workload.WithCanary {
+	return []deployment_workload.WithCanary{
+		{Main: gen, Canary: gen.Canary, Spec: gen.Spec.Canary, SpecPath: "/spec/canary"},
+	}
+}
+
 // Resources returns the list of resource templates
 func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 	workload, err := deployment_workload.New(gen, gen.Canary)
@@ -113,11 +120,18 @@ func (gen *Generator) TrafficSelector() map[string]string {
 // Validate that Generator implements deployment_workload.DeploymentWorkload interface
 var _ deployment_workload.DeploymentWorkload = &Generator{}
 
+// Validate that Generator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &Generator{}
+
 func (gen *Generator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return resource.NewTemplateFromObjectFunction(gen.deployment).
 		WithMutation(mutators.SetDeploymentReplicas(gen.Spec.HPA.IsDeactivated()))
 }
 
+func (gen *Generator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.Spec.DeploymentStrategy
+}
+
 func (gen *Generator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
 	return gen.Spec.HPA
 }
@@ -19,15 +19,16 @@ type CronOptions struct {
 }
 
 // NewCronOptions returns a CronOptions struct for the given saasv1alpha1.BackendSpec
-func NewCronOptions(spec saasv1alpha1.BackendSpec) CronOptions {
+// and redis connection settings
+func NewCronOptions(spec saasv1alpha1.BackendSpec, redis RedisConfig) CronOptions {
 	opts := CronOptions{
 		RackEnv:                   &pod.ClearTextValue{Value: *spec.Config.RackEnv},
-		ConfigRedisProxy:          &pod.ClearTextValue{Value: spec.Config.RedisStorageDSN},
-		ConfigRedisSentinelHosts:  &pod.ClearTextValue{Value: ""},
-		ConfigRedisSentinelRole:   &pod.ClearTextValue{Value: ""},
-		ConfigQueuesMasterName:    &pod.ClearTextValue{Value: spec.Config.RedisQueuesDSN},
-		ConfigQueuesSentinelHosts: &pod.ClearTextValue{Value: ""},
-		ConfigQueuesSentinelRole:  &pod.ClearTextValue{Value: ""},
+		ConfigRedisProxy:          &pod.ClearTextValue{Value: redis.StorageURL},
+		ConfigRedisSentinelHosts:  &pod.ClearTextValue{Value: redis.SentinelHosts},
+		ConfigRedisSentinelRole:   &pod.ClearTextValue{Value: redis.SentinelRole},
+		ConfigQueuesMasterName:    &pod.ClearTextValue{Value: redis.QueuesURL},
+		ConfigQueuesSentinelHosts: &pod.ClearTextValue{Value: redis.SentinelHosts},
+		ConfigQueuesSentinelRole:  &pod.ClearTextValue{Value: redis.SentinelRole},
 	}
 
 	if spec.Config.ErrorMonitoringService != nil && spec.Config.ErrorMonitoringKey != nil {
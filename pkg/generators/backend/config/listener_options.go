@@ -30,15 +30,16 @@ type ListenerOptions struct {
 }
 
 // NewListenerOptions returns an Options struct for the given saasv1alpha1.BackendSpec
-func NewListenerOptions(spec saasv1alpha1.BackendSpec) ListenerOptions {
+// and redis connection settings
+func NewListenerOptions(spec saasv1alpha1.BackendSpec, redis RedisConfig) ListenerOptions {
 	opts := ListenerOptions{
 		RackEnv:                                &pod.ClearTextValue{Value: *spec.Config.RackEnv},
-		ConfigRedisProxy:                       &pod.ClearTextValue{Value: spec.Config.RedisStorageDSN},
-		ConfigRedisSentinelHosts:               &pod.ClearTextValue{Value: ""},
-		ConfigRedisSentinelRole:                &pod.ClearTextValue{Value: ""},
-		ConfigQueuesMasterName:                 &pod.ClearTextValue{Value: spec.Config.RedisQueuesDSN},
-		ConfigQueuesSentinelHosts:              &pod.ClearTextValue{Value: ""},
-		ConfigQueuesSentinelRole:               &pod.ClearTextValue{Value: ""},
+		ConfigRedisProxy:                       &pod.ClearTextValue{Value: redis.StorageURL},
+		ConfigRedisSentinelHosts:               &pod.ClearTextValue{Value: redis.SentinelHosts},
+		ConfigRedisSentinelRole:                &pod.ClearTextValue{Value: redis.SentinelRole},
+		ConfigQueuesMasterName:                 &pod.ClearTextValue{Value: redis.QueuesURL},
+		ConfigQueuesSentinelHosts:              &pod.ClearTextValue{Value: redis.SentinelHosts},
+		ConfigQueuesSentinelRole:               &pod.ClearTextValue{Value: redis.SentinelRole},
 		ConfigMasterServiceID:                  &pod.ClearTextValue{Value: fmt.Sprintf("%d", *spec.Config.MasterServiceID)},
 		ConfigRequestLoggers:                   &pod.ClearTextValue{Value: *spec.Listener.Config.LogFormat},
 		ConfigRedisAsync:                       &pod.ClearTextValue{Value: strconv.FormatBool(*spec.Listener.Config.RedisAsync)},
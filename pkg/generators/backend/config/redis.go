@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RedisConfig holds the connection settings for the storage and queues redis
+type RedisConfig struct {
+	StorageURL    string
+	QueuesURL     string
+	SentinelHosts string
+	SentinelRole  string
+}
+
+// NewRedisConfig returns the redis connection settings for the given saasv1alpha1.BackendSpec.
+// If the spec has a sentinelRef, the sentinel endpoints are resolved from the status of the
+// referenced Sentinel resource, looked up in the given map.
+func NewRedisConfig(spec saasv1alpha1.BackendSpec, namespace string,
+	sentinels map[types.NamespacedName]saasv1alpha1.SentinelStatus) (RedisConfig, error) {
+
+	ref := spec.Config.SentinelRef
+	if ref == nil {
+		return RedisConfig{StorageURL: spec.Config.RedisStorageDSN, QueuesURL: spec.Config.RedisQueuesDSN}, nil
+	}
+
+	key := ref.ObjectKey(namespace)
+	status, ok := sentinels[key]
+	if !ok {
+		return RedisConfig{}, fmt.Errorf("unable to resolve sentinelRef: Sentinel %s not found", key)
+	}
+	hosts, err := status.SentinelHosts(ref.StorageShard, ref.QueuesShard)
+	if err != nil {
+		return RedisConfig{}, fmt.Errorf("unable to resolve sentinelRef: Sentinel %s: %w", key, err)
+	}
+
+	return RedisConfig{
+		StorageURL:    "redis://" + ref.StorageShard,
+		QueuesURL:     "redis://" + ref.QueuesShard,
+		SentinelHosts: hosts,
+		SentinelRole:  "master",
+	}, nil
+}
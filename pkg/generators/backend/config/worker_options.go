@@ -29,15 +29,16 @@ type WorkerOptions struct {
 }
 
 // NewWorkerOptions returns an Options struct for the given saasv1alpha1.BackedSpec
-func NewWorkerOptions(spec saasv1alpha1.BackendSpec) WorkerOptions {
+// and redis connection settings
+func NewWorkerOptions(spec saasv1alpha1.BackendSpec, redis RedisConfig) WorkerOptions {
 	opts := WorkerOptions{
 		RackEnv:                              &pod.ClearTextValue{Value: *spec.Config.RackEnv},
-		ConfigRedisProxy:                     &pod.ClearTextValue{Value: spec.Config.RedisStorageDSN},
-		ConfigRedisSentinelHosts:             &pod.ClearTextValue{Value: ""},
-		ConfigRedisSentinelRole:              &pod.ClearTextValue{Value: ""},
-		ConfigQueuesMasterName:               &pod.ClearTextValue{Value: spec.Config.RedisQueuesDSN},
-		ConfigQueuesSentinelHosts:            &pod.ClearTextValue{Value: ""},
-		ConfigQueuesSentinelRole:             &pod.ClearTextValue{Value: ""},
+		ConfigRedisProxy:                     &pod.ClearTextValue{Value: redis.StorageURL},
+		ConfigRedisSentinelHosts:             &pod.ClearTextValue{Value: redis.SentinelHosts},
+		ConfigRedisSentinelRole:              &pod.ClearTextValue{Value: redis.SentinelRole},
+		ConfigQueuesMasterName:               &pod.ClearTextValue{Value: redis.QueuesURL},
+		ConfigQueuesSentinelHosts:            &pod.ClearTextValue{Value: redis.SentinelHosts},
+		ConfigQueuesSentinelRole:             &pod.ClearTextValue{Value: redis.SentinelRole},
 		ConfigMasterServiceID:                &pod.ClearTextValue{Value: fmt.Sprintf("%d", *spec.Config.MasterServiceID)},
 		ConfigRedisAsync:                     &pod.ClearTextValue{Value: strconv.FormatBool(*spec.Worker.Config.RedisAsync)},
 		ConfigWorkersLoggerFormatter:         &pod.ClearTextValue{Value: *spec.Worker.Config.LogFormat},
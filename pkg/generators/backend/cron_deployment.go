@@ -11,7 +11,7 @@ import (
 )
 
 func (gen *CronGenerator) deployment() *appsv1.Deployment {
-	return &appsv1.Deployment{
+	dep := &appsv1.Deployment{
 		Spec: appsv1.DeploymentSpec{
 			Replicas: gen.CronSpec.Replicas,
 			Strategy: appsv1.DeploymentStrategy{
@@ -29,7 +29,7 @@ func (gen *CronGenerator) deployment() *appsv1.Deployment {
 							Name:            strings.Join([]string{component, cron}, "-"),
 							Image:           pod.Image(gen.Image),
 							Args:            []string{"backend-cron"},
-							Env:             pod.BuildEnvironment(gen.Options),
+							Env:             pod.MergeEnvironment(pod.BuildEnvironment(gen.Options), gen.CronSpec.EnvironmentSpec),
 							Resources:       corev1.ResourceRequirements(*gen.CronSpec.Resources),
 							ImagePullPolicy: *gen.Image.PullPolicy,
 						},
@@ -41,4 +41,8 @@ func (gen *CronGenerator) deployment() *appsv1.Deployment {
 			},
 		},
 	}
+
+	dep.Spec.Template = pod.AddSecretFiles(dep.Spec.Template, gen.Options)
+
+	return dep
 }
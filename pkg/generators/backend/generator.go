@@ -6,7 +6,6 @@ import (
 
 	"github.com/3scale-ops/basereconciler/mutators"
 	"github.com/3scale-ops/basereconciler/resource"
-	"github.com/3scale-ops/basereconciler/util"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	"github.com/3scale-ops/saas-operator/pkg/generators"
 	"github.com/3scale-ops/saas-operator/pkg/generators/backend/config"
@@ -18,7 +17,9 @@ import (
 	deployment_workload "github.com/3scale-ops/saas-operator/pkg/workloads/deployment"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -40,8 +41,15 @@ type Generator struct {
 	config               saasv1alpha1.BackendConfig
 }
 
-// NewGenerator returns a new Options struct
-func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec) (Generator, error) {
+// NewGenerator returns a new Options struct. The status of the Sentinel resources
+// referenced by the spec must be passed in the sentinels map.
+func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec,
+	sentinels map[types.NamespacedName]saasv1alpha1.SentinelStatus) (Generator, error) {
+
+	redis, err := config.NewRedisConfig(spec, namespace, sentinels)
+	if err != nil {
+		return Generator{}, err
+	}
 
 	generator := Generator{
 		BaseOptionsV2: generators.BaseOptionsV2{
@@ -64,11 +72,12 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec) (Ge
 					"threescale_component_element": listener,
 				},
 			},
-			ListenerSpec:  spec.Listener,
-			Image:         *spec.Image,
-			Options:       config.NewListenerOptions(spec),
-			Traffic:       true,
-			TwemproxySpec: spec.Twemproxy,
+			ListenerSpec:          spec.Listener,
+			Image:                 *spec.Image,
+			Options:               config.NewListenerOptions(spec, redis),
+			RolloutOnSecretChange: spec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			Traffic:               true,
+			TwemproxySpec:         spec.Twemproxy,
 		},
 		Worker: WorkerGenerator{
 			BaseOptionsV2: generators.BaseOptionsV2{
@@ -81,10 +90,11 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec) (Ge
 					"threescale_component_element": worker,
 				},
 			},
-			WorkerSpec:    *spec.Worker,
-			Image:         *spec.Image,
-			Options:       config.NewWorkerOptions(spec),
-			TwemproxySpec: spec.Twemproxy,
+			WorkerSpec:            *spec.Worker,
+			Image:                 *spec.Image,
+			Options:               config.NewWorkerOptions(spec, redis),
+			RolloutOnSecretChange: spec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			TwemproxySpec:         spec.Twemproxy,
 		},
 		Cron: CronGenerator{
 			BaseOptionsV2: generators.BaseOptionsV2{
@@ -97,9 +107,10 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec) (Ge
 					"threescale_component_element": cron,
 				},
 			},
-			CronSpec: *spec.Cron,
-			Image:    *spec.Image,
-			Options:  config.NewCronOptions(spec),
+			CronSpec:              *spec.Cron,
+			Image:                 *spec.Image,
+			Options:               config.NewCronOptions(spec, redis),
+			RolloutOnSecretChange: spec.Config.ExternalSecret.RolloutOnChangeEnabled(),
 		},
 		grafanaDashboardSpec: *spec.GrafanaDashboard,
 		config:               spec.Config,
@@ -110,6 +121,10 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec) (Ge
 		if err != nil {
 			return Generator{}, err
 		}
+		canaryRedis, err := config.NewRedisConfig(*canarySpec, namespace, sentinels)
+		if err != nil {
+			return Generator{}, err
+		}
 		generator.CanaryListener = &ListenerGenerator{
 			BaseOptionsV2: generators.BaseOptionsV2{
 				Component:    strings.Join([]string{component, listener, "canary"}, "-"),
@@ -121,11 +136,13 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec) (Ge
 					"threescale_component_element": "canary-" + listener,
 				},
 			},
-			ListenerSpec:  canarySpec.Listener,
-			Image:         *canarySpec.Image,
-			Options:       config.NewListenerOptions(*canarySpec),
-			Traffic:       spec.Listener.Canary.SendTraffic,
-			TwemproxySpec: canarySpec.Twemproxy,
+			ListenerSpec:          canarySpec.Listener,
+			Image:                 *canarySpec.Image,
+			Options:               config.NewListenerOptions(*canarySpec, canaryRedis),
+			RolloutOnSecretChange: canarySpec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			Traffic:               spec.Listener.Canary.SendTraffic,
+			Weights:               spec.Listener.Canary,
+			TwemproxySpec:         canarySpec.Twemproxy,
 		}
 		// Disable PDB and HPA for the canary Deployment
 		generator.CanaryListener.ListenerSpec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
@@ -137,6 +154,10 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec) (Ge
 		if err != nil {
 			return Generator{}, err
 		}
+		canaryRedis, err := config.NewRedisConfig(*canarySpec, namespace, sentinels)
+		if err != nil {
+			return Generator{}, err
+		}
 		generator.CanaryWorker = &WorkerGenerator{
 			BaseOptionsV2: generators.BaseOptionsV2{
 				Component:    strings.Join([]string{component, worker, "canary"}, "-"),
@@ -148,10 +169,11 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec) (Ge
 					"threescale_component_element": "canary-" + worker,
 				},
 			},
-			WorkerSpec:    *canarySpec.Worker,
-			Image:         *canarySpec.Image,
-			Options:       config.NewWorkerOptions(*canarySpec),
-			TwemproxySpec: canarySpec.Twemproxy,
+			WorkerSpec:            *canarySpec.Worker,
+			Image:                 *canarySpec.Image,
+			Options:               config.NewWorkerOptions(*canarySpec, canaryRedis),
+			RolloutOnSecretChange: canarySpec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			TwemproxySpec:         canarySpec.Twemproxy,
 		}
 		// Disable PDB and HPA for the canary Deployment
 		generator.CanaryWorker.WorkerSpec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
@@ -161,6 +183,15 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.BackendSpec) (Ge
 	return generator, nil
 }
 
+// Workloads returns the list of Deployment based workloads
+func (gen *Generator) Workloads() []deployment_workload.WithCanary {
+	return []deployment_workload.WithCanary{
+		{Main: &gen.Listener, Canary: gen.CanaryListener, Spec: gen.Listener.ListenerSpec.Canary, SpecPath: "/spec/listener/canary"},
+		{Main: &gen.Worker, Canary: gen.CanaryWorker, Spec: gen.Worker.WorkerSpec.Canary, SpecPath: "/spec/worker/canary"},
+		{Main: &gen.Cron},
+	}
+}
+
 // Resources returns the list of resource templates
 func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 	listener_resources, err := deployment_workload.New(&gen.Listener, gen.CanaryListener)
@@ -186,17 +217,18 @@ func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 			pod.GenerateExternalSecretFn("backend-system-events-hook", gen.GetNamespace(),
 				*gen.config.ExternalSecret.SecretStoreRef.Name, *gen.config.ExternalSecret.SecretStoreRef.Kind,
 				*gen.config.ExternalSecret.RefreshInterval, gen.GetLabels(), gen.Worker.Options),
-		),
+		).WithEnabled(pod.HasExternalSecretData("backend-system-events-hook", gen.Worker.Options)),
 		resource.NewTemplate(
 			pod.GenerateExternalSecretFn("backend-internal-api", gen.GetNamespace(),
 				*gen.config.ExternalSecret.SecretStoreRef.Name, *gen.config.ExternalSecret.SecretStoreRef.Kind,
 				*gen.config.ExternalSecret.RefreshInterval, gen.GetLabels(), gen.Listener.Options),
-		),
+		).WithEnabled(pod.HasExternalSecretData("backend-internal-api", gen.Listener.Options)),
 		resource.NewTemplate(
 			pod.GenerateExternalSecretFn("backend-error-monitoring", gen.GetNamespace(),
 				*gen.config.ExternalSecret.SecretStoreRef.Name, *gen.config.ExternalSecret.SecretStoreRef.Kind,
 				*gen.config.ExternalSecret.RefreshInterval, gen.GetLabels(), gen.Listener.Options)).
-			WithEnabled(gen.config.ErrorMonitoringKey != nil),
+			WithEnabled(gen.config.ErrorMonitoringKey != nil &&
+				pod.HasExternalSecretData("backend-error-monitoring", gen.Listener.Options)),
 	}
 
 	return operatorutil.ConcatSlices(listener_resources, worker_resources, cron_resources, misc), nil
@@ -206,11 +238,13 @@ func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 // Backend environment
 type ListenerGenerator struct {
 	generators.BaseOptionsV2
-	Image         saasv1alpha1.ImageSpec
-	ListenerSpec  saasv1alpha1.ListenerSpec
-	Options       config.ListenerOptions
-	Traffic       bool
-	TwemproxySpec *saasv1alpha1.TwemproxySpec
+	Image                 saasv1alpha1.ImageSpec
+	ListenerSpec          saasv1alpha1.ListenerSpec
+	Options               config.ListenerOptions
+	RolloutOnSecretChange bool
+	Traffic               bool
+	Weights               *saasv1alpha1.Canary
+	TwemproxySpec         *saasv1alpha1.TwemproxySpec
 }
 
 // Validate that ListenerGenerator implements deployment_workload.DeploymentWorkloadWithTraffic interface
@@ -222,14 +256,23 @@ var _ deployment_workload.WithTraffic = &ListenerGenerator{}
 // Validate that ListenerGenerator implements deployment_workload.WithEnvoySidecar interface
 var _ deployment_workload.WithEnvoySidecar = &ListenerGenerator{}
 
+// Validate that ListenerGenerator implements deployment_workload.WithWeightedTraffic interface
+var _ deployment_workload.WithWeightedTraffic = &ListenerGenerator{}
+
+// Validate that ListenerGenerator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &ListenerGenerator{}
+
 func (gen *ListenerGenerator) Labels() map[string]string {
 	return gen.GetLabels()
 }
 func (gen *ListenerGenerator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return resource.NewTemplateFromObjectFunction(gen.deployment).
 		WithMutation(mutators.SetDeploymentReplicas(gen.ListenerSpec.HPA.IsDeactivated())).
-		WithMutation(mutators.RolloutTrigger{Name: "backend-internal-api", SecretName: util.Pointer("backend-internal-api")}.Add()).
-		WithMutation(mutators.RolloutTrigger{Name: "backend-error-monitoring", SecretName: util.Pointer("backend-error-monitoring")}.Add())
+		WithMutations(pod.SecretRolloutTriggers(gen.RolloutOnSecretChange, gen.Options, gen.ListenerSpec.EnvironmentSpec))
+}
+
+func (gen *ListenerGenerator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.ListenerSpec.DeploymentStrategy
 }
 
 func (gen *ListenerGenerator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
@@ -265,29 +308,44 @@ func (gen *ListenerGenerator) TrafficSelector() map[string]string {
 func (gen *ListenerGenerator) EnvoyDynamicConfigurations() []descriptor.EnvoyDynamicConfigDescriptor {
 	return gen.ListenerSpec.Marin3r.EnvoyDynamicConfig.AsList()
 }
+func (gen *ListenerGenerator) TrafficWeights() *saasv1alpha1.Canary { return gen.Weights }
 
 // WorkerGenerator has methods to generate resources for a
 // Backend environment
 type WorkerGenerator struct {
 	generators.BaseOptionsV2
-	Image         saasv1alpha1.ImageSpec
-	WorkerSpec    saasv1alpha1.WorkerSpec
-	Options       config.WorkerOptions
-	TwemproxySpec *saasv1alpha1.TwemproxySpec
+	Image                 saasv1alpha1.ImageSpec
+	WorkerSpec            saasv1alpha1.WorkerSpec
+	Options               config.WorkerOptions
+	RolloutOnSecretChange bool
+	TwemproxySpec         *saasv1alpha1.TwemproxySpec
 }
 
 // Validate that WorkerGenerator implements deployment_workload.DeploymentWorkload interface
 var _ deployment_workload.DeploymentWorkload = &WorkerGenerator{}
 
+// Validate that WorkerGenerator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &WorkerGenerator{}
+
+// Validate that WorkerGenerator implements deployment_workload.WithSuggestedMetrics interface
+var _ deployment_workload.WithSuggestedMetrics = &WorkerGenerator{}
+
 func (gen *WorkerGenerator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return resource.NewTemplateFromObjectFunction(gen.deployment).
 		WithMutation(mutators.SetDeploymentReplicas(gen.WorkerSpec.HPA.IsDeactivated())).
-		WithMutation(mutators.RolloutTrigger{Name: "backend-system-events-hook", SecretName: util.Pointer("backend-system-events-hook")}.Add()).
-		WithMutation(mutators.RolloutTrigger{Name: "backend-error-monitoring", SecretName: util.Pointer("backend-error-monitoring")}.Add())
+		WithMutations(pod.SecretRolloutTriggers(gen.RolloutOnSecretChange, gen.Options, gen.WorkerSpec.EnvironmentSpec))
+}
+func (gen *WorkerGenerator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.WorkerSpec.DeploymentStrategy
 }
+
 func (gen *WorkerGenerator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
 	return gen.WorkerSpec.HPA
 }
+
+func (gen *WorkerGenerator) SuggestedMetrics() []autoscalingv2.MetricSpec {
+	return gen.WorkerSpec.SuggestedMetrics()
+}
 func (gen *WorkerGenerator) PDBSpec() *saasv1alpha1.PodDisruptionBudgetSpec {
 	return gen.WorkerSpec.PDB
 }
@@ -305,9 +363,10 @@ func (gen *WorkerGenerator) MonitoredEndpoints() []monitoringv1.PodMetricsEndpoi
 // Backend environment
 type CronGenerator struct {
 	generators.BaseOptionsV2
-	Image    saasv1alpha1.ImageSpec
-	CronSpec saasv1alpha1.CronSpec
-	Options  config.CronOptions
+	Image                 saasv1alpha1.ImageSpec
+	CronSpec              saasv1alpha1.CronSpec
+	Options               config.CronOptions
+	RolloutOnSecretChange bool
 }
 
 // Validate that CronGenerator implements deployment_workload.DeploymentWorkload interface
@@ -315,7 +374,7 @@ var _ deployment_workload.DeploymentWorkload = &CronGenerator{}
 
 func (gen *CronGenerator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return resource.NewTemplateFromObjectFunction(gen.deployment).
-		WithMutation(mutators.RolloutTrigger{Name: "backend-error-monitoring", SecretName: util.Pointer("backend-error-monitoring")}.Add())
+		WithMutations(pod.SecretRolloutTriggers(gen.RolloutOnSecretChange, gen.Options, gen.CronSpec.EnvironmentSpec))
 }
 func (gen *CronGenerator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
 	return &saasv1alpha1.HorizontalPodAutoscalerSpec{}
@@ -16,13 +16,7 @@ func (gen *ListenerGenerator) deployment() *appsv1.Deployment {
 	dep := &appsv1.Deployment{
 		Spec: appsv1.DeploymentSpec{
 			Replicas: gen.ListenerSpec.Replicas,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: util.Pointer(intstr.FromInt(0)),
-					MaxSurge:       util.Pointer(intstr.FromInt(1)),
-				},
-			},
+			Strategy: gen.ListenerSpec.DeploymentStrategy.DeploymentStrategy(),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
 					ImagePullSecrets: pod.ImagePullSecrets(gen.Image.PullSecretName),
@@ -43,7 +37,7 @@ func (gen *ListenerGenerator) deployment() *appsv1.Deployment {
 								pod.ContainerPortTCP("http", 3000),
 								pod.ContainerPortTCP("metrics", 9394),
 							),
-							Env:             pod.BuildEnvironment(gen.Options),
+							Env:             pod.MergeEnvironment(pod.BuildEnvironment(gen.Options), gen.ListenerSpec.EnvironmentSpec),
 							Resources:       corev1.ResourceRequirements(*gen.ListenerSpec.Resources),
 							ImagePullPolicy: *gen.Image.PullPolicy,
 							LivenessProbe:   pod.TCPProbe(intstr.FromString("http"), *gen.ListenerSpec.LivenessProbe),
@@ -59,6 +53,8 @@ func (gen *ListenerGenerator) deployment() *appsv1.Deployment {
 		},
 	}
 
+	dep.Spec.Template = pod.AddSecretFiles(dep.Spec.Template, gen.Options)
+
 	if !gen.ListenerSpec.Marin3r.IsDeactivated() {
 		dep = marin3r.EnableSidecar(*dep, *gen.ListenerSpec.Marin3r)
 	}
@@ -15,13 +15,7 @@ func (gen *WorkerGenerator) deployment() *appsv1.Deployment {
 	dep := &appsv1.Deployment{
 		Spec: appsv1.DeploymentSpec{
 			Replicas: gen.WorkerSpec.Replicas,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: util.Pointer(intstr.FromInt(0)),
-					MaxSurge:       util.Pointer(intstr.FromInt(1)),
-				},
-			},
+			Strategy: gen.WorkerSpec.DeploymentStrategy.DeploymentStrategy(),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
 					ImagePullSecrets: pod.ImagePullSecrets(gen.Image.PullSecretName),
@@ -33,7 +27,7 @@ func (gen *WorkerGenerator) deployment() *appsv1.Deployment {
 							Ports: pod.ContainerPorts(
 								pod.ContainerPortTCP("metrics", 9421),
 							),
-							Env:             pod.BuildEnvironment(gen.Options),
+							Env:             pod.MergeEnvironment(pod.BuildEnvironment(gen.Options), gen.WorkerSpec.EnvironmentSpec),
 							Resources:       corev1.ResourceRequirements(*gen.WorkerSpec.Resources),
 							ImagePullPolicy: *gen.Image.PullPolicy,
 							LivenessProbe:   pod.HTTPProbe("/metrics", intstr.FromString("metrics"), corev1.URISchemeHTTP, *gen.WorkerSpec.LivenessProbe),
@@ -48,6 +42,8 @@ func (gen *WorkerGenerator) deployment() *appsv1.Deployment {
 		},
 	}
 
+	dep.Spec.Template = pod.AddSecretFiles(dep.Spec.Template, gen.Options)
+
 	if gen.TwemproxySpec != nil {
 		dep.Spec.Template = twemproxy.AddTwemproxySidecar(dep.Spec.Template, gen.TwemproxySpec)
 	}
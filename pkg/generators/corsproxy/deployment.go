@@ -11,16 +11,10 @@ import (
 )
 
 func (gen *Generator) deployment() *appsv1.Deployment {
-	return &appsv1.Deployment{
+	dep := &appsv1.Deployment{
 		Spec: appsv1.DeploymentSpec{
 			Replicas: gen.Spec.Replicas,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: util.Pointer(intstr.FromInt(0)),
-					MaxSurge:       util.Pointer(intstr.FromInt(1)),
-				},
-			},
+			Strategy: gen.Spec.DeploymentStrategy.DeploymentStrategy(),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
 					ImagePullSecrets: func() []corev1.LocalObjectReference {
@@ -51,4 +45,8 @@ func (gen *Generator) deployment() *appsv1.Deployment {
 			},
 		},
 	}
+
+	dep.Spec.Template = pod.AddSecretFiles(dep.Spec.Template, gen.Options)
+
+	return dep
 }
@@ -5,7 +5,6 @@ import (
 
 	"github.com/3scale-ops/basereconciler/mutators"
 	"github.com/3scale-ops/basereconciler/resource"
-	"github.com/3scale-ops/basereconciler/util"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	"github.com/3scale-ops/saas-operator/pkg/generators"
 	"github.com/3scale-ops/saas-operator/pkg/generators/corsproxy/config"
@@ -55,6 +54,13 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.CORSProxySpec) G
 	}
 }
 
+// Workloads returns the list of Deployment based workloads
+func (gen *Generator) Workloads() []deployment_workload.WithCanary {
+	return []deployment_workload.WithCanary{
+		{Main: gen},
+	}
+}
+
 // Resources returns the list of resource templates
 func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 	workload, err := deployment_workload.New(gen, nil)
@@ -65,7 +71,8 @@ func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 		resource.NewTemplate(
 			pod.GenerateExternalSecretFn("cors-proxy-system-database", gen.GetNamespace(),
 				*gen.Spec.Config.ExternalSecret.SecretStoreRef.Name, *gen.Spec.Config.ExternalSecret.SecretStoreRef.Kind,
-				*gen.Spec.Config.ExternalSecret.RefreshInterval, gen.GetLabels(), gen.Options)),
+				*gen.Spec.Config.ExternalSecret.RefreshInterval, gen.GetLabels(), gen.Options)).
+			WithEnabled(pod.HasExternalSecretData("cors-proxy-system-database", gen.Options)),
 		resource.NewTemplate(
 			grafanadashboard.New(gen.GetKey(), gen.GetLabels(), *gen.Spec.GrafanaDashboard, "dashboards/cors-proxy.json.gtpl")).
 			WithEnabled(!gen.Spec.GrafanaDashboard.IsDeactivated()),
@@ -88,10 +95,17 @@ func (gen *Generator) TrafficSelector() map[string]string {
 // Validate that Generator implements deployment_workload.DeploymentWorkload interface
 var _ deployment_workload.DeploymentWorkload = &Generator{}
 
+// Validate that Generator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &Generator{}
+
 func (gen *Generator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return resource.NewTemplateFromObjectFunction(gen.deployment).
 		WithMutation(mutators.SetDeploymentReplicas(gen.Spec.HPA.IsDeactivated())).
-		WithMutation(mutators.RolloutTrigger{Name: "cors-proxy-system-database", SecretName: util.Pointer("cors-proxy-system-database")}.Add())
+		WithMutations(pod.SecretRolloutTriggers(gen.Spec.Config.ExternalSecret.RolloutOnChangeEnabled(), gen.Options))
+}
+
+func (gen *Generator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.Spec.DeploymentStrategy
 }
 
 func (gen *Generator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
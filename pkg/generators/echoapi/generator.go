@@ -35,6 +35,9 @@ var _ deployment_workload.WithTraffic = &Generator{}
 // Validate that Generator implements deployment_workload.WithEnvoySidecar interface
 var _ deployment_workload.WithEnvoySidecar = &Generator{}
 
+// Validate that Generator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &Generator{}
+
 // NewGenerator returns a new Options struct
 func NewGenerator(instance, namespace string, spec saasv1alpha1.EchoAPISpec) Generator {
 	return Generator{
@@ -52,6 +55,13 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.EchoAPISpec) Gen
 	}
 }
 
+// Workloads returns the list of Deployment based workloads
+func (gen *Generator) Workloads() []deployment_workload.WithCanary {
+	return []deployment_workload.WithCanary{
+		{Main: gen},
+	}
+}
+
 // Resources returns the list of resource templates
 func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 	workload, err := deployment_workload.New(gen, nil)
@@ -79,6 +89,10 @@ func (gen *Generator) Deployment() *resource.Template[*appsv1.Deployment] {
 		WithMutation(mutators.SetDeploymentReplicas(gen.Spec.HPA.IsDeactivated()))
 }
 
+func (gen *Generator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.Spec.DeploymentStrategy
+}
+
 func (gen *Generator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
 	return gen.Spec.HPA
 }
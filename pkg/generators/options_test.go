@@ -0,0 +1,138 @@
+package generators_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	apicast "github.com/3scale-ops/saas-operator/pkg/generators/apicast/config"
+	autossl "github.com/3scale-ops/saas-operator/pkg/generators/autossl/config"
+	backend "github.com/3scale-ops/saas-operator/pkg/generators/backend/config"
+	corsproxy "github.com/3scale-ops/saas-operator/pkg/generators/corsproxy/config"
+	mappingservice "github.com/3scale-ops/saas-operator/pkg/generators/mappingservice/config"
+	sentinel "github.com/3scale-ops/saas-operator/pkg/generators/sentinel/config"
+	system "github.com/3scale-ops/saas-operator/pkg/generators/system/config"
+	zync "github.com/3scale-ops/saas-operator/pkg/generators/zync/config"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/pod"
+)
+
+// TestOptions checks that all the options structs used by the generators are well
+// formed, so malformed struct tags are detected here and not while reconciling. The
+// test fails if any of the options structs declared in the config packages of the
+// generators is not checked.
+func TestOptions(t *testing.T) {
+
+	apicastSpec := saasv1alpha1.ApicastSpec{}
+	apicastSpec.Default()
+	autosslSpec := saasv1alpha1.AutoSSLSpec{}
+	autosslSpec.Default()
+	backendSpec := saasv1alpha1.BackendSpec{}
+	backendSpec.Default()
+	corsproxySpec := saasv1alpha1.CORSProxySpec{}
+	corsproxySpec.Default()
+	mappingserviceSpec := saasv1alpha1.MappingServiceSpec{}
+	mappingserviceSpec.Default()
+	sentinelSpec := saasv1alpha1.SentinelSpec{Config: &saasv1alpha1.SentinelConfig{}}
+	sentinelSpec.Default()
+	systemSpec := saasv1alpha1.SystemSpec{Config: saasv1alpha1.SystemConfig{Zync: &saasv1alpha1.SystemZyncSpec{}}}
+	systemSpec.Default()
+	zyncSpec := saasv1alpha1.ZyncSpec{}
+	zyncSpec.Default()
+
+	tests := []struct {
+		name string
+		opts interface{}
+	}{
+		{"apicast/EnvOptions (empty)", apicast.EnvOptions{}},
+		{"apicast/EnvOptions", apicast.NewEnvOptions(apicastSpec.Staging, "staging")},
+		{"autossl/Options (empty)", autossl.Options{}},
+		{"autossl/Options", autossl.NewOptions(autosslSpec)},
+		{"backend/ListenerOptions (empty)", backend.ListenerOptions{}},
+		{"backend/ListenerOptions", backend.NewListenerOptions(backendSpec, backend.RedisConfig{})},
+		{"backend/WorkerOptions (empty)", backend.WorkerOptions{}},
+		{"backend/WorkerOptions", backend.NewWorkerOptions(backendSpec, backend.RedisConfig{})},
+		{"backend/CronOptions (empty)", backend.CronOptions{}},
+		{"backend/CronOptions", backend.NewCronOptions(backendSpec, backend.RedisConfig{})},
+		{"corsproxy/Options (empty)", corsproxy.Options{}},
+		{"corsproxy/Options", corsproxy.NewOptions(corsproxySpec)},
+		{"mappingservice/Options (empty)", mappingservice.Options{}},
+		{"mappingservice/Options", mappingservice.NewOptions(mappingserviceSpec)},
+		{"sentinel/Options (empty)", sentinel.Options{}},
+		{"sentinel/Options", sentinel.NewOptions(sentinelSpec)},
+		{"system/Options (empty)", system.Options{}},
+		{"system/Options", system.NewOptions(systemSpec, system.RedisConfig{})},
+		{"zync/APIOptions (empty)", zync.APIOptions{}},
+		{"zync/APIOptions", zync.NewAPIOptions(zyncSpec)},
+		{"zync/QueOptions (empty)", zync.QueOptions{}},
+		{"zync/QueOptions", zync.NewQueOptions(zyncSpec)},
+	}
+	checked := map[string]bool{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := pod.ValidateOptions(tt.opts); err != nil {
+				t.Errorf("ValidateOptions() error = %v", err)
+			}
+		})
+		checked[optionsName(reflect.TypeOf(tt.opts))] = true
+	}
+
+	declared, err := declaredOptions("*/config/*.go")
+	if err != nil {
+		t.Fatalf("unable to find the options structs: %v", err)
+	}
+	for _, name := range declared {
+		if !checked[name] {
+			t.Errorf("options struct %s is not checked by TestOptions", name)
+		}
+	}
+}
+
+// optionsName returns the name of the given options struct type,
+// prefixed by the name of the generator, as in 'backend/WorkerOptions'
+func optionsName(t reflect.Type) string {
+	return path.Base(path.Dir(t.PkgPath())) + "/" + t.Name()
+}
+
+// declaredOptions returns the names of the structs declared in the files matched
+// by the given pattern that have any field of type pod.EnvVarValue
+func declaredOptions(pattern string) ([]string, error) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			for _, field := range st.Fields.List {
+				if sel, ok := field.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "EnvVarValue" {
+					names = append(names, filepath.Base(filepath.Dir(filepath.Dir(file)))+"/"+spec.Name.Name)
+					break
+				}
+			}
+			return false
+		})
+	}
+	return names, nil
+}
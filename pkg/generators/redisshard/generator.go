@@ -6,6 +6,9 @@ import (
 	"github.com/3scale-ops/basereconciler/resource"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	"github.com/3scale-ops/saas-operator/pkg/generators"
+	statefulset_workload "github.com/3scale-ops/saas-operator/pkg/workloads/statefulset"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -19,6 +22,7 @@ type Generator struct {
 	MasterIndex int32
 	Replicas    int32
 	Command     string
+	Rollout     *saasv1alpha1.StatefulSetRolloutSpec
 }
 
 // Override the GetSelector function as it needs to be different in this case
@@ -27,6 +31,12 @@ func (gen *Generator) GetSelector() map[string]string {
 	return map[string]string{"redis-shard": gen.GetInstanceName()}
 }
 
+// Override the GetKey function as the StatefulSet name includes the instance
+// name, for the same reason as above
+func (gen *Generator) GetKey() types.NamespacedName {
+	return types.NamespacedName{Name: gen.ServiceName(), Namespace: gen.GetNamespace()}
+}
+
 // NewGenerator returns a new Options struct
 func NewGenerator(instance, namespace string, spec saasv1alpha1.RedisShardSpec) Generator {
 	return Generator{
@@ -43,17 +53,27 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.RedisShardSpec)
 		MasterIndex: *spec.MasterIndex,
 		Replicas:    *spec.SlaveCount + 1,
 		Command:     *spec.Command,
+		Rollout:     spec.Rollout,
 	}
 }
 
+// Validate that Generator implements statefulset_workload.StatefulSetWorkload interface
+var _ statefulset_workload.StatefulSetWorkload = &Generator{}
+
+func (gen *Generator) StatefulSet() *resource.Template[*appsv1.StatefulSet] {
+	return resource.NewTemplateFromObjectFunction(gen.statefulSet)
+}
+func (gen *Generator) RolloutSpec() *saasv1alpha1.StatefulSetRolloutSpec {
+	return gen.Rollout
+}
+
 // Resources returns the list of templates
 func (gen *Generator) Resources() []resource.TemplateInterface {
-	return []resource.TemplateInterface{
-		resource.NewTemplateFromObjectFunction(gen.statefulSet),
+	return append(statefulset_workload.New(gen),
 		resource.NewTemplateFromObjectFunction(gen.service),
 		resource.NewTemplateFromObjectFunction(gen.redisConfigConfigMap),
 		resource.NewTemplateFromObjectFunction(gen.redisReadinessScriptConfigMap),
-	}
+	)
 }
 
 // Returns the name of the StatefulSet headless Service
@@ -0,0 +1,64 @@
+package redisshard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestGenerator_StatefulSet(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          saasv1alpha1.RedisShardSpec
+		wantName      string
+		wantPartition int32
+	}{
+		{
+			name:          "Updates all the pods by default",
+			spec:          saasv1alpha1.RedisShardSpec{},
+			wantName:      "redis-shard-test",
+			wantPartition: 0,
+		},
+		{
+			name: "Holds the pods below the partition",
+			spec: saasv1alpha1.RedisShardSpec{
+				Rollout: &saasv1alpha1.StatefulSetRolloutSpec{Partition: util.Pointer[int32](2)},
+			},
+			wantName:      "redis-shard-test",
+			wantPartition: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.Default()
+			gen := NewGenerator("test", "ns", tt.spec)
+			o, err := gen.Resources()[0].Build(context.TODO(), nil, nil)
+			if err != nil {
+				t.Fatalf("Generator.Resources() error = %v", err)
+			}
+			sts, ok := o.(*appsv1.StatefulSet)
+			if !ok {
+				t.Fatalf("Generator.Resources()[0] = %T, want *appsv1.StatefulSet", o)
+			}
+			if sts.GetName() != tt.wantName {
+				t.Errorf("StatefulSet name = %v, want %v", sts.GetName(), tt.wantName)
+			}
+			want := appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: util.Pointer(tt.wantPartition),
+				},
+			}
+			if diff := deep.Equal(sts.Spec.UpdateStrategy, want); len(diff) > 0 {
+				t.Errorf("StatefulSet updateStrategy = diff %v", diff)
+			}
+			if diff := deep.Equal(sts.Spec.Selector.MatchLabels, map[string]string{"redis-shard": "test"}); len(diff) > 0 {
+				t.Errorf("StatefulSet selector = diff %v", diff)
+			}
+		})
+	}
+}
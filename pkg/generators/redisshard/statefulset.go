@@ -86,12 +86,6 @@ func (gen *Generator) statefulSet() *appsv1.StatefulSet {
 					},
 				},
 			},
-			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: appsv1.RollingUpdateStatefulSetStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
-					Partition: util.Pointer[int32](0),
-				},
-			},
 		},
 	}
 }
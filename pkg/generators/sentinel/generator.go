@@ -15,6 +15,8 @@ import (
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/grafanadashboard"
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/pdb"
 	operatorutils "github.com/3scale-ops/saas-operator/pkg/util"
+	statefulset_workload "github.com/3scale-ops/saas-operator/pkg/workloads/statefulset"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -46,16 +48,25 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.SentinelSpec) Ge
 	}
 }
 
+// Validate that Generator implements statefulset_workload.StatefulSetWorkload interface
+var _ statefulset_workload.StatefulSetWorkload = &Generator{}
+
+func (gen *Generator) StatefulSet() *resource.Template[*appsv1.StatefulSet] {
+	return resource.NewTemplateFromObjectFunction(gen.statefulSet)
+}
+func (gen *Generator) RolloutSpec() *saasv1alpha1.StatefulSetRolloutSpec {
+	return gen.Spec.Rollout
+}
+
 // Resources returns a list of templates
 func (gen *Generator) Resources() []resource.TemplateInterface {
-	resources := []resource.TemplateInterface{
-		resource.NewTemplateFromObjectFunction(gen.statefulSet),
+	resources := append(statefulset_workload.New(gen),
 		resource.NewTemplateFromObjectFunction(gen.statefulSetService).WithMutation(mutators.SetServiceLiveValues()),
 		resource.NewTemplate(pdb.New(gen.GetKey(), gen.GetLabels(), gen.GetSelector(), *gen.Spec.PDB)),
 		resource.NewTemplateFromObjectFunction(gen.configMap),
 		resource.NewTemplate(grafanadashboard.New(gen.GetKey(), gen.GetLabels(), *gen.Spec.GrafanaDashboard, "dashboards/redis-sentinel.json.gtpl")).
 			WithEnabled(!gen.Spec.GrafanaDashboard.IsDeactivated()),
-	}
+	)
 
 	for idx := 0; idx < int(*gen.Spec.Replicas); idx++ {
 		i := idx
@@ -16,7 +16,7 @@ func (gen *AppGenerator) deployment() *appsv1.Deployment {
 	dep := &appsv1.Deployment{
 		Spec: appsv1.DeploymentSpec{
 			Replicas: gen.Spec.Replicas,
-			Strategy: appsv1.DeploymentStrategy(*gen.Spec.DeploymentStrategy),
+			Strategy: gen.Spec.DeploymentStrategy.DeploymentStrategy(),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
 					ImagePullSecrets: func() []corev1.LocalObjectReference {
@@ -32,7 +32,7 @@ func (gen *AppGenerator) deployment() *appsv1.Deployment {
 							Args: []string{
 								"bundle", "exec", "rake", "k8s:deploy",
 							},
-							Env:             pod.BuildEnvironment(gen.Options),
+							Env:             pod.MergeEnvironment(pod.BuildEnvironment(gen.Options), gen.Spec.EnvironmentSpec),
 							ImagePullPolicy: *gen.Image.PullPolicy,
 						},
 					},
@@ -50,7 +50,7 @@ func (gen *AppGenerator) deployment() *appsv1.Deployment {
 								"-c",
 								"config/unicorn.rb",
 							},
-							Env: pod.BuildEnvironment(gen.Options),
+							Env: pod.MergeEnvironment(pod.BuildEnvironment(gen.Options), gen.Spec.EnvironmentSpec),
 							Ports: pod.ContainerPorts(
 								pod.ContainerPortTCP("ui-api", 3000),
 								pod.ContainerPortTCP("metrics", 9394),
@@ -70,6 +70,8 @@ func (gen *AppGenerator) deployment() *appsv1.Deployment {
 		},
 	}
 
+	dep.Spec.Template = pod.AddSecretFiles(dep.Spec.Template, gen.Options)
+
 	dep.Spec.Template.Spec.Volumes = append(
 		dep.Spec.Template.Spec.Volumes,
 		corev1.Volume{
@@ -83,7 +83,8 @@ type Options struct {
 }
 
 // NewOptions returns an Options struct for the given saasv1alpha1.SystemSpec
-func NewOptions(spec saasv1alpha1.SystemSpec) Options {
+// and redis connection settings
+func NewOptions(spec saasv1alpha1.SystemSpec, redis RedisConfig) Options {
 	opts := Options{
 		ForceSSL:                      &pod.ClearTextValue{Value: fmt.Sprintf("%t", *spec.Config.ForceSSL)},
 		ProviderPlan:                  &pod.ClearTextValue{Value: *spec.Config.ThreescaleProviderPlan},
@@ -108,10 +109,10 @@ func NewOptions(spec saasv1alpha1.SystemSpec) Options {
 
 		EventsHookPassword: &pod.SecretValue{Value: spec.Config.EventsSharedSecret},
 
-		RedisURL:           &pod.ClearTextValue{Value: spec.Config.Redis.QueuesDSN},
+		RedisURL:           &pod.ClearTextValue{Value: redis.URL},
 		RedisNamespace:     &pod.ClearTextValue{Value: ""},
-		RedisSentinelHosts: &pod.ClearTextValue{Value: ""},
-		RedisSentinelRole:  &pod.ClearTextValue{Value: ""},
+		RedisSentinelHosts: &pod.ClearTextValue{Value: redis.SentinelHosts},
+		RedisSentinelRole:  &pod.ClearTextValue{Value: redis.SentinelRole},
 
 		SMTPAddress:           &pod.ClearTextValue{Value: spec.Config.SMTP.Address},
 		SMTPUserName:          &pod.SecretValue{Value: spec.Config.SMTP.User},
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RedisConfig holds the connection settings for the system redis
+type RedisConfig struct {
+	URL           string
+	SentinelHosts string
+	SentinelRole  string
+}
+
+// NewRedisConfig returns the redis connection settings for the given saasv1alpha1.SystemSpec.
+// If the spec has a sentinelRef, the sentinel endpoints are resolved from the status of the
+// referenced Sentinel resource, looked up in the given map.
+func NewRedisConfig(spec saasv1alpha1.SystemSpec, namespace string,
+	sentinels map[types.NamespacedName]saasv1alpha1.SentinelStatus) (RedisConfig, error) {
+
+	ref := spec.Config.Redis.SentinelRef
+	if ref == nil {
+		return RedisConfig{URL: spec.Config.Redis.QueuesDSN}, nil
+	}
+
+	key := ref.ObjectKey(namespace)
+	status, ok := sentinels[key]
+	if !ok {
+		return RedisConfig{}, fmt.Errorf("unable to resolve sentinelRef: Sentinel %s not found", key)
+	}
+	hosts, err := status.SentinelHosts(ref.Shard)
+	if err != nil {
+		return RedisConfig{}, fmt.Errorf("unable to resolve sentinelRef: Sentinel %s: %w", key, err)
+	}
+
+	return RedisConfig{
+		URL:           "redis://" + ref.Shard,
+		SentinelHosts: hosts,
+		SentinelRole:  "master",
+	}, nil
+}
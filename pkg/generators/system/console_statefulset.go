@@ -20,11 +20,8 @@ func (gen *ConsoleGenerator) statefulset() *appsv1.StatefulSet {
 			Labels:    gen.GetLabels(),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: util.Pointer[int32](1),
-			Selector: &metav1.LabelSelector{MatchLabels: gen.GetSelector()},
-			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: appsv1.RollingUpdateStatefulSetStrategyType,
-			},
+			Replicas:            util.Pointer[int32](1),
+			Selector:            &metav1.LabelSelector{MatchLabels: gen.GetSelector()},
 			PodManagementPolicy: appsv1.ParallelPodManagement,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -45,7 +42,7 @@ func (gen *ConsoleGenerator) statefulset() *appsv1.StatefulSet {
 								"sleep",
 								"infinity",
 							},
-							Env:             pod.BuildEnvironment(gen.Options),
+							Env:             pod.MergeEnvironment(pod.BuildEnvironment(gen.Options), gen.Spec.EnvironmentSpec),
 							Ports:           nil,
 							Resources:       corev1.ResourceRequirements(*gen.Spec.Resources),
 							ImagePullPolicy: *gen.Image.PullPolicy,
@@ -58,6 +55,8 @@ func (gen *ConsoleGenerator) statefulset() *appsv1.StatefulSet {
 		},
 	}
 
+	sts.Spec.Template = pod.AddSecretFiles(sts.Spec.Template, gen.Options)
+
 	sts.Spec.Template.Spec.Volumes = append(sts.Spec.Template.Spec.Volumes,
 		corev1.Volume{
 			Name: "system-config",
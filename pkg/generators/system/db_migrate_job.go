@@ -0,0 +1,81 @@
+package system
+
+import (
+	"strings"
+
+	"github.com/3scale-ops/basereconciler/util"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/pod"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (gen *DatabaseMigrationGenerator) job(image string) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: gen.GetLabels(),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          gen.Spec.BackoffLimit,
+			ActiveDeadlineSeconds: gen.Spec.ActiveDeadlineSeconds,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: gen.GetLabels(),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					ImagePullSecrets: func() []corev1.LocalObjectReference {
+						if gen.Image.PullSecretName != nil {
+							return []corev1.LocalObjectReference{{Name: *gen.Image.PullSecretName}}
+						}
+						return nil
+					}(),
+					Containers: []corev1.Container{
+						{
+							Name:            strings.Join([]string{component, dbMigrate}, "-"),
+							Image:           image,
+							Command:         gen.Spec.Command,
+							Env:             pod.BuildEnvironment(gen.Options),
+							Resources:       corev1.ResourceRequirements(*gen.Spec.Resources),
+							ImagePullPolicy: *gen.Image.PullPolicy,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "system-tmp",
+									MountPath: "/tmp",
+								},
+								{
+									Name:      "system-config",
+									ReadOnly:  true,
+									MountPath: "/opt/system-extra-configs",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "system-tmp",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{
+									Medium: corev1.StorageMediumMemory,
+								},
+							},
+						},
+						{
+							Name: "system-config",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									DefaultMode: util.Pointer[int32](420),
+									SecretName:  gen.ConfigFilesSecret,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	job.Spec.Template = pod.AddSecretFiles(job.Spec.Template, gen.Options)
+
+	return job
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/3scale-ops/basereconciler/mutators"
 	"github.com/3scale-ops/basereconciler/resource"
-	"github.com/3scale-ops/basereconciler/util"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	"github.com/3scale-ops/saas-operator/pkg/generators"
 	"github.com/3scale-ops/saas-operator/pkg/generators/system/config"
@@ -15,21 +14,24 @@ import (
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/podmonitor"
 	operatorutil "github.com/3scale-ops/saas-operator/pkg/util"
 	deployment_workload "github.com/3scale-ops/saas-operator/pkg/workloads/deployment"
+	migration_workload "github.com/3scale-ops/saas-operator/pkg/workloads/migration"
+	statefulset_workload "github.com/3scale-ops/saas-operator/pkg/workloads/statefulset"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	res "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
-	component      string = "system"
-	app            string = "app"
-	console        string = "console"
-	sidekiq        string = "sidekiq"
-	sidekiqDefault string = "sidekiq-default"
-	sidekiqBilling string = "sidekiq-billing"
-	sidekiqLow     string = "sidekiq-low"
-	searchd        string = "searchd"
+	component string = "system"
+	app       string = "app"
+	console   string = "console"
+	sidekiq   string = "sidekiq"
+	searchd   string = "searchd"
+	dbMigrate string = "db-migrate"
 )
 
 // Generator configures the generators for System
@@ -37,12 +39,7 @@ type Generator struct {
 	generators.BaseOptionsV2
 	App                  AppGenerator
 	CanaryApp            *AppGenerator
-	SidekiqDefault       SidekiqGenerator
-	CanarySidekiqDefault *SidekiqGenerator
-	SidekiqBilling       SidekiqGenerator
-	CanarySidekiqBilling *SidekiqGenerator
-	SidekiqLow           SidekiqGenerator
-	CanarySidekiqLow     *SidekiqGenerator
+	Sidekiqs             []SidekiqPoolGenerator
 	Searchd              SearchdGenerator
 	Console              ConsoleGenerator
 	Config               saasv1alpha1.SystemConfig
@@ -50,10 +47,18 @@ type Generator struct {
 	ConfigFilesSecret    string
 	Options              config.Options
 	Tekton               []SystemTektonGenerator
+	DatabaseMigration    DatabaseMigrationGenerator
 }
 
-// NewGenerator returns a new Options struct
-func NewGenerator(instance, namespace string, spec saasv1alpha1.SystemSpec) (Generator, error) {
+// NewGenerator returns a new Options struct. The status of the Sentinel resources
+// referenced by the spec must be passed in the sentinels map.
+func NewGenerator(instance, namespace string, spec saasv1alpha1.SystemSpec,
+	sentinels map[types.NamespacedName]saasv1alpha1.SentinelStatus) (Generator, error) {
+
+	redis, err := config.NewRedisConfig(spec, namespace, sentinels)
+	if err != nil {
+		return Generator{}, err
+	}
 
 	generator := Generator{
 		BaseOptionsV2: generators.BaseOptionsV2{
@@ -76,63 +81,13 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.SystemSpec) (Gen
 					"threescale_component_element": app,
 				},
 			},
-			Spec:              *spec.App,
-			Options:           config.NewOptions(spec),
-			Image:             *spec.Image,
-			ConfigFilesSecret: *spec.Config.ConfigFilesSecret,
-			Traffic:           true,
-			TwemproxySpec:     spec.Twemproxy,
-		},
-		SidekiqDefault: SidekiqGenerator{
-			BaseOptionsV2: generators.BaseOptionsV2{
-				Component:    strings.Join([]string{component, sidekiqDefault}, "-"),
-				InstanceName: instance,
-				Namespace:    namespace,
-				Labels: map[string]string{
-					"app":                          "3scale-api-management",
-					"threescale_component":         component,
-					"threescale_component_element": sidekiqDefault,
-				},
-			},
-			Spec:              *spec.SidekiqDefault,
-			Options:           config.NewOptions(spec),
-			Image:             *spec.Image,
-			ConfigFilesSecret: *spec.Config.ConfigFilesSecret,
-			TwemproxySpec:     spec.Twemproxy,
-		},
-		SidekiqBilling: SidekiqGenerator{
-			BaseOptionsV2: generators.BaseOptionsV2{
-				Component:    strings.Join([]string{component, sidekiqBilling}, "-"),
-				InstanceName: instance,
-				Namespace:    namespace,
-				Labels: map[string]string{
-					"app":                          "3scale-api-management",
-					"threescale_component":         component,
-					"threescale_component_element": sidekiqBilling,
-				},
-			},
-			Spec:              *spec.SidekiqBilling,
-			Options:           config.NewOptions(spec),
-			Image:             *spec.Image,
-			ConfigFilesSecret: *spec.Config.ConfigFilesSecret,
-			TwemproxySpec:     spec.Twemproxy,
-		},
-		SidekiqLow: SidekiqGenerator{
-			BaseOptionsV2: generators.BaseOptionsV2{
-				Component:    strings.Join([]string{component, sidekiqLow}, "-"),
-				InstanceName: instance,
-				Namespace:    namespace,
-				Labels: map[string]string{
-					"app":                          "3scale-api-management",
-					"threescale_component":         component,
-					"threescale_component_element": sidekiqLow,
-				},
-			},
-			Spec:              *spec.SidekiqLow,
-			Options:           config.NewOptions(spec),
-			Image:             *spec.Image,
-			ConfigFilesSecret: *spec.Config.ConfigFilesSecret,
-			TwemproxySpec:     spec.Twemproxy,
+			Spec:                  *spec.App,
+			Options:               config.NewOptions(spec, redis),
+			RolloutOnSecretChange: spec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			Image:                 *spec.Image,
+			ConfigFilesSecret:     *spec.Config.ConfigFilesSecret,
+			Traffic:               true,
+			TwemproxySpec:         spec.Twemproxy,
 		},
 		Searchd: SearchdGenerator{
 			BaseOptionsV2: generators.BaseOptionsV2{
@@ -164,126 +119,76 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.SystemSpec) (Gen
 					"threescale_component_element": console,
 				},
 			},
-			Spec:              *spec.Console,
-			Options:           config.NewOptions(spec),
-			Image:             *spec.Console.Image,
-			ConfigFilesSecret: *spec.Config.ConfigFilesSecret,
-			Enabled:           *spec.Config.Rails.Console,
-			TwemproxySpec:     spec.Twemproxy,
+			Spec:                  *spec.Console,
+			Options:               config.NewOptions(spec, redis),
+			RolloutOnSecretChange: spec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			Image:                 *spec.Console.Image,
+			ConfigFilesSecret:     *spec.Config.ConfigFilesSecret,
+			Enabled:               *spec.Config.Rails.Console,
+			TwemproxySpec:         spec.Twemproxy,
 		},
-		GrafanaDashboardSpec: *spec.GrafanaDashboard,
-		Config:               spec.Config,
-		ConfigFilesSecret:    *spec.Config.ConfigFilesSecret,
-		Options:              config.NewOptions(spec),
-	}
-
-	if spec.App.Canary != nil {
-		canarySpec, err := spec.ResolveCanarySpec(spec.App.Canary)
-		if err != nil {
-			return Generator{}, err
-		}
-		generator.CanaryApp = &AppGenerator{
+		DatabaseMigration: DatabaseMigrationGenerator{
 			BaseOptionsV2: generators.BaseOptionsV2{
-				Component:    strings.Join([]string{component, app, "canary"}, "-"),
+				Component:    strings.Join([]string{component, dbMigrate}, "-"),
 				InstanceName: instance,
 				Namespace:    namespace,
 				Labels: map[string]string{
 					"app":                          "3scale-api-management",
 					"threescale_component":         component,
-					"threescale_component_element": app + "-canary",
+					"threescale_component_element": dbMigrate,
 				},
 			},
-			Spec:              *canarySpec.App,
-			Image:             *canarySpec.Image,
-			Options:           config.NewOptions(*canarySpec),
-			ConfigFilesSecret: *canarySpec.Config.ConfigFilesSecret,
-			Traffic:           spec.App.Canary.SendTraffic,
-			TwemproxySpec:     canarySpec.Twemproxy,
-		}
-		// Disable PDB and HPA for the canary Deployment
-		generator.CanaryApp.Spec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
-		generator.CanaryApp.Spec.PDB = &saasv1alpha1.PodDisruptionBudgetSpec{}
+			Spec:              spec.DatabaseMigration.DatabaseMigrationSpec,
+			SkipCanaries:      spec.DatabaseMigration.SkipsCanaries(),
+			Options:           config.NewOptions(spec, redis),
+			Image:             *spec.Image,
+			ConfigFilesSecret: *spec.Config.ConfigFilesSecret,
+		},
+		GrafanaDashboardSpec: *spec.GrafanaDashboard,
+		Config:               spec.Config,
+		ConfigFilesSecret:    *spec.Config.ConfigFilesSecret,
+		Options:              config.NewOptions(spec, redis),
 	}
 
-	if spec.SidekiqDefault.Canary != nil {
-		canarySpec, err := spec.ResolveCanarySpec(spec.SidekiqDefault.Canary)
+	if spec.App.Canary != nil {
+		canarySpec, err := spec.ResolveCanarySpec(spec.App.Canary)
 		if err != nil {
 			return Generator{}, err
 		}
-		generator.CanarySidekiqDefault = &SidekiqGenerator{
-			BaseOptionsV2: generators.BaseOptionsV2{
-				Component:    strings.Join([]string{component, sidekiqDefault, "canary"}, "-"),
-				InstanceName: instance,
-				Namespace:    namespace,
-				Labels: map[string]string{
-					"app":                          "3scale-api-management",
-					"threescale_component":         component,
-					"threescale_component_element": sidekiqDefault + "-canary",
-				},
-			},
-			Spec:              *canarySpec.SidekiqDefault,
-			Image:             *canarySpec.Image,
-			Options:           config.NewOptions(*canarySpec),
-			ConfigFilesSecret: *canarySpec.Config.ConfigFilesSecret,
-			TwemproxySpec:     canarySpec.Twemproxy,
-		}
-		// Disable PDB and HPA for the canary Deployment
-		generator.CanarySidekiqDefault.Spec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
-		generator.CanarySidekiqDefault.Spec.PDB = &saasv1alpha1.PodDisruptionBudgetSpec{}
-	}
-
-	if spec.SidekiqLow.Canary != nil {
-		canarySpec, err := spec.ResolveCanarySpec(spec.SidekiqLow.Canary)
+		canaryRedis, err := config.NewRedisConfig(*canarySpec, namespace, sentinels)
 		if err != nil {
 			return Generator{}, err
 		}
-		generator.CanarySidekiqLow = &SidekiqGenerator{
+		generator.CanaryApp = &AppGenerator{
 			BaseOptionsV2: generators.BaseOptionsV2{
-				Component:    strings.Join([]string{component, sidekiqLow, "canary"}, "-"),
+				Component:    strings.Join([]string{component, app, "canary"}, "-"),
 				InstanceName: instance,
 				Namespace:    namespace,
 				Labels: map[string]string{
 					"app":                          "3scale-api-management",
 					"threescale_component":         component,
-					"threescale_component_element": sidekiqLow + "-canary",
+					"threescale_component_element": app + "-canary",
 				},
 			},
-			Spec:              *canarySpec.SidekiqLow,
-			Image:             *canarySpec.Image,
-			Options:           config.NewOptions(*canarySpec),
-			ConfigFilesSecret: *canarySpec.Config.ConfigFilesSecret,
-			TwemproxySpec:     canarySpec.Twemproxy,
+			Spec:                  *canarySpec.App,
+			Image:                 *canarySpec.Image,
+			Options:               config.NewOptions(*canarySpec, canaryRedis),
+			RolloutOnSecretChange: canarySpec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			ConfigFilesSecret:     *canarySpec.Config.ConfigFilesSecret,
+			Traffic:               spec.App.Canary.SendTraffic,
+			TwemproxySpec:         canarySpec.Twemproxy,
 		}
 		// Disable PDB and HPA for the canary Deployment
-		generator.CanarySidekiqLow.Spec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
-		generator.CanarySidekiqLow.Spec.PDB = &saasv1alpha1.PodDisruptionBudgetSpec{}
+		generator.CanaryApp.Spec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
+		generator.CanaryApp.Spec.PDB = &saasv1alpha1.PodDisruptionBudgetSpec{}
 	}
 
-	if spec.SidekiqBilling.Canary != nil {
-		canarySpec, err := spec.ResolveCanarySpec(spec.SidekiqBilling.Canary)
+	for _, pool := range sidekiqPools(spec) {
+		sidekiqs, err := newSidekiqPoolGenerator(instance, namespace, pool, spec, redis, sentinels)
 		if err != nil {
 			return Generator{}, err
 		}
-		generator.CanarySidekiqBilling = &SidekiqGenerator{
-			BaseOptionsV2: generators.BaseOptionsV2{
-				Component:    strings.Join([]string{component, sidekiqBilling, "canary"}, "-"),
-				InstanceName: instance,
-				Namespace:    namespace,
-				Labels: map[string]string{
-					"app":                          "3scale-api-management",
-					"threescale_component":         component,
-					"threescale_component_element": sidekiqBilling + "-canary",
-				},
-			},
-			Spec:              *canarySpec.SidekiqBilling,
-			Image:             *canarySpec.Image,
-			Options:           config.NewOptions(*canarySpec),
-			ConfigFilesSecret: *canarySpec.Config.ConfigFilesSecret,
-			TwemproxySpec:     canarySpec.Twemproxy,
-		}
-		// Disable PDB and HPA for the canary Deployment
-		generator.CanarySidekiqBilling.Spec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
-		generator.CanarySidekiqBilling.Spec.PDB = &saasv1alpha1.PodDisruptionBudgetSpec{}
+		generator.Sidekiqs = append(generator.Sidekiqs, sidekiqs)
 	}
 
 	for _, task := range spec.Tasks {
@@ -301,7 +206,7 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.SystemSpec) (Gen
 				},
 				Spec:              task,
 				Image:             *task.Config.Image,
-				Options:           config.NewOptions(spec),
+				Options:           config.NewOptions(spec, redis),
 				ConfigFilesSecret: *spec.Config.ConfigFilesSecret,
 				TwemproxySpec:     spec.Twemproxy,
 				Enabled:           *task.Enabled,
@@ -311,23 +216,73 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.SystemSpec) (Gen
 	return generator, nil
 }
 
+// Workloads returns the list of Deployment based workloads
+func (gen *Generator) Workloads() []deployment_workload.WithCanary {
+	workloads := []deployment_workload.WithCanary{
+		{Main: &gen.App, Canary: gen.CanaryApp, Spec: gen.App.Spec.Canary, SpecPath: "/spec/app/canary"},
+	}
+	for i := range gen.Sidekiqs {
+		pool := &gen.Sidekiqs[i]
+		workloads = append(workloads, deployment_workload.WithCanary{
+			Main: &pool.Main, Canary: pool.Canary, Spec: pool.Main.Spec.Canary,
+			SpecPath: pool.SpecPath + "/canary", SpecTests: pool.SpecTests,
+		})
+	}
+	return workloads
+}
+
+// DatabaseMigrations returns the gates that hold the rollout of the app and sidekiq
+// Deployments to a new image until the database migrations have run with it. The
+// database is considered to be migrated to the image that the live system-app
+// Deployment runs. Canaries get their own gate unless they skip the migrations, in
+// which case they are rolled out straight away.
+func (gen *Generator) DatabaseMigrations() []migration_workload.Gate {
+	app := gen.App.GetKey()
+
+	main := migration_workload.Gate{
+		Workload:   &gen.DatabaseMigration,
+		Deployment: app,
+		Images:     []*saasv1alpha1.ImageSpec{&gen.App.Image},
+	}
+	for i := range gen.Sidekiqs {
+		main.Images = append(main.Images, &gen.Sidekiqs[i].Main.Image)
+	}
+	gates := []migration_workload.Gate{main}
+
+	if gen.CanaryApp != nil && !gen.DatabaseMigration.SkipCanaries {
+		gates = append(gates, migration_workload.Gate{
+			Workload:   &gen.DatabaseMigration,
+			Deployment: app,
+			Images:     []*saasv1alpha1.ImageSpec{&gen.CanaryApp.Image},
+		})
+	}
+	for i := range gen.Sidekiqs {
+		pool := &gen.Sidekiqs[i]
+		if pool.Canary != nil && !gen.DatabaseMigration.SkipCanaries {
+			gates = append(gates, migration_workload.Gate{
+				Workload:   &gen.DatabaseMigration,
+				Deployment: app,
+				Images:     []*saasv1alpha1.ImageSpec{&pool.Canary.Image},
+			})
+		}
+	}
+
+	return gates
+}
+
 // Resources returns the list of resource templates
 func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 	app_resources, err := deployment_workload.New(&gen.App, gen.CanaryApp)
 	if err != nil {
 		return nil, err
 	}
-	sidekiq_default_resources, err := deployment_workload.New(&gen.SidekiqDefault, gen.CanarySidekiqDefault)
-	if err != nil {
-		return nil, err
-	}
-	sidekiq_billing_resources, err := deployment_workload.New(&gen.SidekiqBilling, gen.CanarySidekiqBilling)
-	if err != nil {
-		return nil, err
-	}
-	sidekiq_low_resources, err := deployment_workload.New(&gen.SidekiqLow, gen.CanarySidekiqLow)
-	if err != nil {
-		return nil, err
+	sidekiq_resources := []resource.TemplateInterface{}
+	for i := range gen.Sidekiqs {
+		resources, err := deployment_workload.New(&gen.Sidekiqs[i].Main, gen.Sidekiqs[i].Canary)
+		if err != nil {
+			return nil, err
+		}
+		sidekiq_resources = append(sidekiq_resources, resources...)
 	}
 
 	misc := []resource.TemplateInterface{
@@ -343,7 +298,7 @@ func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 					*gen.Config.ExternalSecret.SecretStoreRef.Name, *gen.Config.ExternalSecret.SecretStoreRef.Kind,
 					*gen.Config.ExternalSecret.RefreshInterval, gen.GetLabels(), gen.Options,
 				),
-			),
+			).WithEnabled(pod.HasExternalSecretData(es, gen.Options)),
 		)
 	}
 	for _, tr := range gen.Tekton {
@@ -359,11 +314,9 @@ func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 
 	return operatorutil.ConcatSlices(
 			app_resources,
-			sidekiq_default_resources,
-			sidekiq_billing_resources,
-			sidekiq_low_resources,
+			sidekiq_resources,
 			gen.Searchd.StatefulSetWithTraffic(),
-			gen.Console.StatefulSet(),
+			statefulset_workload.New(&gen.Console),
 			misc,
 		),
 		nil
@@ -383,27 +336,16 @@ func getSystemSecrets() []string {
 	}
 }
 
-func getSystemSecretsRolloutTriggers(additionalSecrets ...string) []resource.TemplateMutationFunction {
-	secrets := append(getSystemSecrets(), additionalSecrets...)
-	triggers := make([]resource.TemplateMutationFunction, 0, len(secrets))
-	for _, secret := range secrets {
-		triggers = append(
-			triggers,
-			mutators.RolloutTrigger{Name: secret, SecretName: util.Pointer(secret)}.Add(),
-		)
-	}
-	return triggers
-}
-
 // AppGenerator has methods to generate resources for system-app
 type AppGenerator struct {
 	generators.BaseOptionsV2
-	Spec              saasv1alpha1.SystemAppSpec
-	Options           config.Options
-	Image             saasv1alpha1.ImageSpec
-	ConfigFilesSecret string
-	Traffic           bool
-	TwemproxySpec     *saasv1alpha1.TwemproxySpec
+	Spec                  saasv1alpha1.SystemAppSpec
+	Options               config.Options
+	RolloutOnSecretChange bool
+	Image                 saasv1alpha1.ImageSpec
+	ConfigFilesSecret     string
+	Traffic               bool
+	TwemproxySpec         *saasv1alpha1.TwemproxySpec
 }
 
 // Validate that AppGenerator implements deployment_workload.DeploymentWorkload interface
@@ -412,6 +354,9 @@ var _ deployment_workload.DeploymentWorkload = &AppGenerator{}
 // Validate that AppGenerator implements deployment_workload.WithTraffic interface
 var _ deployment_workload.WithTraffic = &AppGenerator{}
 
+// Validate that AppGenerator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &AppGenerator{}
+
 func (gen *AppGenerator) Services() []*resource.Template[*corev1.Service] {
 	return []*resource.Template[*corev1.Service]{
 		resource.NewTemplateFromObjectFunction(gen.service).
@@ -427,10 +372,15 @@ func (gen *AppGenerator) TrafficSelector() map[string]string {
 
 func (gen *AppGenerator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return resource.NewTemplateFromObjectFunction(gen.deployment).
-		WithMutations(getSystemSecretsRolloutTriggers(gen.ConfigFilesSecret)).
+		WithMutations(pod.SecretRolloutTriggers(gen.RolloutOnSecretChange, gen.Options, gen.Spec.EnvironmentSpec)).
+		WithMutation(pod.SecretRolloutTrigger(gen.ConfigFilesSecret)).
 		WithMutation(mutators.SetDeploymentReplicas(gen.Spec.HPA.IsDeactivated()))
 }
 
+func (gen *AppGenerator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.Spec.DeploymentStrategy
+}
+
 func (gen *AppGenerator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
 	return gen.Spec.HPA
 }
@@ -452,26 +402,42 @@ func (gen *AppGenerator) MonitoredEndpoints() []monitoringv1.PodMetricsEndpoint
 // Validate that SidekiqGenerator implements deployment_workload.DeploymentWorkloadWithTraffic interface
 var _ deployment_workload.DeploymentWorkload = &SidekiqGenerator{}
 
+// Validate that SidekiqGenerator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &SidekiqGenerator{}
+
+// Validate that SidekiqGenerator implements deployment_workload.WithSuggestedMetrics interface
+var _ deployment_workload.WithSuggestedMetrics = &SidekiqGenerator{}
+
 // SidekiqGenerator has methods to generate resources for system-sidekiq
 type SidekiqGenerator struct {
 	generators.BaseOptionsV2
-	Spec              saasv1alpha1.SystemSidekiqSpec
-	Options           config.Options
-	Image             saasv1alpha1.ImageSpec
-	ConfigFilesSecret string
-	TwemproxySpec     *saasv1alpha1.TwemproxySpec
+	Spec                  saasv1alpha1.SystemSidekiqSpec
+	Options               config.Options
+	RolloutOnSecretChange bool
+	Image                 saasv1alpha1.ImageSpec
+	ConfigFilesSecret     string
+	TwemproxySpec         *saasv1alpha1.TwemproxySpec
 }
 
 func (gen *SidekiqGenerator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return resource.NewTemplateFromObjectFunction(gen.deployment).
-		WithMutations(getSystemSecretsRolloutTriggers(gen.ConfigFilesSecret)).
+		WithMutations(pod.SecretRolloutTriggers(gen.RolloutOnSecretChange, gen.Options, gen.Spec.EnvironmentSpec)).
+		WithMutation(pod.SecretRolloutTrigger(gen.ConfigFilesSecret)).
 		WithMutation(mutators.SetDeploymentReplicas(gen.Spec.HPA.IsDeactivated()))
 }
 
+func (gen *SidekiqGenerator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.Spec.DeploymentStrategy
+}
+
 func (gen *SidekiqGenerator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
 	return gen.Spec.HPA
 }
 
+func (gen *SidekiqGenerator) SuggestedMetrics() []autoscalingv2.MetricSpec {
+	return gen.Spec.SuggestedMetrics()
+}
+
 func (gen *SidekiqGenerator) PDBSpec() *saasv1alpha1.PodDisruptionBudgetSpec {
 	return gen.Spec.PDB
 }
@@ -486,6 +452,135 @@ func (gen *SidekiqGenerator) MonitoredEndpoints() []monitoringv1.PodMetricsEndpo
 	return pmes
 }
 
+// SidekiqPoolGenerator holds the generators for the main
+// and the canary Deployments of a Sidekiq pool
+type SidekiqPoolGenerator struct {
+	Main      SidekiqGenerator
+	Canary    *SidekiqGenerator
+	SpecPath  string
+	SpecTests []deployment_workload.SpecTest
+}
+
+// sidekiqPool identifies a Sidekiq pool by its name and the
+// JSON pointer to its spec within the System resource
+type sidekiqPool struct {
+	name      string
+	specPath  string
+	specTests []deployment_workload.SpecTest
+}
+
+// sidekiqPools returns the list of Sidekiq pools of the given spec: the
+// sidekiqDefault, sidekiqBilling and sidekiqLow ones first and then the
+// named pools in sidekiqPools, in the order they are declared
+func sidekiqPools(spec saasv1alpha1.SystemSpec) []sidekiqPool {
+	pools := []sidekiqPool{
+		{name: string(saasv1alpha1.Default), specPath: "/spec/sidekiqDefault"},
+		{name: string(saasv1alpha1.Billing), specPath: "/spec/sidekiqBilling"},
+		{name: string(saasv1alpha1.Low), specPath: "/spec/sidekiqLow"},
+	}
+	for i, pool := range spec.SidekiqPools {
+		specPath := fmt.Sprintf("/spec/sidekiqPools/%d", i)
+		pools = append(pools, sidekiqPool{
+			name:     pool.Name,
+			specPath: specPath,
+			// the pools are addressed by their index, so check that
+			// the index still points to the same pool
+			specTests: []deployment_workload.SpecTest{{Path: specPath + "/name", Value: pool.Name}},
+		})
+	}
+	return pools
+}
+
+func newSidekiqPoolGenerator(instance, namespace string, pool sidekiqPool, spec saasv1alpha1.SystemSpec,
+	redis config.RedisConfig, sentinels map[types.NamespacedName]saasv1alpha1.SentinelStatus) (SidekiqPoolGenerator, error) {
+
+	element := strings.Join([]string{sidekiq, pool.name}, "-")
+	poolSpec := spec.SidekiqPool(pool.name)
+
+	generator := SidekiqPoolGenerator{
+		Main: SidekiqGenerator{
+			BaseOptionsV2: generators.BaseOptionsV2{
+				Component:    strings.Join([]string{component, element}, "-"),
+				InstanceName: instance,
+				Namespace:    namespace,
+				Labels: map[string]string{
+					"app":                          "3scale-api-management",
+					"threescale_component":         component,
+					"threescale_component_element": element,
+				},
+			},
+			Spec:                  *poolSpec,
+			Options:               config.NewOptions(spec, redis),
+			RolloutOnSecretChange: spec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			Image:                 *spec.Image,
+			ConfigFilesSecret:     *spec.Config.ConfigFilesSecret,
+			TwemproxySpec:         spec.Twemproxy,
+		},
+		SpecPath:  pool.specPath,
+		SpecTests: pool.specTests,
+	}
+
+	if poolSpec.Canary != nil {
+		canarySpec, err := spec.ResolveCanarySpec(poolSpec.Canary)
+		if err != nil {
+			return SidekiqPoolGenerator{}, err
+		}
+		canaryPoolSpec := canarySpec.SidekiqPool(pool.name)
+		if canaryPoolSpec == nil {
+			return SidekiqPoolGenerator{}, fmt.Errorf("canary of sidekiq pool '%s' removes the pool", pool.name)
+		}
+		canaryRedis, err := config.NewRedisConfig(*canarySpec, namespace, sentinels)
+		if err != nil {
+			return SidekiqPoolGenerator{}, err
+		}
+		generator.Canary = &SidekiqGenerator{
+			BaseOptionsV2: generators.BaseOptionsV2{
+				Component:    strings.Join([]string{component, element, "canary"}, "-"),
+				InstanceName: instance,
+				Namespace:    namespace,
+				Labels: map[string]string{
+					"app":                          "3scale-api-management",
+					"threescale_component":         component,
+					"threescale_component_element": element + "-canary",
+				},
+			},
+			Spec:                  *canaryPoolSpec,
+			Image:                 *canarySpec.Image,
+			Options:               config.NewOptions(*canarySpec, canaryRedis),
+			RolloutOnSecretChange: canarySpec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			ConfigFilesSecret:     *canarySpec.Config.ConfigFilesSecret,
+			TwemproxySpec:         canarySpec.Twemproxy,
+		}
+		// Disable PDB and HPA for the canary Deployment
+		generator.Canary.Spec.HPA = &saasv1alpha1.HorizontalPodAutoscalerSpec{}
+		generator.Canary.Spec.PDB = &saasv1alpha1.PodDisruptionBudgetSpec{}
+	}
+
+	return generator, nil
+}
+
+// DatabaseMigrationGenerator has methods to generate the Job that
+// runs the database migrations of system
+type DatabaseMigrationGenerator struct {
+	generators.BaseOptionsV2
+	Spec              saasv1alpha1.DatabaseMigrationSpec
+	SkipCanaries      bool
+	Options           config.Options
+	Image             saasv1alpha1.ImageSpec
+	ConfigFilesSecret string
+}
+
+// Validate that DatabaseMigrationGenerator implements migration_workload.MigrationWorkload interface
+var _ migration_workload.MigrationWorkload = &DatabaseMigrationGenerator{}
+
+func (gen *DatabaseMigrationGenerator) DatabaseMigrationSpec() *saasv1alpha1.DatabaseMigrationSpec {
+	return &gen.Spec
+}
+
+func (gen *DatabaseMigrationGenerator) Job(image string) *batchv1.Job {
+	return gen.job(image)
+}
+
 // SearchdGenerator has methods to generate resources for system-Searchd
 type SearchdGenerator struct {
 	generators.BaseOptionsV2
@@ -498,30 +593,45 @@ type SearchdGenerator struct {
 	Enabled              bool
 }
 
+// Validate that SearchdGenerator implements statefulset_workload.StatefulSetWorkload interface
+var _ statefulset_workload.StatefulSetWorkload = &SearchdGenerator{}
+
+func (gen *SearchdGenerator) StatefulSet() *resource.Template[*appsv1.StatefulSet] {
+	return resource.NewTemplateFromObjectFunction[*appsv1.StatefulSet](gen.statefulset).WithEnabled(gen.Enabled)
+}
+func (gen *SearchdGenerator) RolloutSpec() *saasv1alpha1.StatefulSetRolloutSpec {
+	return gen.Spec.Rollout
+}
+
 func (gen *SearchdGenerator) StatefulSetWithTraffic() []resource.TemplateInterface {
-	return []resource.TemplateInterface{
-		resource.NewTemplateFromObjectFunction[*appsv1.StatefulSet](gen.statefulset).WithEnabled(gen.Enabled),
+	return append(statefulset_workload.New(gen),
 		resource.NewTemplateFromObjectFunction[*corev1.Service](gen.service).WithEnabled(gen.Enabled).WithMutation(mutators.SetServiceLiveValues()),
-	}
+	)
 }
 
 // ConsoleGenerator has methods to generate resources for system-console
 type ConsoleGenerator struct {
 	generators.BaseOptionsV2
-	Spec              saasv1alpha1.SystemRailsConsoleSpec
-	Options           config.Options
-	Image             saasv1alpha1.ImageSpec
-	ConfigFilesSecret string
-	Enabled           bool
-	TwemproxySpec     *saasv1alpha1.TwemproxySpec
+	Spec                  saasv1alpha1.SystemRailsConsoleSpec
+	Options               config.Options
+	RolloutOnSecretChange bool
+	Image                 saasv1alpha1.ImageSpec
+	ConfigFilesSecret     string
+	Enabled               bool
+	TwemproxySpec         *saasv1alpha1.TwemproxySpec
 }
 
-func (gen *ConsoleGenerator) StatefulSet() []resource.TemplateInterface {
-	return []resource.TemplateInterface{
-		resource.NewTemplateFromObjectFunction(gen.statefulset).
-			WithEnabled(gen.Enabled).
-			WithMutations(getSystemSecretsRolloutTriggers(gen.ConfigFilesSecret)),
-	}
+// Validate that ConsoleGenerator implements statefulset_workload.StatefulSetWorkload interface
+var _ statefulset_workload.StatefulSetWorkload = &ConsoleGenerator{}
+
+func (gen *ConsoleGenerator) StatefulSet() *resource.Template[*appsv1.StatefulSet] {
+	return resource.NewTemplateFromObjectFunction(gen.statefulset).
+		WithEnabled(gen.Enabled).
+		WithMutations(pod.SecretRolloutTriggers(gen.RolloutOnSecretChange, gen.Options, gen.Spec.EnvironmentSpec)).
+		WithMutation(pod.SecretRolloutTrigger(gen.ConfigFilesSecret))
+}
+func (gen *ConsoleGenerator) RolloutSpec() *saasv1alpha1.StatefulSetRolloutSpec {
+	return gen.Spec.Rollout
 }
 
 // SystemTektonGenerator has methods to generate resources for system tekton tasks
@@ -0,0 +1,94 @@
+package system
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	deployment_workload "github.com/3scale-ops/saas-operator/pkg/workloads/deployment"
+	"github.com/go-test/deep"
+)
+
+func TestGenerator_Workloads(t *testing.T) {
+	type workload struct {
+		Name      string
+		Element   string
+		Canary    string
+		SpecPath  string
+		SpecTests []deployment_workload.SpecTest
+	}
+	tests := []struct {
+		name  string
+		pools []saasv1alpha1.SystemSidekiqPoolSpec
+		want  []workload
+	}{
+		{
+			name:  "Keeps the names of the sidekiqDefault, sidekiqBilling and sidekiqLow Deployments",
+			pools: nil,
+			want: []workload{
+				{Name: "system-app", Element: "app", SpecPath: "/spec/app/canary"},
+				{Name: "system-sidekiq-default", Element: "sidekiq-default", SpecPath: "/spec/sidekiqDefault/canary"},
+				{Name: "system-sidekiq-billing", Element: "sidekiq-billing", SpecPath: "/spec/sidekiqBilling/canary"},
+				{Name: "system-sidekiq-low", Element: "sidekiq-low", SpecPath: "/spec/sidekiqLow/canary"},
+			},
+		},
+		{
+			name: "Generates a Deployment for each named pool",
+			pools: []saasv1alpha1.SystemSidekiqPoolSpec{
+				{Name: "mailers"},
+				{Name: "webhooks", SystemSidekiqSpec: saasv1alpha1.SystemSidekiqSpec{
+					Canary: &saasv1alpha1.Canary{ImageTag: util.Pointer("canary")},
+				}},
+			},
+			want: []workload{
+				{Name: "system-app", Element: "app", SpecPath: "/spec/app/canary"},
+				{Name: "system-sidekiq-default", Element: "sidekiq-default", SpecPath: "/spec/sidekiqDefault/canary"},
+				{Name: "system-sidekiq-billing", Element: "sidekiq-billing", SpecPath: "/spec/sidekiqBilling/canary"},
+				{Name: "system-sidekiq-low", Element: "sidekiq-low", SpecPath: "/spec/sidekiqLow/canary"},
+				{
+					Name: "system-sidekiq-mailers", Element: "sidekiq-mailers", SpecPath: "/spec/sidekiqPools/0/canary",
+					SpecTests: []deployment_workload.SpecTest{{Path: "/spec/sidekiqPools/0/name", Value: "mailers"}},
+				},
+				{
+					Name: "system-sidekiq-webhooks", Element: "sidekiq-webhooks", Canary: "system-sidekiq-webhooks-canary",
+					SpecPath:  "/spec/sidekiqPools/1/canary",
+					SpecTests: []deployment_workload.SpecTest{{Path: "/spec/sidekiqPools/1/name", Value: "webhooks"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := saasv1alpha1.SystemSpec{
+				Config:       saasv1alpha1.SystemConfig{Zync: &saasv1alpha1.SystemZyncSpec{}},
+				SidekiqPools: tt.pools,
+			}
+			spec.Default()
+			gen, err := NewGenerator("test", "ns", spec, nil)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+
+			got := []workload{}
+			for _, w := range gen.Workloads() {
+				wl := workload{
+					Name:      w.Main.GetKey().Name,
+					Element:   w.Main.GetLabels()["threescale_component_element"],
+					SpecPath:  w.SpecPath,
+					SpecTests: w.SpecTests,
+				}
+				if w.Canary != nil && !reflect.ValueOf(w.Canary).IsNil() {
+					wl.Canary = w.Canary.GetKey().Name
+					if element := w.Canary.GetLabels()["threescale_component_element"]; element != wl.Element+"-canary" {
+						t.Errorf("canary of %s has element label %q", wl.Name, element)
+					}
+				}
+				got = append(got, wl)
+			}
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Errorf("Generator.Workloads() = diff %v", diff)
+			}
+		})
+	}
+}
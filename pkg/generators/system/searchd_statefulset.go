@@ -20,11 +20,8 @@ func (gen *SearchdGenerator) statefulset() *appsv1.StatefulSet {
 			Labels:    gen.GetLabels(),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: util.Pointer[int32](1),
-			Selector: &metav1.LabelSelector{MatchLabels: gen.GetSelector()},
-			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: appsv1.RollingUpdateStatefulSetStrategyType,
-			},
+			Replicas:            util.Pointer[int32](1),
+			Selector:            &metav1.LabelSelector{MatchLabels: gen.GetSelector()},
 			PodManagementPolicy: appsv1.OrderedReadyPodManagement,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -16,7 +16,7 @@ func (gen *SidekiqGenerator) deployment() *appsv1.Deployment {
 	dep := &appsv1.Deployment{
 		Spec: appsv1.DeploymentSpec{
 			Replicas: gen.Spec.Replicas,
-			Strategy: appsv1.DeploymentStrategy(*gen.Spec.DeploymentStrategy),
+			Strategy: gen.Spec.DeploymentStrategy.DeploymentStrategy(),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
 					ImagePullSecrets: func() []corev1.LocalObjectReference {
@@ -44,7 +44,7 @@ func (gen *SidekiqGenerator) deployment() *appsv1.Deployment {
 										Value: fmt.Sprintf("%d", *gen.Spec.Config.MaxThreads),
 									},
 								)
-								return envVars
+								return pod.MergeEnvironment(envVars, gen.Spec.EnvironmentSpec)
 							}(),
 							Ports: pod.ContainerPorts(
 								pod.ContainerPortTCP("metrics", 9394),
@@ -75,6 +75,8 @@ func (gen *SidekiqGenerator) deployment() *appsv1.Deployment {
 		},
 	}
 
+	dep.Spec.Template = pod.AddSecretFiles(dep.Spec.Template, gen.Options)
+
 	dep.Spec.Template.Spec.Volumes = append(dep.Spec.Template.Spec.Volumes,
 		corev1.Volume{
 			Name: "system-config",
@@ -94,6 +94,11 @@ func (gen *SystemTektonGenerator) task() *pipelinev1beta1.Task {
 		},
 	}
 
+	if volume := pod.SecretFilesVolume(gen.Options); volume != nil {
+		task.Spec.Volumes = append(task.Spec.Volumes, *volume)
+		task.Spec.Steps[0].VolumeMounts = append(task.Spec.Steps[0].VolumeMounts, pod.SecretFilesVolumeMount())
+	}
+
 	if gen.TwemproxySpec != nil {
 		task.Spec = twemproxy.AddTwemproxyTaskSidecar(task.Spec, gen.TwemproxySpec)
 	}
@@ -11,19 +11,17 @@ import (
 )
 
 const (
-	HealthPoolName    string = "health"
-	HealthBindAddress string = "127.0.0.1:22333"
+	HealthPoolName      string = "health"
+	HealthBindAddress   string = "127.0.0.1:22333"
+	twemproxyConfigFile string = "nutcracker.yml"
 )
 
 // configMap returns a ConfigMap that holds the twemproxy config file.
 func (gen *Generator) configMap(toYAML bool) *corev1.ConfigMap {
-	config := make(map[string]twemproxy.ServerPoolConfig, len(gen.Spec.ServerPools)+1)
-	for _, pool := range gen.Spec.ServerPools {
-		if *pool.Target == saasv1alpha1.Masters {
-			config[pool.Name] = twemproxy.GenerateServerPool(pool, gen.masterTargets)
-		} else {
-			config[pool.Name] = twemproxy.GenerateServerPool(pool, gen.slaverwTargets)
-		}
+	pools := gen.ServerPools()
+	config := make(map[string]twemproxy.ServerPoolConfig, len(pools)+1)
+	for _, pool := range pools {
+		config[pool.Name] = twemproxy.GenerateServerPool(pool, gen.targets(pool))
 	}
 
 	config[HealthPoolName] = twemproxy.ServerPoolConfig{
@@ -49,14 +47,24 @@ func (gen *Generator) configMap(toYAML bool) *corev1.ConfigMap {
 		panic(err)
 	}
 
+	// Store the server pools used to generate the config so
+	// they can be used to compute previews of future changes
+	serverPools, err := json.Marshal(pools)
+	if err != nil {
+		panic(err)
+	}
+
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      gen.GetInstanceName(),
 			Namespace: gen.GetNamespace(),
 			Labels:    gen.GetLabels(),
+			Annotations: map[string]string{
+				saasv1alpha1.TwemproxyServerPoolsAnnotationKey: string(serverPools),
+			},
 		},
 		Data: map[string]string{
-			"nutcracker.yml": string(b),
+			twemproxyConfigFile: string(b),
 		},
 	}
 }
@@ -37,7 +37,6 @@ func TestGenerator_configMap(t *testing.T) {
 					Labels:       map[string]string{},
 				},
 				Spec: saasv1alpha1.TwemproxyConfigSpec{
-					SentinelURIs: []string{"sentinel.example.com"},
 					ServerPools: []saasv1alpha1.TwemproxyServerPool{
 						{
 							Name:   "pool1",
@@ -79,6 +78,9 @@ func TestGenerator_configMap(t *testing.T) {
 					Name:      "test",
 					Namespace: "ns",
 					Labels:    map[string]string{},
+					Annotations: map[string]string{
+						saasv1alpha1.TwemproxyServerPoolsAnnotationKey: `[{"name":"pool1","topology":[{"shardName":"lshard01","physicalShard":"pshard01"},{"shardName":"lshard02","physicalShard":"pshard01"},{"shardName":"lshard03","physicalShard":"pshard01"},{"shardName":"lshard04","physicalShard":"pshard02"}],"bindAddress":"localhost:2000","timeout":1000,"tcpBacklog":500,"preConnect":false,"target":"masters"},{"name":"pool2","topology":[{"shardName":"lshard01","physicalShard":"pshard01"},{"shardName":"lshard02","physicalShard":"pshard02"}],"bindAddress":"localhost:3000","timeout":1000,"tcpBacklog":500,"preConnect":false,"target":"masters"}]`,
+					},
 				},
 				Data: map[string]string{
 					"nutcracker.yml": `{"health":{"listen":"127.0.0.1:22333","preconnect":false,"redis":true,"auto_eject_hosts":false,"servers":["127.0.0.1:6379:1 dummy"]},"pool1":{"listen":"localhost:2000","hash":"fnv1a_64","hash_tag":"{}","distribution":"ketama","timeout":1000,"backlog":500,"preconnect":false,"redis":true,"auto_eject_hosts":false,"servers":["127.0.0.1:6379:1 lshard01","127.0.0.1:6379:1 lshard02","127.0.0.1:6379:1 lshard03","127.0.0.2:6379:1 lshard04"]},"pool2":{"listen":"localhost:3000","hash":"fnv1a_64","hash_tag":"{}","distribution":"ketama","timeout":1000,"backlog":500,"preconnect":false,"redis":true,"auto_eject_hosts":false,"servers":["127.0.0.1:6379:1 lshard01","127.0.0.2:6379:1 lshard02"]}}`,
@@ -95,7 +97,6 @@ func TestGenerator_configMap(t *testing.T) {
 					Labels:       map[string]string{},
 				},
 				Spec: saasv1alpha1.TwemproxyConfigSpec{
-					SentinelURIs: []string{"sentinel.example.com"},
 					ServerPools: []saasv1alpha1.TwemproxyServerPool{
 						{
 							Name:   "pool1",
@@ -128,6 +129,9 @@ func TestGenerator_configMap(t *testing.T) {
 					Name:      "test",
 					Namespace: "ns",
 					Labels:    map[string]string{},
+					Annotations: map[string]string{
+						saasv1alpha1.TwemproxyServerPoolsAnnotationKey: `[{"name":"pool1","topology":[{"shardName":"lshard01","physicalShard":"pshard01"},{"shardName":"lshard02","physicalShard":"pshard01"},{"shardName":"lshard03","physicalShard":"pshard01"},{"shardName":"lshard04","physicalShard":"pshard02"}],"bindAddress":"localhost:2000","timeout":1000,"tcpBacklog":500,"preConnect":false,"target":"slaves-rw"}]`,
+					},
 				},
 				Data: map[string]string{
 					"nutcracker.yml": `{"health":{"listen":"127.0.0.1:22333","preconnect":false,"redis":true,"auto_eject_hosts":false,"servers":["127.0.0.1:6379:1 dummy"]},"pool1":{"listen":"localhost:2000","hash":"fnv1a_64","hash_tag":"{}","distribution":"ketama","timeout":1000,"backlog":500,"preconnect":false,"redis":true,"auto_eject_hosts":false,"servers":["127.0.0.3:6379:1 lshard01","127.0.0.3:6379:1 lshard02","127.0.0.3:6379:1 lshard03","127.0.0.4:6379:1 lshard04"]}}`,
@@ -0,0 +1,112 @@
+package twemproxyconfig
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/resource"
+	"github.com/3scale-ops/basereconciler/util"
+	marin3rv1alpha1 "github.com/3scale-ops/marin3r/apis/marin3r/v1alpha1"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig"
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/factory"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/twemproxy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EnvoyConfig returns the marin3r EnvoyConfig that configures Envoy
+// as a redis proxy for the server pools
+func (gen *Generator) EnvoyConfig() *resource.Template[*marin3rv1alpha1.EnvoyConfig] {
+	return resource.NewTemplate(func(client.Object) (*marin3rv1alpha1.EnvoyConfig, error) {
+		return gen.envoyConfig()
+	}).WithEnabled(gen.Spec.EnvoyRedisProxy != nil)
+}
+
+func (gen *Generator) envoyConfig() (*marin3rv1alpha1.EnvoyConfig, error) {
+	key := types.NamespacedName{Name: gen.GetInstanceName(), Namespace: gen.GetNamespace()}
+
+	// the template is also built when disabled, so the
+	// resource can be deleted if it exists
+	if gen.Spec.EnvoyRedisProxy == nil {
+		return &marin3rv1alpha1.EnvoyConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		}, nil
+	}
+
+	resources, err := gen.envoyDynamicConfigurations()
+	if err != nil {
+		return nil, err
+	}
+
+	ec, err := envoyconfig.New(key, gen.Spec.EnvoyRedisProxy.NodeID, factory.Default(), resources...)(nil)
+	if err != nil {
+		return nil, err
+	}
+	ec.SetLabels(gen.GetLabels())
+
+	return ec, nil
+}
+
+// envoyDynamicConfigurations returns a redis_proxy listener and a cluster
+// for each server pool. Both the listener and the cluster are named
+// after the server pool.
+func (gen *Generator) envoyDynamicConfigurations() ([]descriptor.EnvoyDynamicConfigDescriptor, error) {
+	pools := gen.ServerPools()
+	list := make([]descriptor.EnvoyDynamicConfigDescriptor, 0, 2*len(pools))
+
+	for _, pool := range pools {
+		host, port, err := splitHostPort(pool.BindAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bind address for server pool %s: '%s'", pool.Name, err.Error())
+		}
+
+		listener := &saasv1alpha1.EnvoyRedisProxyDynamicConfig{
+			GeneratorVersion: util.Pointer("v1"),
+			ListenerRedisProxy: &saasv1alpha1.ListenerRedisProxy{
+				Address:     util.Pointer(host),
+				Port:        port,
+				ClusterName: pool.Name,
+				OpTimeout:   metav1.Duration{Duration: time.Duration(pool.Timeout) * time.Millisecond},
+			},
+		}
+
+		servers := twemproxy.GenerateServerPool(pool, gen.targets(pool)).Servers
+		shards := make([]saasv1alpha1.RedisShardEndpoint, 0, len(servers))
+		for _, srv := range servers {
+			host, port, err := splitHostPort(srv.Address)
+			if err != nil {
+				return nil, fmt.Errorf("invalid target for shard %s of server pool %s: '%s'", srv.Name, pool.Name, err.Error())
+			}
+			shards = append(shards, saasv1alpha1.RedisShardEndpoint{Name: srv.Name, Host: host, Port: port})
+		}
+
+		cluster := &saasv1alpha1.EnvoyRedisProxyDynamicConfig{
+			GeneratorVersion:    util.Pointer("v1"),
+			ClusterShardedRedis: &saasv1alpha1.ClusterShardedRedis{Shards: shards},
+		}
+
+		list = append(list,
+			listener.AsEnvoyDynamicConfigDescriptor(pool.Name),
+			cluster.AsEnvoyDynamicConfigDescriptor(pool.Name),
+		)
+	}
+
+	return list, nil
+}
+
+func splitHostPort(address string) (string, uint32, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, err
+	}
+	p, err := strconv.ParseUint(port, 10, 32)
+	if err != nil {
+		return "", 0, err
+	}
+	return host, uint32(p), nil
+}
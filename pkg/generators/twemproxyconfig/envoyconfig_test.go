@@ -0,0 +1,181 @@
+package twemproxyconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/3scale-ops/saas-operator/pkg/generators"
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/twemproxy"
+	"github.com/go-test/deep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testEnvoyGenerator(envoy *saasv1alpha1.EnvoyRedisProxySpec, targets map[string]twemproxy.Server) Generator {
+	return Generator{
+		BaseOptionsV2: generators.BaseOptionsV2{
+			Component:    "twemproxy",
+			InstanceName: "test",
+			Namespace:    "ns",
+			Labels:       map[string]string{"app": "twemproxy"},
+		},
+		Spec: saasv1alpha1.TwemproxyConfigSpec{
+			ServerPools: []saasv1alpha1.TwemproxyServerPool{{
+				Name:   "pool1",
+				Target: util.Pointer(saasv1alpha1.Masters),
+				Topology: []saasv1alpha1.ShardedRedisTopology{
+					{ShardName: "lshard01", PhysicalShard: "pshard01"},
+					{ShardName: "lshard02", PhysicalShard: "pshard01"},
+					{ShardName: "lshard03", PhysicalShard: "pshard02"},
+				},
+				BindAddress: "0.0.0.0:22121",
+				Timeout:     400,
+			}},
+			EnvoyRedisProxy: envoy,
+		},
+		masterTargets: targets,
+	}
+}
+
+func TestGenerator_envoyDynamicConfigurations(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     Generator
+		want    []descriptor.EnvoyDynamicConfigDescriptor
+		wantErr bool
+	}{
+		{
+			name: "Generates a listener and a cluster per server pool",
+			gen: testEnvoyGenerator(&saasv1alpha1.EnvoyRedisProxySpec{NodeID: "node"}, map[string]twemproxy.Server{
+				"pshard01": {Address: "127.0.0.1:6379", Priority: 1},
+				"pshard02": {Address: "127.0.0.2:6379", Priority: 1},
+			}),
+			want: []descriptor.EnvoyDynamicConfigDescriptor{
+				&saasv1alpha1.EnvoyRedisProxyDynamicConfig{
+					Name:             "pool1",
+					GeneratorVersion: util.Pointer("v1"),
+					ListenerRedisProxy: &saasv1alpha1.ListenerRedisProxy{
+						Address:     util.Pointer("0.0.0.0"),
+						Port:        22121,
+						ClusterName: "pool1",
+						OpTimeout:   metav1.Duration{Duration: 400 * time.Millisecond},
+					},
+				},
+				&saasv1alpha1.EnvoyRedisProxyDynamicConfig{
+					Name:             "pool1",
+					GeneratorVersion: util.Pointer("v1"),
+					ClusterShardedRedis: &saasv1alpha1.ClusterShardedRedis{
+						Shards: []saasv1alpha1.RedisShardEndpoint{
+							{Name: "lshard01", Host: "127.0.0.1", Port: 6379},
+							{Name: "lshard02", Host: "127.0.0.1", Port: 6379},
+							{Name: "lshard03", Host: "127.0.0.2", Port: 6379},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Uses the held server pools while changes are pending approval",
+			gen: func() Generator {
+				gen := testEnvoyGenerator(&saasv1alpha1.EnvoyRedisProxySpec{NodeID: "node"}, map[string]twemproxy.Server{
+					"pshard01": {Address: "127.0.0.1:6379", Priority: 1},
+					"pshard02": {Address: "127.0.0.2:6379", Priority: 1},
+				})
+				gen.HoldServerPools([]saasv1alpha1.TwemproxyServerPool{{
+					Name:   "pool1",
+					Target: util.Pointer(saasv1alpha1.Masters),
+					Topology: []saasv1alpha1.ShardedRedisTopology{
+						{ShardName: "lshard01", PhysicalShard: "pshard02"},
+					},
+					BindAddress: "0.0.0.0:22121",
+					Timeout:     400,
+				}})
+				return gen
+			}(),
+			want: []descriptor.EnvoyDynamicConfigDescriptor{
+				&saasv1alpha1.EnvoyRedisProxyDynamicConfig{
+					Name:             "pool1",
+					GeneratorVersion: util.Pointer("v1"),
+					ListenerRedisProxy: &saasv1alpha1.ListenerRedisProxy{
+						Address:     util.Pointer("0.0.0.0"),
+						Port:        22121,
+						ClusterName: "pool1",
+						OpTimeout:   metav1.Duration{Duration: 400 * time.Millisecond},
+					},
+				},
+				&saasv1alpha1.EnvoyRedisProxyDynamicConfig{
+					Name:             "pool1",
+					GeneratorVersion: util.Pointer("v1"),
+					ClusterShardedRedis: &saasv1alpha1.ClusterShardedRedis{
+						Shards: []saasv1alpha1.RedisShardEndpoint{
+							{Name: "lshard01", Host: "127.0.0.2", Port: 6379},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Returns error if a physical shard has no target",
+			gen: testEnvoyGenerator(&saasv1alpha1.EnvoyRedisProxySpec{NodeID: "node"}, map[string]twemproxy.Server{
+				"pshard01": {Address: "127.0.0.1:6379", Priority: 1},
+			}),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.gen.envoyDynamicConfigurations()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Generator.envoyDynamicConfigurations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Errorf("Generator.envoyDynamicConfigurations() = diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestGenerator_envoyConfig(t *testing.T) {
+	targets := map[string]twemproxy.Server{
+		"pshard01": {Address: "127.0.0.1:6379", Priority: 1},
+		"pshard02": {Address: "127.0.0.2:6379", Priority: 1},
+	}
+
+	t.Run("Generates the EnvoyConfig", func(t *testing.T) {
+		gen := testEnvoyGenerator(&saasv1alpha1.EnvoyRedisProxySpec{NodeID: "node"}, targets)
+		ec, err := gen.envoyConfig()
+		if err != nil {
+			t.Fatalf("Generator.envoyConfig() error = %v", err)
+		}
+		if ec.GetName() != "test" || ec.GetNamespace() != "ns" || ec.Spec.NodeID != "node" {
+			t.Errorf("Generator.envoyConfig() got unexpected metadata %s/%s, nodeID %s",
+				ec.GetNamespace(), ec.GetName(), ec.Spec.NodeID)
+		}
+		if diff := deep.Equal(ec.GetLabels(), map[string]string{"app": "twemproxy"}); len(diff) > 0 {
+			t.Errorf("Generator.envoyConfig() labels = diff %v", diff)
+		}
+		if got := len(ec.Spec.EnvoyResources.Listeners); got != 1 {
+			t.Errorf("Generator.envoyConfig() listeners = %v, want 1", got)
+		}
+		if got := len(ec.Spec.EnvoyResources.Clusters); got != 1 {
+			t.Errorf("Generator.envoyConfig() clusters = %v, want 1", got)
+		}
+	})
+
+	t.Run("Generates an empty EnvoyConfig when disabled", func(t *testing.T) {
+		gen := testEnvoyGenerator(nil, targets)
+		ec, err := gen.envoyConfig()
+		if err != nil {
+			t.Fatalf("Generator.envoyConfig() error = %v", err)
+		}
+		if ec.GetName() != "test" || ec.GetNamespace() != "ns" || ec.Spec.EnvoyResources != nil {
+			t.Errorf("Generator.envoyConfig() got unexpected EnvoyConfig %v", ec)
+		}
+	})
+}
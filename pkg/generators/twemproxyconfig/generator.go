@@ -47,8 +47,13 @@ func init() {
 type Generator struct {
 	generators.BaseOptionsV2
 	Spec           saasv1alpha1.TwemproxyConfigSpec
+	sentinelURIs   []string
+	heldPools      []saasv1alpha1.TwemproxyServerPool
 	masterTargets  map[string]twemproxy.Server
 	slaverwTargets map[string]twemproxy.Server
+	// serverShards maps the address of each of the servers reported
+	// in the status of the Sentinel resources to its shard
+	serverShards map[string]string
 }
 
 // NewGenerator returns a new Options struct
@@ -68,20 +73,69 @@ func NewGenerator(ctx context.Context, instance *saasv1alpha1.TwemproxyConfig, c
 		Spec: instance.Spec,
 	}
 
-	var err error
-	if gen.Spec.SentinelURIs == nil {
-		gen.Spec.SentinelURIs, err = discoverSentinels(ctx, cl, instance.GetNamespace())
+	sentinels, err := resolveSentinels(ctx, cl, instance)
+	if err != nil {
+		return Generator{}, err
+	}
+
+	// Check if there are pools in the config that require slave discovery. In preview
+	// mode slaves are always discovered as the pools currently applied might target them.
+	discoverSlavesRW := gen.Spec.Preview.IsEnabled()
+	for _, pool := range gen.Spec.ServerPools {
+		if *pool.Target == saasv1alpha1.SlavesRW {
+			discoverSlavesRW = true
+		}
+	}
+
+	gen.masterTargets = map[string]twemproxy.Server{}
+	if discoverSlavesRW {
+		gen.slaverwTargets = map[string]twemproxy.Server{}
+	}
+
+	// Each Sentinel resource monitors its own set of shards. In split clusters
+	// the targets of all the sets are merged into a single view of the cluster.
+	for _, status := range sentinels {
+		uris := make([]string, 0, len(status.Sentinels))
+		for _, address := range status.Sentinels {
+			uris = append(uris, fmt.Sprintf("redis://%s", address))
+		}
+		for _, shard := range status.MonitoredShards {
+			for _, srv := range shard.Servers {
+				if gen.serverShards == nil {
+					gen.serverShards = map[string]string{}
+				}
+				gen.serverShards[srv.Address] = shard.Name
+			}
+		}
+		masters, slavesrw, err := gen.discoverTargets(ctx, uris, discoverSlavesRW, pool, log)
 		if err != nil {
 			return Generator{}, err
 		}
+		if err := mergeTargets(gen.masterTargets, masters); err != nil {
+			return Generator{}, err
+		}
+		if discoverSlavesRW {
+			if err := mergeTargets(gen.slaverwTargets, slavesrw); err != nil {
+				return Generator{}, err
+			}
+		}
+		gen.sentinelURIs = append(gen.sentinelURIs, uris...)
 	}
 
+	return gen, nil
+}
+
+// discoverTargets returns the master and rw slave targets of the shards monitored
+// by the sentinels in the given list of URIs
+func (gen *Generator) discoverTargets(ctx context.Context, sentinelURIs []string, discoverSlavesRW bool,
+	pool *server.ServerPool, log logr.Logger) (map[string]twemproxy.Server, map[string]twemproxy.Server, error) {
+
 	clustermap := map[string]map[string]string{}
-	clustermap["sentinel"] = make(map[string]string, len(gen.Spec.SentinelURIs))
-	for _, uri := range gen.Spec.SentinelURIs {
+	clustermap["sentinel"] = make(map[string]string, len(sentinelURIs))
+	for _, uri := range sentinelURIs {
 		u, err := url.Parse(uri)
 		if err != nil {
-			return Generator{}, err
+			return nil, nil, err
 		}
 		alias := strings.Split(u.Hostname(), ".")[0]
 		clustermap["sentinel"][alias] = u.String()
@@ -89,15 +143,7 @@ func NewGenerator(ctx context.Context, instance *saasv1alpha1.TwemproxyConfig, c
 
 	shardedCluster, err := sharded.NewShardedClusterFromTopology(ctx, clustermap, pool)
 	if err != nil {
-		return Generator{}, err
-	}
-
-	// Check if there are pools in the config that require slave discovery
-	discoverSlavesRW := false
-	for _, pool := range gen.Spec.ServerPools {
-		if *pool.Target == saasv1alpha1.SlavesRW {
-			discoverSlavesRW = true
-		}
+		return nil, nil, err
 	}
 
 	switch discoverSlavesRW {
@@ -105,14 +151,15 @@ func NewGenerator(ctx context.Context, instance *saasv1alpha1.TwemproxyConfig, c
 	case false:
 		// any error discovering masters should return
 		if merr := shardedCluster.SentinelDiscover(ctx, sharded.OnlyMasterDiscoveryOpt); merr != nil {
-			return Generator{}, merr
+			return nil, nil, merr
 		}
-		gen.masterTargets, err = gen.getMonitoredMasters(ctx, shardedCluster, log.WithName("masterTargets"))
+		masters, err := gen.getMonitoredMasters(ctx, shardedCluster, log.WithName("masterTargets"))
 		if err != nil {
-			return Generator{}, err
+			return nil, nil, err
 		}
+		return masters, nil, nil
 
-	case true:
+	default:
 		merr := shardedCluster.SentinelDiscover(ctx, sharded.SlaveReadOnlyDiscoveryOpt)
 		if merr != nil {
 			log.Error(merr, "DiscoveryError")
@@ -121,54 +168,112 @@ func NewGenerator(ctx context.Context, instance *saasv1alpha1.TwemproxyConfig, c
 			sentinelError := &sharded.DiscoveryError_Sentinel_Failure{}
 			masterError := &sharded.DiscoveryError_Master_SingleServerFailure{}
 			if errors.As(merr, sentinelError) || errors.As(merr, masterError) {
-				return Generator{}, merr
+				return nil, nil, merr
 			}
 		}
 
-		gen.masterTargets, err = gen.getMonitoredMasters(ctx, shardedCluster, log.WithName("masterTargets"))
+		masters, err := gen.getMonitoredMasters(ctx, shardedCluster, log.WithName("masterTargets"))
 		if err != nil {
-			return Generator{}, err
+			return nil, nil, err
 		}
-		gen.slaverwTargets, err = gen.getMonitoredReadWriteSlavesWithFallbackToMasters(
+		slavesrw, err := gen.getMonitoredReadWriteSlavesWithFallbackToMasters(
 			ctx, shardedCluster, log.WithName("slaverwTargets"),
 		)
 		if err != nil {
-			return Generator{}, err
+			return nil, nil, err
 		}
+		return masters, slavesrw, nil
 	}
+}
 
-	return gen, nil
+// mergeTargets adds the targets in src to dst. A shard monitored by more than one
+// Sentinel set is considered an error as the target would be ambiguous.
+func mergeTargets(dst, src map[string]twemproxy.Server) error {
+	for shard, server := range src {
+		if _, ok := dst[shard]; ok {
+			return fmt.Errorf("shard %s is monitored by more than one Sentinel", shard)
+		}
+		dst[shard] = server
+	}
+	return nil
+}
+
+// SentinelURIs returns the URIs of all the sentinels used to discover the targets
+func (gen *Generator) SentinelURIs() []string {
+	return gen.sentinelURIs
 }
 
 func (gen *Generator) GetTargets(poolName string) map[string]twemproxy.Server {
-	for _, pool := range gen.Spec.ServerPools {
+	for _, pool := range gen.ServerPools() {
 		if pool.Name == poolName {
-			if *pool.Target == saasv1alpha1.Masters {
-				return gen.masterTargets
-			} else {
-				return gen.slaverwTargets
-			}
+			return gen.targets(pool)
 		}
 	}
 	return nil
 }
 
-func discoverSentinels(ctx context.Context, cl client.Client, namespace string) ([]string, error) {
+func (gen *Generator) targets(pool saasv1alpha1.TwemproxyServerPool) map[string]twemproxy.Server {
+	if *pool.Target == saasv1alpha1.Masters {
+		return gen.masterTargets
+	}
+	return gen.slaverwTargets
+}
+
+// ServerPools returns the server pools used to generate the config. These
+// are the server pools in the spec unless changes are being held in preview mode.
+func (gen *Generator) ServerPools() []saasv1alpha1.TwemproxyServerPool {
+	if gen.heldPools != nil {
+		return gen.heldPools
+	}
+	return gen.Spec.ServerPools
+}
+
+// HoldServerPools makes the generator use the given server pools instead of
+// the ones in the spec. This is used to hold changes pending approval in preview mode.
+func (gen *Generator) HoldServerPools(pools []saasv1alpha1.TwemproxyServerPool) {
+	gen.heldPools = pools
+}
+
+// resolveSentinels returns the status of each of the Sentinel resources used by the
+// TwemproxyConfig. The Sentinel resource within the namespace is autodiscovered if the
+// TwemproxyConfig has no explicit references.
+func resolveSentinels(ctx context.Context, cl client.Client, instance *saasv1alpha1.TwemproxyConfig) ([]saasv1alpha1.SentinelStatus, error) {
+
+	keys := instance.SentinelKeys()
+	if keys == nil {
+		key, err := discoverSentinel(ctx, cl, instance.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		keys = []types.NamespacedName{key}
+	}
+
+	sentinels := make([]saasv1alpha1.SentinelStatus, 0, len(keys))
+	for _, key := range keys {
+		s := &saasv1alpha1.Sentinel{}
+		if err := cl.Get(ctx, key, s); err != nil {
+			return nil, err
+		}
+		if len(s.Status.Sentinels) == 0 {
+			return nil, fmt.Errorf("Sentinel %s has no sentinels reported in its status", key)
+		}
+		sentinels = append(sentinels, s.Status)
+	}
+
+	return sentinels, nil
+}
+
+func discoverSentinel(ctx context.Context, cl client.Client, namespace string) (types.NamespacedName, error) {
 	sl := &saasv1alpha1.SentinelList{}
 	if err := cl.List(ctx, sl, client.InNamespace(namespace)); err != nil {
-		return nil, err
+		return types.NamespacedName{}, err
 	}
 
 	if len(sl.Items) != 1 {
-		return nil, fmt.Errorf("unexpected number (%d) of Sentinel resources in namespace", len(sl.Items))
-	}
-
-	uris := make([]string, 0, len(sl.Items[0].Status.Sentinels))
-	for _, address := range sl.Items[0].Status.Sentinels {
-		uris = append(uris, fmt.Sprintf("redis://%s", address))
+		return types.NamespacedName{}, fmt.Errorf("unexpected number (%d) of Sentinel resources in namespace", len(sl.Items))
 	}
 
-	return uris, nil
+	return client.ObjectKeyFromObject(&sl.Items[0]), nil
 }
 
 func (gen *Generator) getMonitoredMasters(ctx context.Context,
@@ -16,7 +16,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestNewGenerator(t *testing.T) {
@@ -40,7 +42,7 @@ func TestNewGenerator(t *testing.T) {
 				instance: &saasv1alpha1.TwemproxyConfig{
 					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
 					Spec: saasv1alpha1.TwemproxyConfigSpec{
-						SentinelURIs: []string{"redis://127.0.0.1:26379"},
+						SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel"}},
 						ServerPools: []saasv1alpha1.TwemproxyServerPool{{
 							Name:   "test-pool",
 							Target: util.Pointer(saasv1alpha1.Masters),
@@ -59,7 +61,7 @@ func TestNewGenerator(t *testing.T) {
 						ReconcileServerPools: util.Pointer(true),
 					},
 				},
-				cl: nil,
+				cl: newTestClient(newTestSentinel("sentinel", "test", "127.0.0.1:26379")),
 				pool: server.NewServerPool(
 					// redis servers
 					server.NewFakeServerWithFakeClient("127.0.0.1", "1000", redis_client.NewPredefinedRedisFakeResponse("role-master", nil)),
@@ -127,7 +129,7 @@ func TestNewGenerator(t *testing.T) {
 						"part-of": "3scale-saas",
 					}},
 				Spec: saasv1alpha1.TwemproxyConfigSpec{
-					SentinelURIs: []string{"redis://127.0.0.1:26379"},
+					SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel"}},
 					ServerPools: []saasv1alpha1.TwemproxyServerPool{{
 						Name:   "test-pool",
 						Target: util.Pointer(saasv1alpha1.Masters),
@@ -145,6 +147,7 @@ func TestNewGenerator(t *testing.T) {
 					}},
 					ReconcileServerPools: util.Pointer(true),
 				},
+				sentinelURIs: []string{"redis://127.0.0.1:26379"},
 				masterTargets: map[string]twemproxy.Server{
 					"shard0": {
 						Address:  "127.0.0.1:1000",
@@ -166,7 +169,7 @@ func TestNewGenerator(t *testing.T) {
 				instance: &saasv1alpha1.TwemproxyConfig{
 					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
 					Spec: saasv1alpha1.TwemproxyConfigSpec{
-						SentinelURIs: []string{"redis://127.0.0.1:26379"},
+						SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel"}},
 						ServerPools: []saasv1alpha1.TwemproxyServerPool{{
 							Name:   "test-pool",
 							Target: util.Pointer(saasv1alpha1.Masters),
@@ -185,7 +188,7 @@ func TestNewGenerator(t *testing.T) {
 						ReconcileServerPools: util.Pointer(true),
 					},
 				},
-				cl: nil,
+				cl: newTestClient(newTestSentinel("sentinel", "test", "127.0.0.1:26379")),
 				pool: server.NewServerPool(
 					// sentinel
 					server.NewFakeServerWithFakeClient("127.0.0.1", "26379",
@@ -208,7 +211,7 @@ func TestNewGenerator(t *testing.T) {
 				instance: &saasv1alpha1.TwemproxyConfig{
 					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
 					Spec: saasv1alpha1.TwemproxyConfigSpec{
-						SentinelURIs: []string{"redis://127.0.0.1:26379"},
+						SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel"}},
 						ServerPools: []saasv1alpha1.TwemproxyServerPool{{
 							Name:   "test-pool",
 							Target: util.Pointer(saasv1alpha1.SlavesRW),
@@ -227,7 +230,7 @@ func TestNewGenerator(t *testing.T) {
 						ReconcileServerPools: util.Pointer(true),
 					},
 				},
-				cl: nil,
+				cl: newTestClient(newTestSentinel("sentinel", "test", "127.0.0.1:26379")),
 				pool: server.NewServerPool(
 					// redis servers
 					server.NewFakeServerWithFakeClient("127.0.0.1", "1000", redis_client.NewPredefinedRedisFakeResponse("role-master", nil)),
@@ -347,7 +350,7 @@ func TestNewGenerator(t *testing.T) {
 						"part-of": "3scale-saas",
 					}},
 				Spec: saasv1alpha1.TwemproxyConfigSpec{
-					SentinelURIs: []string{"redis://127.0.0.1:26379"},
+					SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel"}},
 					ServerPools: []saasv1alpha1.TwemproxyServerPool{{
 						Name:   "test-pool",
 						Target: util.Pointer(saasv1alpha1.SlavesRW),
@@ -365,6 +368,7 @@ func TestNewGenerator(t *testing.T) {
 					}},
 					ReconcileServerPools: util.Pointer(true),
 				},
+				sentinelURIs: []string{"redis://127.0.0.1:26379"},
 				masterTargets: map[string]twemproxy.Server{
 					"shard0": {
 						Address:  "127.0.0.1:1000",
@@ -395,7 +399,7 @@ func TestNewGenerator(t *testing.T) {
 				instance: &saasv1alpha1.TwemproxyConfig{
 					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
 					Spec: saasv1alpha1.TwemproxyConfigSpec{
-						SentinelURIs: []string{"redis://127.0.0.1:26379"},
+						SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel"}},
 						ServerPools: []saasv1alpha1.TwemproxyServerPool{{
 							Name:   "test-pool",
 							Target: util.Pointer(saasv1alpha1.SlavesRW),
@@ -414,7 +418,7 @@ func TestNewGenerator(t *testing.T) {
 						ReconcileServerPools: util.Pointer(true),
 					},
 				},
-				cl: nil,
+				cl: newTestClient(newTestSentinel("sentinel", "test", "127.0.0.1:26379")),
 				pool: server.NewServerPool(
 					// redis servers
 					server.NewFakeServerWithFakeClient("127.0.0.1", "1000", redis_client.NewPredefinedRedisFakeResponse("role-master", nil)),
@@ -531,7 +535,7 @@ func TestNewGenerator(t *testing.T) {
 						"part-of": "3scale-saas",
 					}},
 				Spec: saasv1alpha1.TwemproxyConfigSpec{
-					SentinelURIs: []string{"redis://127.0.0.1:26379"},
+					SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel"}},
 					ServerPools: []saasv1alpha1.TwemproxyServerPool{{
 						Name:   "test-pool",
 						Target: util.Pointer(saasv1alpha1.SlavesRW),
@@ -549,6 +553,7 @@ func TestNewGenerator(t *testing.T) {
 					}},
 					ReconcileServerPools: util.Pointer(true),
 				},
+				sentinelURIs: []string{"redis://127.0.0.1:26379"},
 				masterTargets: map[string]twemproxy.Server{
 					"shard0": {
 						Address:  "127.0.0.1:1000",
@@ -579,7 +584,7 @@ func TestNewGenerator(t *testing.T) {
 				instance: &saasv1alpha1.TwemproxyConfig{
 					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
 					Spec: saasv1alpha1.TwemproxyConfigSpec{
-						SentinelURIs: []string{"redis://127.0.0.1:26379"},
+						SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel"}},
 						ServerPools: []saasv1alpha1.TwemproxyServerPool{{
 							Name:   "test-pool",
 							Target: util.Pointer(saasv1alpha1.SlavesRW),
@@ -598,7 +603,7 @@ func TestNewGenerator(t *testing.T) {
 						ReconcileServerPools: util.Pointer(true),
 					},
 				},
-				cl: nil,
+				cl: newTestClient(newTestSentinel("sentinel", "test", "127.0.0.1:26379")),
 				pool: server.NewServerPool(
 					// redis servers
 					server.NewFakeServerWithFakeClient("127.0.0.1", "1000"), // is down
@@ -683,6 +688,177 @@ func TestNewGenerator(t *testing.T) {
 			want:    Generator{},
 			wantErr: true,
 		},
+		{
+			name: "Populates the generation with the topology of several Sentinel sets (split cluster)",
+			args: args{
+				ctx: context.TODO(),
+				instance: &saasv1alpha1.TwemproxyConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+					Spec: saasv1alpha1.TwemproxyConfigSpec{
+						SentinelRefs: []saasv1alpha1.SentinelReference{
+							{Name: "sentinel-a"},
+							{Name: "sentinel-b", Namespace: util.Pointer("other")},
+						},
+						ServerPools: []saasv1alpha1.TwemproxyServerPool{{
+							Name:   "test-pool",
+							Target: util.Pointer(saasv1alpha1.Masters),
+							Topology: []saasv1alpha1.ShardedRedisTopology{
+								{ShardName: "l-shard00", PhysicalShard: "shard0"},
+								{ShardName: "l-shard01", PhysicalShard: "shard1"},
+							},
+							BindAddress: "0.0.0.0:22121",
+							Timeout:     5000,
+							TCPBacklog:  512,
+							PreConnect:  false,
+						}},
+						ReconcileServerPools: util.Pointer(true),
+					},
+				},
+				cl: newTestClient(
+					newTestSentinel("sentinel-a", "test", "127.0.0.1:26379"),
+					newTestSentinel("sentinel-b", "other", "127.0.0.1:26380"),
+				),
+				pool: server.NewServerPool(
+					// redis servers
+					server.NewFakeServerWithFakeClient("127.0.0.1", "1000", redis_client.NewPredefinedRedisFakeResponse("role-master", nil)),
+					server.NewFakeServerWithFakeClient("127.0.0.1", "5000", redis_client.NewPredefinedRedisFakeResponse("role-master", nil)),
+					// sentinels
+					server.NewFakeServerWithFakeClient("127.0.0.1", "26379",
+						redis_client.FakeResponse{
+							// cmd: Ping
+							InjectResponse: func() interface{} { return nil },
+							InjectError:    func() error { return nil },
+						},
+						redis_client.FakeResponse{
+							// cmd: SentinelMasters()
+							InjectResponse: func() interface{} {
+								return []interface{}{
+									[]interface{}{"name", "shard0", "ip", "127.0.0.1", "port", "1000"},
+								}
+							},
+							InjectError: func() error { return nil },
+						},
+						redis_client.FakeResponse{
+							// cmd: SentinelMaster (shard0)
+							InjectResponse: func() interface{} {
+								return &redis_client.SentinelMasterCmdResult{Name: "shard0", IP: "127.0.0.1", Port: 1000, Flags: "master"}
+							},
+							InjectError: func() error { return nil },
+						},
+						redis_client.FakeResponse{
+							// cmd: SentinelGetMasterAddrByName (shard0)
+							InjectResponse: func() interface{} {
+								return []string{"127.0.0.1", "1000"}
+							},
+							InjectError: func() error { return nil },
+						},
+					),
+					server.NewFakeServerWithFakeClient("127.0.0.1", "26380",
+						redis_client.FakeResponse{
+							// cmd: Ping
+							InjectResponse: func() interface{} { return nil },
+							InjectError:    func() error { return nil },
+						},
+						redis_client.FakeResponse{
+							// cmd: SentinelMasters()
+							InjectResponse: func() interface{} {
+								return []interface{}{
+									[]interface{}{"name", "shard1", "ip", "127.0.0.1", "port", "5000"},
+								}
+							},
+							InjectError: func() error { return nil },
+						},
+						redis_client.FakeResponse{
+							// cmd: SentinelMaster (shard1)
+							InjectResponse: func() interface{} {
+								return &redis_client.SentinelMasterCmdResult{Name: "shard1", IP: "127.0.0.1", Port: 5000, Flags: "master"}
+							},
+							InjectError: func() error { return nil },
+						},
+						redis_client.FakeResponse{
+							// cmd: SentinelGetMasterAddrByName (shard1)
+							InjectResponse: func() interface{} {
+								return []string{"127.0.0.1", "5000"}
+							},
+							InjectError: func() error { return nil },
+						},
+					),
+				),
+				log: logr.Discard(),
+			},
+			want: Generator{
+				BaseOptionsV2: generators.BaseOptionsV2{
+					Component:    "twemproxy",
+					InstanceName: "test",
+					Namespace:    "test",
+					Labels: map[string]string{
+						"app":     component,
+						"part-of": "3scale-saas",
+					}},
+				Spec: saasv1alpha1.TwemproxyConfigSpec{
+					SentinelRefs: []saasv1alpha1.SentinelReference{
+						{Name: "sentinel-a"},
+						{Name: "sentinel-b", Namespace: util.Pointer("other")},
+					},
+					ServerPools: []saasv1alpha1.TwemproxyServerPool{{
+						Name:   "test-pool",
+						Target: util.Pointer(saasv1alpha1.Masters),
+						Topology: []saasv1alpha1.ShardedRedisTopology{
+							{ShardName: "l-shard00", PhysicalShard: "shard0"},
+							{ShardName: "l-shard01", PhysicalShard: "shard1"},
+						},
+						BindAddress: "0.0.0.0:22121",
+						Timeout:     5000,
+						TCPBacklog:  512,
+						PreConnect:  false,
+					}},
+					ReconcileServerPools: util.Pointer(true),
+				},
+				sentinelURIs: []string{"redis://127.0.0.1:26379", "redis://127.0.0.1:26380"},
+				masterTargets: map[string]twemproxy.Server{
+					"shard0": {
+						Address:  "127.0.0.1:1000",
+						Priority: 1,
+					},
+					"shard1": {
+						Address:  "127.0.0.1:5000",
+						Priority: 1,
+					},
+				},
+				slaverwTargets: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Returns error (referenced Sentinel not found)",
+			args: args{
+				ctx: context.TODO(),
+				instance: &saasv1alpha1.TwemproxyConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+					Spec: saasv1alpha1.TwemproxyConfigSpec{
+						SentinelRefs: []saasv1alpha1.SentinelReference{{Name: "sentinel", Namespace: util.Pointer("other")}},
+						ServerPools: []saasv1alpha1.TwemproxyServerPool{{
+							Name:   "test-pool",
+							Target: util.Pointer(saasv1alpha1.Masters),
+							Topology: []saasv1alpha1.ShardedRedisTopology{
+								{ShardName: "l-shard00", PhysicalShard: "shard0"},
+								{ShardName: "l-shard01", PhysicalShard: "shard1"},
+							},
+							BindAddress: "0.0.0.0:22121",
+							Timeout:     5000,
+							TCPBacklog:  512,
+							PreConnect:  false,
+						}},
+						ReconcileServerPools: util.Pointer(true),
+					},
+				},
+				cl:   newTestClient(newTestSentinel("sentinel", "test", "127.0.0.1:26379")),
+				pool: server.NewServerPool(),
+				log:  logr.Discard(),
+			},
+			want:    Generator{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -698,3 +874,18 @@ func TestNewGenerator(t *testing.T) {
 		})
 	}
 }
+
+func newTestClient(objects ...client.Object) client.Client {
+	s := runtime.NewScheme()
+	if err := saasv1alpha1.AddToScheme(s); err != nil {
+		panic(err)
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build()
+}
+
+func newTestSentinel(name, namespace string, sentinels ...string) *saasv1alpha1.Sentinel {
+	return &saasv1alpha1.Sentinel{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status:     saasv1alpha1.SentinelStatus{Sentinels: sentinels},
+	}
+}
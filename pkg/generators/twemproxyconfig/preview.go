@@ -0,0 +1,214 @@
+package twemproxyconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/twemproxy"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// AppliedServerPools returns the server pools that were used to generate the given
+// ConfigMap. It returns nil if the ConfigMap does not hold this information, which
+// happens with ConfigMaps created by older versions of the operator.
+func AppliedServerPools(cm *corev1.ConfigMap) ([]saasv1alpha1.TwemproxyServerPool, error) {
+	value, ok := cm.GetAnnotations()[saasv1alpha1.TwemproxyServerPoolsAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	pools := []saasv1alpha1.TwemproxyServerPool{}
+	if err := json.Unmarshal([]byte(value), &pools); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal server pools from ConfigMap %s: '%s'", cm.GetName(), err.Error())
+	}
+	for idx := range pools {
+		pools[idx].Default()
+	}
+	return pools, nil
+}
+
+// Preview returns the changes between the given server pools, which are the ones
+// currently applied, and the server pools in the spec. It returns nil if there are
+// no changes. The same targets are used to generate both the current and the desired
+// configs, so changes in the targets due to failovers are not part of the preview.
+// The hash of the preview identifies the transition between the current and the
+// desired server pools, so an approval does not apply to later changes in the spec,
+// including reverting it back to previously approved server pools.
+func (gen *Generator) Preview(applied []saasv1alpha1.TwemproxyServerPool) *saasv1alpha1.TwemproxyConfigPreview {
+
+	current, _ := json.Marshal(applied)
+	desired, _ := json.Marshal(gen.Spec.ServerPools)
+	if string(current) == string(desired) {
+		return nil
+	}
+
+	preview := &saasv1alpha1.TwemproxyConfigPreview{
+		Hash:        util.Hash([]string{string(current), string(desired)}),
+		ServerPools: []saasv1alpha1.ServerPoolPreview{},
+	}
+
+	appliedPools := make(map[string]saasv1alpha1.TwemproxyServerPool, len(applied))
+	for _, pool := range applied {
+		appliedPools[pool.Name] = pool
+	}
+
+	for _, pool := range gen.Spec.ServerPools {
+		cpool, ok := appliedPools[pool.Name]
+		if !ok {
+			cpool = saasv1alpha1.TwemproxyServerPool{Name: pool.Name, Target: pool.Target}
+		}
+		preview.ServerPools = append(preview.ServerPools, gen.serverPoolPreview(cpool, pool))
+		delete(appliedPools, pool.Name)
+	}
+
+	// pools that are removed
+	for _, pool := range applied {
+		if _, ok := appliedPools[pool.Name]; ok {
+			preview.ServerPools = append(preview.ServerPools, gen.serverPoolPreview(pool,
+				saasv1alpha1.TwemproxyServerPool{Name: pool.Name, Target: pool.Target}))
+		}
+	}
+
+	return preview
+}
+
+func (gen *Generator) serverPoolPreview(current, desired saasv1alpha1.TwemproxyServerPool) saasv1alpha1.ServerPoolPreview {
+	preview := saasv1alpha1.ServerPoolPreview{Name: desired.Name}
+
+	currentShards := make(map[string]string, len(current.Topology))
+	for _, s := range current.Topology {
+		currentShards[s.ShardName] = s.PhysicalShard
+	}
+	desiredShards := make(map[string]string, len(desired.Topology))
+	for _, s := range desired.Topology {
+		desiredShards[s.ShardName] = s.PhysicalShard
+	}
+
+	for _, s := range desired.Topology {
+		pshard, ok := currentShards[s.ShardName]
+		if !ok {
+			preview.Added = append(preview.Added, s.ShardName)
+		} else if pshard != s.PhysicalShard {
+			preview.Remapped = append(preview.Remapped,
+				saasv1alpha1.ShardRemap{ShardName: s.ShardName, From: pshard, To: s.PhysicalShard})
+		}
+	}
+	for _, s := range current.Topology {
+		if _, ok := desiredShards[s.ShardName]; !ok {
+			preview.Removed = append(preview.Removed, s.ShardName)
+		}
+	}
+
+	keysRemapped := twemproxy.KeysRemapped(
+		twemproxy.NewContinuum(twemproxy.GenerateServerPool(current, gen.targets(current)).Servers),
+		twemproxy.NewContinuum(twemproxy.GenerateServerPool(desired, gen.targets(desired)).Servers),
+	)
+	preview.KeysAffected = fmt.Sprintf("%.2f%%", keysRemapped*100)
+
+	return preview
+}
+
+// ServerPoolsFromConfigMap returns the server pools that generate the config held in
+// the given ConfigMap. It is used when the ConfigMap does not store the server pools used
+// to generate it. The physical shard of each server is resolved from its address, using
+// the current targets and the servers reported in the status of the Sentinel resources, so
+// servers that changed due to failovers are still mapped to the same physical shard. The
+// target of each pool is inferred from the servers and defaults to the one in the spec. An
+// error is returned if the physical shard of any of the servers cannot be resolved.
+func (gen *Generator) ServerPoolsFromConfigMap(cm *corev1.ConfigMap) ([]saasv1alpha1.TwemproxyServerPool, error) {
+
+	live := map[string]twemproxy.ServerPoolConfig{}
+	if err := yaml.Unmarshal([]byte(cm.Data[twemproxyConfigFile]), &live); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal twemproxy config from ConfigMap %s: '%s'", cm.GetName(), err.Error())
+	}
+	delete(live, HealthPoolName)
+
+	// keep the order of the pools in the spec, followed
+	// by the pools that are no longer in the spec
+	names := make([]string, 0, len(live))
+	desiredPools := make(map[string]saasv1alpha1.TwemproxyServerPool, len(gen.Spec.ServerPools))
+	for _, pool := range gen.Spec.ServerPools {
+		desiredPools[pool.Name] = pool
+		if _, ok := live[pool.Name]; ok {
+			names = append(names, pool.Name)
+		}
+	}
+	removed := []string{}
+	for name := range live {
+		if _, ok := desiredPools[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	names = append(names, removed...)
+
+	pools := make([]saasv1alpha1.TwemproxyServerPool, 0, len(names))
+	for _, name := range names {
+		config := live[name]
+		pool := saasv1alpha1.TwemproxyServerPool{
+			Name:        name,
+			Topology:    make([]saasv1alpha1.ShardedRedisTopology, 0, len(config.Servers)),
+			BindAddress: config.Listen,
+			Timeout:     config.Timeout,
+			TCPBacklog:  config.Backlog,
+			PreConnect:  config.PreConnect,
+		}
+		for _, srv := range config.Servers {
+			pshard, ok := gen.physicalShard(srv.Address)
+			if !ok {
+				return nil, fmt.Errorf("unable to resolve the physical shard of server %s (%s) in ConfigMap %s",
+					srv.Name, srv.Address, cm.GetName())
+			}
+			pool.Topology = append(pool.Topology, saasv1alpha1.ShardedRedisTopology{ShardName: srv.Name, PhysicalShard: pshard})
+		}
+		pool.Target = gen.inferTarget(config.Servers, pool.Topology, desiredPools[name].Target)
+		pool.Default()
+		pools = append(pools, pool)
+	}
+
+	return pools, nil
+}
+
+// physicalShard returns the physical shard of the server with the given address
+func (gen *Generator) physicalShard(address string) (string, bool) {
+	for _, targets := range []map[string]twemproxy.Server{gen.masterTargets, gen.slaverwTargets} {
+		for shard, srv := range targets {
+			if srv.Address == address {
+				return shard, true
+			}
+		}
+	}
+	shard, ok := gen.serverShards[address]
+	return shard, ok
+}
+
+// inferTarget returns the target of a server pool from the addresses of its servers. A
+// server is only evidence of the target if the master and rw slave targets of its shard
+// differ. The given default is returned if the servers are not conclusive.
+func (gen *Generator) inferTarget(servers []twemproxy.Server, topology []saasv1alpha1.ShardedRedisTopology,
+	def *saasv1alpha1.TargetRedisServers) *saasv1alpha1.TargetRedisServers {
+
+	var masters, slaves bool
+	for idx, srv := range servers {
+		master, slave := gen.masterTargets[topology[idx].PhysicalShard], gen.slaverwTargets[topology[idx].PhysicalShard]
+		if slave.Address == "" || master.Address == slave.Address {
+			continue
+		}
+		masters = masters || srv.Address == master.Address
+		slaves = slaves || srv.Address == slave.Address
+	}
+
+	switch {
+	case slaves && !masters:
+		return util.Pointer(saasv1alpha1.SlavesRW)
+	case masters && !slaves:
+		return util.Pointer(saasv1alpha1.Masters)
+	case def != nil:
+		return util.Pointer(*def)
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package twemproxyconfig
+
+import (
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/twemproxy"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testServerPool(target saasv1alpha1.TargetRedisServers, topology ...saasv1alpha1.ShardedRedisTopology) saasv1alpha1.TwemproxyServerPool {
+	return saasv1alpha1.TwemproxyServerPool{
+		Name:        "pool",
+		Target:      util.Pointer(target),
+		Topology:    topology,
+		BindAddress: "0.0.0.0:22121",
+		Timeout:     5000,
+		TCPBacklog:  512,
+	}
+}
+
+func TestAppliedServerPools(t *testing.T) {
+	tests := []struct {
+		name    string
+		cm      *corev1.ConfigMap
+		want    []saasv1alpha1.TwemproxyServerPool
+		wantErr bool
+	}{
+		{
+			name: "Returns the server pools stored in the ConfigMap",
+			cm: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					saasv1alpha1.TwemproxyServerPoolsAnnotationKey: `[{"name":"pool","topology":[{"shardName":"lshard01","physicalShard":"pshard01"}],"bindAddress":"0.0.0.0:22121","timeout":5000,"tcpBacklog":512,"preConnect":false}]`,
+				},
+			}},
+			want: []saasv1alpha1.TwemproxyServerPool{
+				testServerPool(saasv1alpha1.Masters, saasv1alpha1.ShardedRedisTopology{ShardName: "lshard01", PhysicalShard: "pshard01"}),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Returns nil if the ConfigMap does not store the server pools",
+			cm:      &corev1.ConfigMap{},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "Returns error",
+			cm: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{saasv1alpha1.TwemproxyServerPoolsAnnotationKey: `{`},
+			}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AppliedServerPools(tt.cm)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AppliedServerPools() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); len(diff) != 0 {
+				t.Errorf("AppliedServerPools() = diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestGenerator_Preview(t *testing.T) {
+	targets := map[string]twemproxy.Server{
+		"pshard01": {Address: "127.0.0.1:6379", Priority: 1},
+		"pshard02": {Address: "127.0.0.2:6379", Priority: 1},
+	}
+	applied := []saasv1alpha1.TwemproxyServerPool{
+		testServerPool(saasv1alpha1.Masters,
+			saasv1alpha1.ShardedRedisTopology{ShardName: "lshard01", PhysicalShard: "pshard01"},
+			saasv1alpha1.ShardedRedisTopology{ShardName: "lshard02", PhysicalShard: "pshard01"},
+			saasv1alpha1.ShardedRedisTopology{ShardName: "lshard03", PhysicalShard: "pshard01"},
+			saasv1alpha1.ShardedRedisTopology{ShardName: "lshard04", PhysicalShard: "pshard02"},
+		),
+	}
+	tests := []struct {
+		name    string
+		pools   []saasv1alpha1.TwemproxyServerPool
+		applied []saasv1alpha1.TwemproxyServerPool
+		want    *saasv1alpha1.TwemproxyConfigPreview
+	}{
+		{
+			name:    "Returns nil if there are no changes",
+			pools:   applied,
+			applied: applied,
+			want:    nil,
+		},
+		{
+			name: "Returns the changes in the topology",
+			pools: []saasv1alpha1.TwemproxyServerPool{
+				testServerPool(saasv1alpha1.Masters,
+					saasv1alpha1.ShardedRedisTopology{ShardName: "lshard01", PhysicalShard: "pshard01"},
+					saasv1alpha1.ShardedRedisTopology{ShardName: "lshard02", PhysicalShard: "pshard01"},
+					saasv1alpha1.ShardedRedisTopology{ShardName: "lshard03", PhysicalShard: "pshard02"},
+					saasv1alpha1.ShardedRedisTopology{ShardName: "lshard05", PhysicalShard: "pshard02"},
+				),
+			},
+			applied: applied,
+			want: &saasv1alpha1.TwemproxyConfigPreview{
+				ServerPools: []saasv1alpha1.ServerPoolPreview{{
+					Name:         "pool",
+					Added:        []string{"lshard05"},
+					Removed:      []string{"lshard04"},
+					Remapped:     []saasv1alpha1.ShardRemap{{ShardName: "lshard03", From: "pshard01", To: "pshard02"}},
+					KeysAffected: "46.79%",
+				}},
+			},
+		},
+		{
+			name: "Changes in the pool parameters are also previewed",
+			pools: func() []saasv1alpha1.TwemproxyServerPool {
+				pools := []saasv1alpha1.TwemproxyServerPool{*applied[0].DeepCopy()}
+				pools[0].Timeout = 1000
+				return pools
+			}(),
+			applied: applied,
+			want: &saasv1alpha1.TwemproxyConfigPreview{
+				ServerPools: []saasv1alpha1.ServerPoolPreview{{
+					Name:         "pool",
+					KeysAffected: "0.00%",
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := &Generator{
+				Spec:          saasv1alpha1.TwemproxyConfigSpec{ServerPools: tt.pools},
+				masterTargets: targets,
+			}
+			got := gen.Preview(tt.applied)
+			if diff := cmp.Diff(got, tt.want, cmpopts.IgnoreFields(saasv1alpha1.TwemproxyConfigPreview{}, "Hash")); len(diff) != 0 {
+				t.Errorf("Generator.Preview() = diff %v", diff)
+			}
+			if got != nil && got.Hash == "" {
+				t.Errorf("Generator.Preview() returned an empty hash")
+			}
+		})
+	}
+}
+
+func TestGenerator_Preview_Hash(t *testing.T) {
+	targets := map[string]twemproxy.Server{
+		"pshard01": {Address: "127.0.0.1:6379", Priority: 1},
+		"pshard02": {Address: "127.0.0.2:6379", Priority: 1},
+	}
+	a := []saasv1alpha1.TwemproxyServerPool{testServerPool(saasv1alpha1.Masters,
+		saasv1alpha1.ShardedRedisTopology{ShardName: "lshard01", PhysicalShard: "pshard01"})}
+	b := []saasv1alpha1.TwemproxyServerPool{testServerPool(saasv1alpha1.Masters,
+		saasv1alpha1.ShardedRedisTopology{ShardName: "lshard01", PhysicalShard: "pshard02"})}
+	c := []saasv1alpha1.TwemproxyServerPool{testServerPool(saasv1alpha1.Masters,
+		saasv1alpha1.ShardedRedisTopology{ShardName: "lshard01", PhysicalShard: "pshard01"},
+		saasv1alpha1.ShardedRedisTopology{ShardName: "lshard02", PhysicalShard: "pshard02"})}
+
+	preview := func(applied, desired []saasv1alpha1.TwemproxyServerPool) string {
+		gen := &Generator{Spec: saasv1alpha1.TwemproxyConfigSpec{ServerPools: desired}, masterTargets: targets}
+		return gen.Preview(applied).Hash
+	}
+
+	if preview(a, b) == preview(b, a) {
+		t.Errorf("Generator.Preview() reverting a change has the same hash as the change")
+	}
+	if preview(a, b) == preview(c, b) {
+		t.Errorf("Generator.Preview() changes from different server pools have the same hash")
+	}
+	if preview(a, b) != preview(a, b) {
+		t.Errorf("Generator.Preview() the same change has different hashes")
+	}
+}
+
+func TestGenerator_ServerPoolsFromConfigMap(t *testing.T) {
+	masters := map[string]twemproxy.Server{
+		"pshard01": {Address: "127.0.0.1:6379", Priority: 1},
+		"pshard02": {Address: "127.0.0.2:6379", Priority: 1},
+	}
+	slaves := map[string]twemproxy.Server{
+		"pshard01": {Address: "127.0.0.11:6379", Priority: 1},
+		"pshard02": {Address: "127.0.0.12:6379", Priority: 1},
+	}
+	// servers reported in the status of the Sentinel resources
+	serverShards := map[string]string{
+		"127.0.0.1:6379": "pshard01", "127.0.0.11:6379": "pshard01", "127.0.0.21:6379": "pshard01",
+		"127.0.0.2:6379": "pshard02", "127.0.0.12:6379": "pshard02",
+	}
+	applied := []saasv1alpha1.TwemproxyServerPool{
+		testServerPool(saasv1alpha1.Masters,
+			saasv1alpha1.ShardedRedisTopology{ShardName: "lshard01", PhysicalShard: "pshard01"},
+			saasv1alpha1.ShardedRedisTopology{ShardName: "lshard02", PhysicalShard: "pshard01"},
+			saasv1alpha1.ShardedRedisTopology{ShardName: "lshard03", PhysicalShard: "pshard02"},
+		),
+	}
+	appliedSlaves := []saasv1alpha1.TwemproxyServerPool{*applied[0].DeepCopy()}
+	appliedSlaves[0].Target = util.Pointer(saasv1alpha1.SlavesRW)
+	// ConfigMap generated by an older version of the operator, without the annotation
+	legacyConfigMap := func(pools []saasv1alpha1.TwemproxyServerPool, masters map[string]twemproxy.Server) *corev1.ConfigMap {
+		gen := &Generator{Spec: saasv1alpha1.TwemproxyConfigSpec{ServerPools: pools}, masterTargets: masters, slaverwTargets: slaves}
+		cm := gen.configMap(true)
+		cm.SetAnnotations(nil)
+		return cm
+	}
+	tests := []struct {
+		name    string
+		pools   []saasv1alpha1.TwemproxyServerPool
+		cm      *corev1.ConfigMap
+		want    []saasv1alpha1.TwemproxyServerPool
+		wantErr bool
+	}{
+		{
+			name:  "Returns the server pools that generate the config",
+			pools: applied,
+			cm:    legacyConfigMap(applied, masters),
+			want:  applied,
+		},
+		{
+			name:  "Resolves the physical shards of servers changed by a failover",
+			pools: applied,
+			cm: legacyConfigMap(applied, map[string]twemproxy.Server{
+				"pshard01": {Address: "127.0.0.21:6379", Priority: 1},
+				"pshard02": {Address: "127.0.0.2:6379", Priority: 1},
+			}),
+			want: applied,
+		},
+		{
+			name:  "Infers the target of the server pools",
+			pools: applied,
+			cm:    legacyConfigMap(appliedSlaves, masters),
+			want:  appliedSlaves,
+		},
+		{
+			name: "Returns the server pools that are removed",
+			pools: func() []saasv1alpha1.TwemproxyServerPool {
+				pools := []saasv1alpha1.TwemproxyServerPool{*applied[0].DeepCopy()}
+				pools[0].Name = "other"
+				return pools
+			}(),
+			cm:   legacyConfigMap(applied, masters),
+			want: applied,
+		},
+		{
+			name:  "Returns error if a server cannot be resolved",
+			pools: applied,
+			cm: legacyConfigMap(applied, map[string]twemproxy.Server{
+				"pshard01": {Address: "127.0.0.99:6379", Priority: 1},
+				"pshard02": {Address: "127.0.0.2:6379", Priority: 1},
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "Returns error",
+			pools:   applied,
+			cm:      &corev1.ConfigMap{Data: map[string]string{twemproxyConfigFile: `{`}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := &Generator{
+				Spec:           saasv1alpha1.TwemproxyConfigSpec{ServerPools: tt.pools},
+				masterTargets:  masters,
+				slaverwTargets: slaves,
+				serverShards:   serverShards,
+			}
+			got, err := gen.ServerPoolsFromConfigMap(tt.cm)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Generator.ServerPoolsFromConfigMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); len(diff) != 0 {
+				t.Errorf("Generator.ServerPoolsFromConfigMap() = diff %v", diff)
+			}
+		})
+	}
+}
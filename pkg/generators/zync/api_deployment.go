@@ -14,13 +14,7 @@ func (gen *APIGenerator) deployment() *appsv1.Deployment {
 	dep := &appsv1.Deployment{
 		Spec: appsv1.DeploymentSpec{
 			Replicas: gen.APISpec.Replicas,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: util.Pointer(intstr.FromInt(0)),
-					MaxSurge:       util.Pointer(intstr.FromInt(1)),
-				},
-			},
+			Strategy: gen.APISpec.DeploymentStrategy.DeploymentStrategy(),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
 					ImagePullSecrets: func() []corev1.LocalObjectReference {
@@ -59,7 +53,7 @@ func (gen *APIGenerator) deployment() *appsv1.Deployment {
 										},
 									},
 								)
-								return envVars
+								return pod.MergeEnvironment(envVars, gen.APISpec.EnvironmentSpec)
 							}(),
 							Resources:       corev1.ResourceRequirements(*gen.APISpec.Resources),
 							ImagePullPolicy: *gen.Image.PullPolicy,
@@ -74,5 +68,7 @@ func (gen *APIGenerator) deployment() *appsv1.Deployment {
 			},
 		},
 	}
+
+	dep.Spec.Template = pod.AddSecretFiles(dep.Spec.Template, gen.Options)
 	return dep
 }
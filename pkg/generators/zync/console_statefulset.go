@@ -18,11 +18,8 @@ func (gen *ConsoleGenerator) statefulset() *appsv1.StatefulSet {
 			Labels:    gen.GetLabels(),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: util.Pointer[int32](1),
-			Selector: &metav1.LabelSelector{MatchLabels: gen.GetSelector()},
-			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: appsv1.RollingUpdateStatefulSetStrategyType,
-			},
+			Replicas:            util.Pointer[int32](1),
+			Selector:            &metav1.LabelSelector{MatchLabels: gen.GetSelector()},
 			PodManagementPolicy: appsv1.ParallelPodManagement,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -69,7 +66,7 @@ func (gen *ConsoleGenerator) statefulset() *appsv1.StatefulSet {
 										},
 									},
 								)
-								return envVars
+								return pod.MergeEnvironment(envVars, gen.Spec.EnvironmentSpec)
 							}(),
 							Resources:       corev1.ResourceRequirements(*gen.Spec.Resources),
 							ImagePullPolicy: *gen.Spec.Image.PullPolicy,
@@ -82,5 +79,7 @@ func (gen *ConsoleGenerator) statefulset() *appsv1.StatefulSet {
 			},
 		},
 	}
+
+	sts.Spec.Template = pod.AddSecretFiles(sts.Spec.Template, gen.Options)
 	return sts
 }
@@ -6,7 +6,6 @@ import (
 
 	"github.com/3scale-ops/basereconciler/mutators"
 	"github.com/3scale-ops/basereconciler/resource"
-	"github.com/3scale-ops/basereconciler/util"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	"github.com/3scale-ops/saas-operator/pkg/generators"
 	"github.com/3scale-ops/saas-operator/pkg/generators/zync/config"
@@ -15,10 +14,13 @@ import (
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/podmonitor"
 	operatorutil "github.com/3scale-ops/saas-operator/pkg/util"
 	deployment_workload "github.com/3scale-ops/saas-operator/pkg/workloads/deployment"
+	migration_workload "github.com/3scale-ops/saas-operator/pkg/workloads/migration"
+	statefulset_workload "github.com/3scale-ops/saas-operator/pkg/workloads/statefulset"
 	externalsecretsv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -27,6 +29,7 @@ const (
 	api       string = "zync"
 	que       string = "que"
 	console   string = "console"
+	dbMigrate string = "db-migrate"
 )
 
 // Generator configures the generators for Zync
@@ -37,6 +40,7 @@ type Generator struct {
 	Console              ConsoleGenerator
 	GrafanaDashboardSpec saasv1alpha1.GrafanaDashboardSpec
 	Config               saasv1alpha1.ZyncConfig
+	DatabaseMigration    DatabaseMigrationGenerator
 }
 
 // NewGenerator returns a new Options struct
@@ -62,10 +66,11 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.ZyncSpec) Genera
 					"threescale_component_element": api,
 				},
 			},
-			APISpec: *spec.API,
-			Image:   *spec.Image,
-			Options: config.NewAPIOptions(spec),
-			Traffic: true,
+			APISpec:               *spec.API,
+			Image:                 *spec.Image,
+			Options:               config.NewAPIOptions(spec),
+			RolloutOnSecretChange: spec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			Traffic:               true,
 		},
 		Que: QueGenerator{
 			BaseOptionsV2: generators.BaseOptionsV2{
@@ -78,9 +83,10 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.ZyncSpec) Genera
 					"threescale_component_element": que,
 				},
 			},
-			QueSpec: *spec.Que,
-			Image:   *spec.Image,
-			Options: config.NewQueOptions(spec),
+			QueSpec:               *spec.Que,
+			Image:                 *spec.Image,
+			Options:               config.NewQueOptions(spec),
+			RolloutOnSecretChange: spec.Config.ExternalSecret.RolloutOnChangeEnabled(),
 		},
 		Console: ConsoleGenerator{
 			BaseOptionsV2: generators.BaseOptionsV2{
@@ -93,15 +99,51 @@ func NewGenerator(instance, namespace string, spec saasv1alpha1.ZyncSpec) Genera
 					"threescale_component_element": console,
 				},
 			},
-			Spec:    *spec.Console,
+			Spec:                  *spec.Console,
+			Options:               config.NewAPIOptions(spec),
+			RolloutOnSecretChange: spec.Config.ExternalSecret.RolloutOnChangeEnabled(),
+			Enabled:               *spec.Console.Enabled,
+		},
+		DatabaseMigration: DatabaseMigrationGenerator{
+			BaseOptionsV2: generators.BaseOptionsV2{
+				Component:    strings.Join([]string{component, dbMigrate}, "-"),
+				InstanceName: instance,
+				Namespace:    namespace,
+				Labels: map[string]string{
+					"app":                          "3scale-api-management",
+					"threescale_component":         component,
+					"threescale_component_element": dbMigrate,
+				},
+			},
+			Spec:    *spec.DatabaseMigration,
+			Image:   *spec.Image,
 			Options: config.NewAPIOptions(spec),
-			Enabled: *spec.Console.Enabled,
 		},
 		GrafanaDashboardSpec: *spec.GrafanaDashboard,
 		Config:               spec.Config,
 	}
 }
 
+// Workloads returns the list of Deployment based workloads
+func (gen *Generator) Workloads() []deployment_workload.WithCanary {
+	return []deployment_workload.WithCanary{
+		{Main: &gen.API},
+		{Main: &gen.Que},
+	}
+}
+
+// DatabaseMigrations returns the gate that holds the rollout of the api and que
+// Deployments to a new image until the database migrations have run with it. The
+// database is considered to be migrated to the image that the live zync Deployment
+// runs.
+func (gen *Generator) DatabaseMigrations() []migration_workload.Gate {
+	return []migration_workload.Gate{{
+		Workload:   &gen.DatabaseMigration,
+		Deployment: gen.API.GetKey(),
+		Images:     []*saasv1alpha1.ImageSpec{&gen.API.Image, &gen.Que.Image},
+	}}
+}
+
 // Resources returns the list of templates
 func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 	app_resources, err := deployment_workload.New(&gen.API, nil)
@@ -122,13 +164,13 @@ func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 			pod.GenerateExternalSecretFn("zync", gen.GetNamespace(),
 				*gen.Config.ExternalSecret.SecretStoreRef.Name, *gen.Config.ExternalSecret.SecretStoreRef.Kind,
 				*gen.Config.ExternalSecret.RefreshInterval, gen.GetLabels(), gen.API.Options),
-		),
+		).WithEnabled(pod.HasExternalSecretData("zync", gen.API.Options)),
 	}
 
 	return operatorutil.ConcatSlices(
 		app_resources,
 		que_resources,
-		gen.Console.StatefulSet(),
+		statefulset_workload.New(&gen.Console),
 		misc,
 	), nil
 }
@@ -137,10 +179,11 @@ func (gen *Generator) Resources() ([]resource.TemplateInterface, error) {
 // Zync environment
 type APIGenerator struct {
 	generators.BaseOptionsV2
-	Image   saasv1alpha1.ImageSpec
-	APISpec saasv1alpha1.APISpec
-	Options config.APIOptions
-	Traffic bool
+	Image                 saasv1alpha1.ImageSpec
+	APISpec               saasv1alpha1.APISpec
+	Options               config.APIOptions
+	RolloutOnSecretChange bool
+	Traffic               bool
 }
 
 // Validate that APIGenerator implements deployment_workload.DeploymentWorkload interface
@@ -149,13 +192,20 @@ var _ deployment_workload.DeploymentWorkload = &APIGenerator{}
 // Validate that APIGenerator implements deployment_workload.WithTraffic interface
 var _ deployment_workload.WithTraffic = &APIGenerator{}
 
+// Validate that APIGenerator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &APIGenerator{}
+
 func (gen *APIGenerator) Labels() map[string]string {
 	return gen.GetLabels()
 }
 func (gen *APIGenerator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return resource.NewTemplateFromObjectFunction(gen.deployment).
 		WithMutation(mutators.SetDeploymentReplicas(gen.APISpec.HPA.IsDeactivated())).
-		WithMutation(mutators.RolloutTrigger{Name: "zync", SecretName: util.Pointer("zync")}.Add())
+		WithMutations(pod.SecretRolloutTriggers(gen.RolloutOnSecretChange, gen.Options, gen.APISpec.EnvironmentSpec))
+}
+
+func (gen *APIGenerator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.APISpec.DeploymentStrategy
 }
 
 func (gen *APIGenerator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
@@ -181,23 +231,51 @@ func (gen *APIGenerator) TrafficSelector() map[string]string {
 	}
 }
 
+// DatabaseMigrationGenerator has methods to generate the Job that
+// runs the database migrations of zync
+type DatabaseMigrationGenerator struct {
+	generators.BaseOptionsV2
+	Spec    saasv1alpha1.DatabaseMigrationSpec
+	Image   saasv1alpha1.ImageSpec
+	Options config.APIOptions
+}
+
+// Validate that DatabaseMigrationGenerator implements migration_workload.MigrationWorkload interface
+var _ migration_workload.MigrationWorkload = &DatabaseMigrationGenerator{}
+
+func (gen *DatabaseMigrationGenerator) DatabaseMigrationSpec() *saasv1alpha1.DatabaseMigrationSpec {
+	return &gen.Spec
+}
+
+func (gen *DatabaseMigrationGenerator) Job(image string) *batchv1.Job {
+	return gen.job(image)
+}
+
 // QueGenerator has methods to generate resources for a
 // Que environment
 type QueGenerator struct {
 	generators.BaseOptionsV2
-	Image   saasv1alpha1.ImageSpec
-	QueSpec saasv1alpha1.QueSpec
-	Options config.QueOptions
+	Image                 saasv1alpha1.ImageSpec
+	QueSpec               saasv1alpha1.QueSpec
+	Options               config.QueOptions
+	RolloutOnSecretChange bool
 }
 
 // Validate that QueGenerator implements deployment_workload.DeploymentWorkload interface
 var _ deployment_workload.DeploymentWorkload = &QueGenerator{}
 
+// Validate that QueGenerator implements deployment_workload.WithBlueGreen interface
+var _ deployment_workload.WithBlueGreen = &QueGenerator{}
+
 func (gen *QueGenerator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return resource.NewTemplateFromObjectFunction(gen.deployment).
 		WithMutation(mutators.SetDeploymentReplicas(gen.QueSpec.HPA.IsDeactivated())).
-		WithMutation(mutators.RolloutTrigger{Name: "zync", SecretName: util.Pointer("zync")}.Add())
+		WithMutations(pod.SecretRolloutTriggers(gen.RolloutOnSecretChange, gen.Options, gen.QueSpec.EnvironmentSpec))
 }
+func (gen *QueGenerator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.QueSpec.DeploymentStrategy
+}
+
 func (gen *QueGenerator) HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec {
 	return gen.QueSpec.HPA
 }
@@ -213,16 +291,21 @@ func (gen *QueGenerator) MonitoredEndpoints() []monitoringv1.PodMetricsEndpoint
 // ConsoleGenerator has methods to generate resources for zync-console
 type ConsoleGenerator struct {
 	generators.BaseOptionsV2
-	Image   saasv1alpha1.ImageSpec
-	Spec    saasv1alpha1.ZyncRailsConsoleSpec
-	Options config.APIOptions
-	Enabled bool
+	Image                 saasv1alpha1.ImageSpec
+	Spec                  saasv1alpha1.ZyncRailsConsoleSpec
+	Options               config.APIOptions
+	RolloutOnSecretChange bool
+	Enabled               bool
 }
 
-func (gen *ConsoleGenerator) StatefulSet() []resource.TemplateInterface {
-	return []resource.TemplateInterface{
-		resource.NewTemplateFromObjectFunction(gen.statefulset).
-			WithEnabled(gen.Enabled).
-			WithMutation(mutators.RolloutTrigger{Name: "zync", SecretName: util.Pointer("zync")}.Add()),
-	}
+// Validate that ConsoleGenerator implements statefulset_workload.StatefulSetWorkload interface
+var _ statefulset_workload.StatefulSetWorkload = &ConsoleGenerator{}
+
+func (gen *ConsoleGenerator) StatefulSet() *resource.Template[*appsv1.StatefulSet] {
+	return resource.NewTemplateFromObjectFunction(gen.statefulset).
+		WithEnabled(gen.Enabled).
+		WithMutations(pod.SecretRolloutTriggers(gen.RolloutOnSecretChange, gen.Options, gen.Spec.EnvironmentSpec))
+}
+func (gen *ConsoleGenerator) RolloutSpec() *saasv1alpha1.StatefulSetRolloutSpec {
+	return gen.Spec.Rollout
 }
@@ -14,13 +14,7 @@ func (gen *QueGenerator) deployment() *appsv1.Deployment {
 	dep := &appsv1.Deployment{
 		Spec: appsv1.DeploymentSpec{
 			Replicas: gen.QueSpec.Replicas,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: util.Pointer(intstr.FromInt(0)),
-					MaxSurge:       util.Pointer(intstr.FromInt(1)),
-				},
-			},
+			Strategy: gen.QueSpec.DeploymentStrategy.DeploymentStrategy(),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
 					ImagePullSecrets: func() []corev1.LocalObjectReference {
@@ -63,7 +57,7 @@ func (gen *QueGenerator) deployment() *appsv1.Deployment {
 										},
 									},
 								)
-								return envVars
+								return pod.MergeEnvironment(envVars, gen.QueSpec.EnvironmentSpec)
 							}(),
 							Resources:       corev1.ResourceRequirements(*gen.QueSpec.Resources),
 							ImagePullPolicy: *gen.Image.PullPolicy,
@@ -78,5 +72,7 @@ func (gen *QueGenerator) deployment() *appsv1.Deployment {
 			},
 		},
 	}
+
+	dep.Spec.Template = pod.AddSecretFiles(dep.Spec.Template, gen.Options)
 	return dep
 }
@@ -0,0 +1,68 @@
+package certificate
+
+import (
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// New returns a basereconciler_types.GeneratorFunction function that will return a cert-manager
+// Certificate resource that issues a client certificate into the Secret with the same name
+func New(key types.NamespacedName, labels map[string]string,
+	cfg saasv1alpha1.ClusterClientCertificate) func(client.Object) (*certmanagerv1.Certificate, error) {
+
+	return func(client.Object) (*certmanagerv1.Certificate, error) {
+
+		cert := &certmanagerv1.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels:    labels,
+			},
+			Spec: certmanagerv1.CertificateSpec{
+				SecretName: key.Name,
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  cfg.IssuerRef.Name,
+					Kind:  certmanagerv1.IssuerKind,
+					Group: certmanagerv1.SchemeGroupVersion.Group,
+				},
+				DNSNames:    cfg.DNSNames,
+				Duration:    cfg.Duration,
+				RenewBefore: cfg.RenewBefore,
+				Usages: []certmanagerv1.KeyUsage{
+					certmanagerv1.UsageDigitalSignature,
+					certmanagerv1.UsageKeyEncipherment,
+					certmanagerv1.UsageClientAuth,
+				},
+			},
+		}
+		if cfg.IssuerRef.Kind != nil {
+			cert.Spec.IssuerRef.Kind = *cfg.IssuerRef.Kind
+		}
+		if cfg.CommonName != nil {
+			cert.Spec.CommonName = *cfg.CommonName
+		}
+
+		return cert, nil
+	}
+}
+
+// ClientCertificates returns the configuration of the cert-manager Certificates that issue
+// the client certificates of the clusters in the given envoy dynamic configurations, indexed
+// by the name of the Secret that holds each certificate
+func ClientCertificates(resources ...descriptor.EnvoyDynamicConfigDescriptor) map[string]saasv1alpha1.ClusterClientCertificate {
+	certs := map[string]saasv1alpha1.ClusterClientCertificate{}
+	for _, res := range resources {
+		cluster, ok := res.GetOptions().(*saasv1alpha1.Cluster)
+		if !ok || cluster.UpstreamTLS == nil ||
+			cluster.UpstreamTLS.ClientCertificate == nil || cluster.UpstreamTLS.ClientCertificateSecretName == nil {
+			continue
+		}
+		certs[*cluster.UpstreamTLS.ClientCertificateSecretName] = *cluster.UpstreamTLS.ClientCertificate
+	}
+	return certs
+}
@@ -0,0 +1,138 @@
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/go-test/deep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNew(t *testing.T) {
+	usages := []certmanagerv1.KeyUsage{
+		certmanagerv1.UsageDigitalSignature,
+		certmanagerv1.UsageKeyEncipherment,
+		certmanagerv1.UsageClientAuth,
+	}
+	tests := []struct {
+		name string
+		cfg  saasv1alpha1.ClusterClientCertificate
+		want *certmanagerv1.Certificate
+	}{
+		{
+			name: "Defaults to a namespaced Issuer",
+			cfg: saasv1alpha1.ClusterClientCertificate{
+				IssuerRef: saasv1alpha1.CertificateIssuerRef{Name: "issuer"},
+			},
+			want: &certmanagerv1.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "ns", Labels: map[string]string{"app": "test"}},
+				Spec: certmanagerv1.CertificateSpec{
+					SecretName: "client-cert",
+					IssuerRef:  cmmeta.ObjectReference{Name: "issuer", Kind: "Issuer", Group: "cert-manager.io"},
+					Usages:     usages,
+				},
+			},
+		},
+		{
+			name: "Generates a Certificate signed by a ClusterIssuer",
+			cfg: saasv1alpha1.ClusterClientCertificate{
+				IssuerRef:   saasv1alpha1.CertificateIssuerRef{Name: "issuer", Kind: util.Pointer("ClusterIssuer")},
+				CommonName:  util.Pointer("client"),
+				DNSNames:    []string{"client.example.com"},
+				Duration:    &metav1.Duration{Duration: 720 * time.Hour},
+				RenewBefore: &metav1.Duration{Duration: 240 * time.Hour},
+			},
+			want: &certmanagerv1.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "ns", Labels: map[string]string{"app": "test"}},
+				Spec: certmanagerv1.CertificateSpec{
+					SecretName:  "client-cert",
+					IssuerRef:   cmmeta.ObjectReference{Name: "issuer", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+					CommonName:  "client",
+					DNSNames:    []string{"client.example.com"},
+					Duration:    &metav1.Duration{Duration: 720 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 240 * time.Hour},
+					Usages:      usages,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(types.NamespacedName{Name: "client-cert", Namespace: "ns"},
+				map[string]string{"app": "test"}, tt.cfg)(nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Errorf("New() = diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestClientCertificates(t *testing.T) {
+	cert := saasv1alpha1.ClusterClientCertificate{
+		IssuerRef: saasv1alpha1.CertificateIssuerRef{Name: "issuer"},
+	}
+	tests := []struct {
+		name      string
+		resources []descriptor.EnvoyDynamicConfigDescriptor
+		want      map[string]saasv1alpha1.ClusterClientCertificate
+	}{
+		{
+			name: "Returns the Certificates of the clusters",
+			resources: saasv1alpha1.MapOfEnvoyDynamicConfig{
+				"with_certificate": {
+					Cluster: &saasv1alpha1.Cluster{
+						Host: "upstream", Port: 443,
+						UpstreamTLS: &saasv1alpha1.ClusterUpstreamTLS{
+							ClientCertificateSecretName: util.Pointer("client-cert"),
+							ClientCertificate:           &cert,
+						},
+					},
+				},
+				"with_secret": {
+					Cluster: &saasv1alpha1.Cluster{
+						Host: "upstream", Port: 443,
+						UpstreamTLS: &saasv1alpha1.ClusterUpstreamTLS{
+							ClientCertificateSecretName: util.Pointer("provided-cert"),
+						},
+					},
+				},
+				"without_tls": {
+					Cluster: &saasv1alpha1.Cluster{Host: "upstream", Port: 80},
+				},
+				"listener": {
+					ListenerHttp: &saasv1alpha1.ListenerHttp{Port: 8080, RouteConfigName: "route"},
+				},
+			}.AsList(),
+			want: map[string]saasv1alpha1.ClusterClientCertificate{"client-cert": cert},
+		},
+		{
+			name: "Ignores the Certificate if the Secret name is unset",
+			resources: saasv1alpha1.MapOfEnvoyDynamicConfig{
+				"cluster": {
+					Cluster: &saasv1alpha1.Cluster{
+						Host: "upstream", Port: 443,
+						UpstreamTLS: &saasv1alpha1.ClusterUpstreamTLS{ClientCertificate: &cert},
+					},
+				},
+			}.AsList(),
+			want: map[string]saasv1alpha1.ClusterClientCertificate{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClientCertificates(tt.resources...)
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Errorf("ClientCertificates() = diff %v", diff)
+			}
+		})
+	}
+}
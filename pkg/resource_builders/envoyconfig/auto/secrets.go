@@ -1,19 +1,22 @@
 package auto
 
 import (
+	"fmt"
+
 	marin3rv1alpha1 "github.com/3scale-ops/marin3r/apis/marin3r/v1alpha1"
 	"github.com/3scale-ops/marin3r/pkg/envoy"
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_extensions_transport_sockets_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/samber/lo"
 )
 
+// GenerateSecrets returns the list of SDS certificate secrets used by the
+// listeners (server certificates) and clusters (client certificates)
 func GenerateSecrets(resources []envoy.Resource) ([]marin3rv1alpha1.EnvoySecretResource, error) {
 
 	refs := []string{}
-
 	for _, res := range resources {
-
 		switch o := res.(type) {
 
 		case *envoy_config_listener_v3.Listener:
@@ -23,6 +26,12 @@ func GenerateSecrets(resources []envoy.Resource) ([]marin3rv1alpha1.EnvoySecretR
 			}
 			refs = append(refs, secrets...)
 
+		case *envoy_config_cluster_v3.Cluster:
+			secrets, err := secretRefsFromCluster(o)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, secrets...)
 		}
 	}
 
@@ -34,21 +43,91 @@ func GenerateSecrets(resources []envoy.Resource) ([]marin3rv1alpha1.EnvoySecretR
 	return secrets, nil
 }
 
+// GenerateValidationContexts returns the list of SDS validation context secrets
+// used by the listeners (to verify clients) and clusters (to verify upstream hosts)
+func GenerateValidationContexts(resources []envoy.Resource) ([]string, error) {
+
+	refs := []string{}
+	for _, res := range resources {
+		var tlsContext *envoy_extensions_transport_sockets_tls_v3.CommonTlsContext
+		var err error
+
+		switch o := res.(type) {
+		case *envoy_config_listener_v3.Listener:
+			tlsContext, err = listenerTlsContext(o)
+		case *envoy_config_cluster_v3.Cluster:
+			tlsContext, err = clusterTlsContext(o)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if sds := tlsContext.GetValidationContextSdsSecretConfig(); sds != nil {
+			refs = append(refs, sds.Name)
+		}
+	}
+
+	return lo.Uniq(refs), nil
+}
+
 func secretRefsFromListener(listener *envoy_config_listener_v3.Listener) ([]string, error) {
+	tlsContext, err := listenerTlsContext(listener)
+	if err != nil {
+		return nil, err
+	}
+	return certificateRefs(tlsContext), nil
+}
 
-	if listener.FilterChains[0].TransportSocket == nil {
-		return nil, nil
+func secretRefsFromCluster(cluster *envoy_config_cluster_v3.Cluster) ([]string, error) {
+	tlsContext, err := clusterTlsContext(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return certificateRefs(tlsContext), nil
+}
+
+func certificateRefs(tlsContext *envoy_extensions_transport_sockets_tls_v3.CommonTlsContext) []string {
+	if tlsContext == nil {
+		return nil
 	}
 
 	secrets := []string{}
+	for _, sdsConfig := range tlsContext.TlsCertificateSdsSecretConfigs {
+		secrets = append(secrets, sdsConfig.Name)
+	}
+	return lo.Uniq(secrets)
+}
+
+func listenerTlsContext(listener *envoy_config_listener_v3.Listener) (*envoy_extensions_transport_sockets_tls_v3.CommonTlsContext, error) {
+	if len(listener.FilterChains) == 0 || listener.FilterChains[0].TransportSocket == nil {
+		return nil, nil
+	}
+
 	proto, err := listener.FilterChains[0].TransportSocket.GetTypedConfig().UnmarshalNew()
 	if err != nil {
 		return nil, err
 	}
-	tlsContext := proto.(*envoy_extensions_transport_sockets_tls_v3.DownstreamTlsContext)
-	for _, sdsConfig := range tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs {
-		secrets = append(secrets, sdsConfig.Name)
+	tlsContext, ok := proto.(*envoy_extensions_transport_sockets_tls_v3.DownstreamTlsContext)
+	if !ok {
+		return nil, fmt.Errorf("unexpected transport socket in listener '%s'", listener.Name)
+	}
+
+	return tlsContext.CommonTlsContext, nil
+}
+
+func clusterTlsContext(cluster *envoy_config_cluster_v3.Cluster) (*envoy_extensions_transport_sockets_tls_v3.CommonTlsContext, error) {
+	if cluster.TransportSocket == nil {
+		return nil, nil
+	}
+
+	proto, err := cluster.TransportSocket.GetTypedConfig().UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	tlsContext, ok := proto.(*envoy_extensions_transport_sockets_tls_v3.UpstreamTlsContext)
+	if !ok {
+		return nil, fmt.Errorf("unexpected transport socket in cluster '%s'", cluster.Name)
 	}
 
-	return lo.Uniq(secrets), nil
+	return tlsContext.CommonTlsContext, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/3scale-ops/marin3r/pkg/envoy"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/templates"
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 )
 
@@ -106,6 +107,25 @@ func TestGenerateSecrets(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Generates envoy secret resources for cluster client certificates",
+			args: args{
+				resources: []envoy.Resource{
+					&envoy_config_cluster_v3.Cluster{
+						Name:            "cluster1",
+						TransportSocket: templates.UpstreamTransportSocket_v1("example.com", false, util.Pointer("client1"), util.Pointer("ca")),
+					},
+					&envoy_config_cluster_v3.Cluster{
+						Name:            "cluster2",
+						TransportSocket: templates.UpstreamTransportSocket_v1("example.com", false, nil, nil),
+					},
+				},
+			},
+			want: []marin3rv1alpha1.EnvoySecretResource{
+				{Name: "client1"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -120,3 +140,59 @@ func TestGenerateSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateValidationContexts(t *testing.T) {
+	type args struct {
+		resources []envoy.Resource
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Returns the validation contexts of listeners and clusters",
+			args: args{
+				resources: []envoy.Resource{
+					func() envoy.Resource {
+						l, _ := templates.ListenerHTTP_v2("test", &saasv1alpha1.ListenerHttp{
+							Port:                  8080,
+							RouteConfigName:       "my_route",
+							CertificateSecretName: util.Pointer("cert"),
+							EnableHttp2:           util.Pointer(false),
+							ProxyProtocol:         util.Pointer(false),
+							ClientValidation: &saasv1alpha1.ListenerClientValidation{
+								CASecretName:             "clients-ca",
+								RequireClientCertificate: util.Pointer(true),
+							},
+						})
+						return l
+					}(),
+					&envoy_config_cluster_v3.Cluster{
+						Name:            "cluster1",
+						TransportSocket: templates.UpstreamTransportSocket_v1("example.com", false, nil, util.Pointer("upstream-ca")),
+					},
+					&envoy_config_cluster_v3.Cluster{
+						Name:            "cluster2",
+						TransportSocket: templates.UpstreamTransportSocket_v1("example.com", false, nil, util.Pointer("upstream-ca")),
+					},
+				},
+			},
+			want:    []string{"clients-ca", "upstream-ca"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateValidationContexts(tt.args.resources)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateValidationContexts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateValidationContexts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
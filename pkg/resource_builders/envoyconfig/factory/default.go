@@ -9,11 +9,17 @@ import (
 )
 
 var f = EnvoyDynamicConfigFactory{
-	"ListenerHttp_v1":       RegisterTemplate(templates.ListenerHTTP_v1, &envoy_config_listener_v3.Listener{}),
-	"Cluster_v1":            RegisterTemplate(templates.Cluster_v1, &envoy_config_cluster_v3.Cluster{}),
-	"RouteConfiguration_v1": RegisterTemplate(templates.RouteConfiguration_v1, &envoy_config_route_v3.RouteConfiguration{}),
-	"Runtime_v1":            RegisterTemplate(templates.Runtime_v1, &envoy_service_runtime_v3.Runtime{}),
-	"RawConfig_v1":          RegisterTemplate(templates.RawConfig_v1, nil),
+	"ListenerHttp_v1":        RegisterTemplate(templates.ListenerHTTP_v1, &envoy_config_listener_v3.Listener{}),
+	"Cluster_v1":             RegisterTemplate(templates.Cluster_v1, &envoy_config_cluster_v3.Cluster{}),
+	"RouteConfiguration_v1":  RegisterTemplate(templates.RouteConfiguration_v1, &envoy_config_route_v3.RouteConfiguration{}),
+	"Runtime_v1":             RegisterTemplate(templates.Runtime_v1, &envoy_service_runtime_v3.Runtime{}),
+	"RawConfig_v1":           RegisterTemplate(templates.RawConfig_v1, nil),
+	"ListenerRedisProxy_v1":  RegisterTemplate(templates.ListenerRedisProxy_v1, &envoy_config_listener_v3.Listener{}),
+	"ClusterShardedRedis_v1": RegisterTemplate(templates.ClusterShardedRedis_v1, &envoy_config_cluster_v3.Cluster{}),
+	"ListenerTcpProxy_v1":    RegisterTemplate(templates.ListenerTcpProxy_v1, &envoy_config_listener_v3.Listener{}),
+	"Cluster_v2":             RegisterTemplate(templates.Cluster_v2, &envoy_config_cluster_v3.Cluster{}),
+	"RouteConfiguration_v2":  RegisterTemplate(templates.RouteConfiguration_v2, &envoy_config_route_v3.RouteConfiguration{}),
+	"ListenerHttp_v2":        RegisterTemplate(templates.ListenerHTTP_v2, &envoy_config_listener_v3.Listener{}),
 }
 
 func Default() EnvoyDynamicConfigFactory {
@@ -40,6 +40,9 @@ func (factory EnvoyDynamicConfigFactory) GetClass(v descriptor.EnvoyDynamicConfi
 	return class, nil
 }
 
+// NewResource generates the envoy dynamic resource described by the given descriptor
+// and validates it using the protobuf validation rules of the Envoy API. A ValidationError
+// is returned if the resource cannot be generated or is not valid.
 func (factory EnvoyDynamicConfigFactory) NewResource(desc descriptor.EnvoyDynamicConfigDescriptor) (envoy.Resource, error) {
 
 	class, err := factory.GetClass(desc)
@@ -49,7 +52,29 @@ func (factory EnvoyDynamicConfigFactory) NewResource(desc descriptor.EnvoyDynami
 
 	resource, err := class.Function(desc.GetName(), desc.GetOptions())
 	if err != nil {
-		return nil, err
+		return nil, &ValidationError{Name: desc.GetName(), Err: err}
+	}
+
+	if v, ok := resource.(interface{ ValidateAll() error }); ok {
+		if err := v.ValidateAll(); err != nil {
+			return nil, &ValidationError{Name: desc.GetName(), Err: err}
+		}
 	}
+
 	return resource, nil
 }
+
+// ValidationError is returned when an envoy dynamic resource
+// cannot be generated from its descriptor or is not valid
+type ValidationError struct {
+	Name string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid envoy dynamic config '%s': %s", e.Name, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
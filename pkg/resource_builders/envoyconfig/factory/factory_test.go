@@ -89,6 +89,19 @@ func TestEnvoyDynamicConfigFactory_NewResource(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name:    "Fails validation",
+			factory: testFactory,
+			args: args{
+				descriptor: &testDescriptor{
+					name:             "",
+					generatorVersion: "v1",
+					opts:             &testOptions{},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "Unregistered class",
 			factory: testFactory,
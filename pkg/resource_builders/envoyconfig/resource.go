@@ -16,6 +16,7 @@ import (
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_service_runtime_v3 "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -48,46 +49,23 @@ func newFromProtos(key types.NamespacedName, nodeID string, resources []envoy.Re
 
 	return func() (*marin3rv1alpha1.EnvoyConfig, error) {
 
-		clusters := []marin3rv1alpha1.EnvoyResource{}
-		routes := []marin3rv1alpha1.EnvoyResource{}
-		listeners := []marin3rv1alpha1.EnvoyResource{}
-		runtimes := []marin3rv1alpha1.EnvoyResource{}
 		secrets, err := auto.GenerateSecrets(resources)
 		if err != nil {
 			return nil, err
 		}
-
-		for i := range resources {
-
-			j, err := envoy_serializer_v3.JSON{}.Marshal(resources[i])
-			if err != nil {
-				return nil, err
-			}
-			y, err := yaml.JSONToYAML([]byte(j))
-			if err != nil {
-				return nil, err
-			}
-
-			switch resources[i].(type) {
-
-			case *envoy_config_cluster_v3.Cluster:
-				clusters = append(clusters, marin3rv1alpha1.EnvoyResource{Value: string(y)})
-
-			case *envoy_config_route_v3.RouteConfiguration:
-				routes = append(routes, marin3rv1alpha1.EnvoyResource{Value: string(y)})
-
-			case *envoy_config_listener_v3.Listener:
-				listeners = append(listeners, marin3rv1alpha1.EnvoyResource{Value: string(y)})
-
-			case *envoy_service_runtime_v3.Runtime:
-				runtimes = append(runtimes, marin3rv1alpha1.EnvoyResource{Value: string(y)})
-
-			default:
-				return nil, fmt.Errorf("unknown dynamic configuration type")
+		validationContexts, err := auto.GenerateValidationContexts(resources)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range validationContexts {
+			for _, secret := range secrets {
+				if secret.Name == name {
+					return nil, fmt.Errorf("secret '%s' cannot be used both as a certificate and as a validation context", name)
+				}
 			}
 		}
 
-		return &marin3rv1alpha1.EnvoyConfig{
+		ec := &marin3rv1alpha1.EnvoyConfig{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      key.Name,
 				Namespace: key.Namespace,
@@ -96,15 +74,122 @@ func newFromProtos(key types.NamespacedName, nodeID string, resources []envoy.Re
 				EnvoyAPI:      util.Pointer(envoy.APIv3),
 				NodeID:        nodeID,
 				Serialization: util.Pointer(envoy_serializer.YAML),
-				EnvoyResources: &marin3rv1alpha1.EnvoyResources{
-					Clusters:  clusters,
-					Routes:    routes,
-					Listeners: listeners,
-					Runtimes:  runtimes,
-					Secrets:   secrets,
-				},
 			},
-		}, nil
+		}
+
+		// validation context secrets can only be generated using the "resources"
+		// field of the EnvoyConfig, so the "envoyResources" field is used unless
+		// there is a need for them
+		if len(validationContexts) > 0 {
+			ec.Spec.Resources, err = resourcesFromProtos(resources, secrets, validationContexts)
+		} else {
+			ec.Spec.EnvoyResources, err = envoyResourcesFromProtos(resources, secrets)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return ec, nil
+	}
+}
+
+func envoyResourcesFromProtos(resources []envoy.Resource, secrets []marin3rv1alpha1.EnvoySecretResource) (*marin3rv1alpha1.EnvoyResources, error) {
+
+	clusters := []marin3rv1alpha1.EnvoyResource{}
+	routes := []marin3rv1alpha1.EnvoyResource{}
+	listeners := []marin3rv1alpha1.EnvoyResource{}
+	runtimes := []marin3rv1alpha1.EnvoyResource{}
+
+	for i := range resources {
+
+		j, err := envoy_serializer_v3.JSON{}.Marshal(resources[i])
+		if err != nil {
+			return nil, err
+		}
+		y, err := yaml.JSONToYAML([]byte(j))
+		if err != nil {
+			return nil, err
+		}
+
+		rType, err := resourceType(resources[i])
+		if err != nil {
+			return nil, err
+		}
+
+		switch rType {
+		case envoy.Cluster:
+			clusters = append(clusters, marin3rv1alpha1.EnvoyResource{Value: string(y)})
+		case envoy.Route:
+			routes = append(routes, marin3rv1alpha1.EnvoyResource{Value: string(y)})
+		case envoy.Listener:
+			listeners = append(listeners, marin3rv1alpha1.EnvoyResource{Value: string(y)})
+		case envoy.Runtime:
+			runtimes = append(runtimes, marin3rv1alpha1.EnvoyResource{Value: string(y)})
+		}
+	}
+
+	return &marin3rv1alpha1.EnvoyResources{
+		Clusters:  clusters,
+		Routes:    routes,
+		Listeners: listeners,
+		Runtimes:  runtimes,
+		Secrets:   secrets,
+	}, nil
+}
+
+func resourcesFromProtos(resources []envoy.Resource, secrets []marin3rv1alpha1.EnvoySecretResource,
+	validationContexts []string) ([]marin3rv1alpha1.Resource, error) {
+
+	list := make([]marin3rv1alpha1.Resource, 0, len(resources)+len(secrets)+len(validationContexts))
+
+	for i := range resources {
+
+		j, err := envoy_serializer_v3.JSON{}.Marshal(resources[i])
+		if err != nil {
+			return nil, err
+		}
+
+		rType, err := resourceType(resources[i])
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, marin3rv1alpha1.Resource{
+			Type:  rType,
+			Value: &runtime.RawExtension{Raw: []byte(j)},
+		})
+	}
+
+	for _, secret := range secrets {
+		list = append(list, marin3rv1alpha1.Resource{
+			Type:                  envoy.Secret,
+			GenerateFromTlsSecret: util.Pointer(secret.Name),
+			Blueprint:             util.Pointer(marin3rv1alpha1.TlsCertificate),
+		})
+	}
+
+	for _, name := range validationContexts {
+		list = append(list, marin3rv1alpha1.Resource{
+			Type:                  envoy.Secret,
+			GenerateFromTlsSecret: util.Pointer(name),
+			Blueprint:             util.Pointer(marin3rv1alpha1.TlsValidationContext),
+		})
+	}
+
+	return list, nil
+}
 
+func resourceType(resource envoy.Resource) (envoy.Type, error) {
+	switch resource.(type) {
+	case *envoy_config_cluster_v3.Cluster:
+		return envoy.Cluster, nil
+	case *envoy_config_route_v3.RouteConfiguration:
+		return envoy.Route, nil
+	case *envoy_config_listener_v3.Listener:
+		return envoy.Listener, nil
+	case *envoy_service_runtime_v3.Runtime:
+		return envoy.Runtime, nil
+	default:
+		return "", fmt.Errorf("unknown dynamic configuration type")
 	}
 }
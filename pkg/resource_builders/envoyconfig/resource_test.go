@@ -11,6 +11,7 @@ import (
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/factory"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/templates"
 	"github.com/MakeNowJust/heredoc"
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -22,6 +23,7 @@ import (
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"google.golang.org/protobuf/types/known/durationpb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -304,6 +306,62 @@ func Test_newFromProtos(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Generates an EnvoyConfig with validation context secrets",
+			args: args{
+				key:    types.NamespacedName{Name: "test", Namespace: "ns"},
+				nodeID: "test",
+				resources: []envoy.Resource{
+					&envoy_config_cluster_v3.Cluster{
+						Name: "cluster1",
+						LoadAssignment: &envoy_config_endpoint_v3.ClusterLoadAssignment{
+							ClusterName: "cluster1",
+						},
+						TransportSocket: templates.UpstreamTransportSocket_v1("example.com", false, util.Pointer("client"), util.Pointer("ca")),
+					},
+				},
+			},
+			want: &marin3rv1alpha1.EnvoyConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+				Spec: marin3rv1alpha1.EnvoyConfigSpec{
+					NodeID:        "test",
+					Serialization: util.Pointer(envoy_serializer.YAML),
+					EnvoyAPI:      util.Pointer(envoy.APIv3),
+					Resources: []marin3rv1alpha1.Resource{
+						{
+							Type:  envoy.Cluster,
+							Value: &runtime.RawExtension{Raw: []byte(`{"name":"cluster1","load_assignment":{"cluster_name":"cluster1"},"transport_socket":{"name":"envoy.transport_sockets.tls","typed_config":{"@type":"type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext","common_tls_context":{"tls_params":{"tls_minimum_protocol_version":"TLSv1_2"},"tls_certificate_sds_secret_configs":[{"name":"client","sds_config":{"ads":{},"resource_api_version":"V3"}}],"validation_context_sds_secret_config":{"name":"ca","sds_config":{"ads":{},"resource_api_version":"V3"}},"alpn_protocols":["http/1.1"]},"sni":"example.com"}}}`)},
+						},
+						{
+							Type:                  envoy.Secret,
+							GenerateFromTlsSecret: util.Pointer("client"),
+							Blueprint:             util.Pointer(marin3rv1alpha1.TlsCertificate),
+						},
+						{
+							Type:                  envoy.Secret,
+							GenerateFromTlsSecret: util.Pointer("ca"),
+							Blueprint:             util.Pointer(marin3rv1alpha1.TlsValidationContext),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Fails if a secret is used as certificate and as validation context",
+			args: args{
+				key:    types.NamespacedName{Name: "test", Namespace: "ns"},
+				nodeID: "test",
+				resources: []envoy.Resource{
+					&envoy_config_cluster_v3.Cluster{
+						Name:            "cluster1",
+						TransportSocket: templates.UpstreamTransportSocket_v1("example.com", false, util.Pointer("cert"), util.Pointer("cert")),
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoy_extensions_transport_sockets_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_extensions_upstreams_http_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -43,6 +44,27 @@ func Cluster_v1(name string, opts interface{}) (envoy.Resource, error) {
 		},
 	}
 
+	// when the cluster is split between the main and the canary Deployments
+	// there is one endpoint per Deployment, with the corresponding weight
+	if len(o.WeightedUpstreams) > 0 {
+		endpoints := make([]*envoy_config_endpoint_v3.LbEndpoint, 0, len(o.WeightedUpstreams))
+		for _, upstream := range o.WeightedUpstreams {
+			// envoy does not allow endpoints with a weight of 0
+			if upstream.Weight == 0 {
+				continue
+			}
+			endpoints = append(endpoints, &envoy_config_endpoint_v3.LbEndpoint{
+				HostIdentifier: &envoy_config_endpoint_v3.LbEndpoint_Endpoint{
+					Endpoint: &envoy_config_endpoint_v3.Endpoint{
+						Address: Address_v1(upstream.Host, upstream.Port),
+					},
+				},
+				LoadBalancingWeight: wrapperspb.UInt32(upstream.Weight),
+			})
+		}
+		cluster.LoadAssignment.Endpoints[0].LbEndpoints = endpoints
+	}
+
 	if *o.IsHttp2 {
 		any, err := anypb.New(&envoy_extensions_upstreams_http_v3.HttpProtocolOptions{
 			UpstreamProtocolOptions: &envoy_extensions_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig_{
@@ -66,3 +88,187 @@ func Cluster_v1(name string, opts interface{}) (envoy.Resource, error) {
 
 	return cluster, nil
 }
+
+func Cluster_v2(name string, opts interface{}) (envoy.Resource, error) {
+	o := opts.(*saasv1alpha1.Cluster)
+
+	resource, err := Cluster_v1(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	cluster := resource.(*envoy_config_cluster_v3.Cluster)
+
+	if o.ConnectTimeout != nil {
+		cluster.ConnectTimeout = durationpb.New(o.ConnectTimeout.Duration)
+	}
+	if o.HealthCheck != nil {
+		cluster.HealthChecks = []*envoy_config_core_v3.HealthCheck{HealthCheck_v1(o.Host, o.HealthCheck)}
+	}
+	if o.OutlierDetection != nil {
+		cluster.OutlierDetection = OutlierDetection_v1(o.OutlierDetection)
+	}
+	if o.CircuitBreakers != nil {
+		cluster.CircuitBreakers = CircuitBreakers_v1(o.CircuitBreakers)
+	}
+	if o.UpstreamTLS != nil {
+		sni := o.Host
+		if o.UpstreamTLS.SNI != nil {
+			sni = *o.UpstreamTLS.SNI
+		}
+		cluster.TransportSocket = UpstreamTransportSocket_v1(sni, *o.IsHttp2,
+			o.UpstreamTLS.ClientCertificateSecretName, o.UpstreamTLS.CASecretName)
+	}
+
+	return cluster, nil
+}
+
+func HealthCheck_v1(host string, opts *saasv1alpha1.ClusterHealthCheck) *envoy_config_core_v3.HealthCheck {
+	hc := &envoy_config_core_v3.HealthCheck{
+		Timeout:            durationpb.New(opts.Timeout.Duration),
+		Interval:           durationpb.New(opts.Interval.Duration),
+		HealthyThreshold:   wrapperspb.UInt32(valueOrDefault(opts.HealthyThreshold, 1)),
+		UnhealthyThreshold: wrapperspb.UInt32(valueOrDefault(opts.UnhealthyThreshold, 3)),
+	}
+
+	switch opts.Protocol {
+	case "http":
+		hc.HealthChecker = &envoy_config_core_v3.HealthCheck_HttpHealthCheck_{
+			HttpHealthCheck: &envoy_config_core_v3.HealthCheck_HttpHealthCheck{
+				Host: host,
+				Path: valueOrDefault(opts.Path, "/"),
+			},
+		}
+	case "grpc":
+		grpc := &envoy_config_core_v3.HealthCheck_GrpcHealthCheck{}
+		if opts.ServiceName != nil {
+			grpc.ServiceName = *opts.ServiceName
+		}
+		hc.HealthChecker = &envoy_config_core_v3.HealthCheck_GrpcHealthCheck_{GrpcHealthCheck: grpc}
+	default:
+		// an empty tcp health check only checks that the connection succeeds
+		hc.HealthChecker = &envoy_config_core_v3.HealthCheck_TcpHealthCheck_{
+			TcpHealthCheck: &envoy_config_core_v3.HealthCheck_TcpHealthCheck{},
+		}
+	}
+
+	return hc
+}
+
+func OutlierDetection_v1(opts *saasv1alpha1.ClusterOutlierDetection) *envoy_config_cluster_v3.OutlierDetection {
+	od := &envoy_config_cluster_v3.OutlierDetection{
+		Consecutive_5Xx: wrapperspb.UInt32(valueOrDefault(opts.Consecutive5xx, 5)),
+	}
+	if opts.Interval != nil {
+		od.Interval = durationpb.New(opts.Interval.Duration)
+	}
+	if opts.BaseEjectionTime != nil {
+		od.BaseEjectionTime = durationpb.New(opts.BaseEjectionTime.Duration)
+	}
+	if opts.MaxEjectionPercent != nil {
+		od.MaxEjectionPercent = wrapperspb.UInt32(*opts.MaxEjectionPercent)
+	}
+	return od
+}
+
+func CircuitBreakers_v1(opts *saasv1alpha1.ClusterCircuitBreakers) *envoy_config_cluster_v3.CircuitBreakers {
+	uint32Value := func(v *uint32) *wrapperspb.UInt32Value {
+		if v == nil {
+			return nil
+		}
+		return wrapperspb.UInt32(*v)
+	}
+
+	return &envoy_config_cluster_v3.CircuitBreakers{
+		Thresholds: []*envoy_config_cluster_v3.CircuitBreakers_Thresholds{{
+			Priority:           envoy_config_core_v3.RoutingPriority_DEFAULT,
+			MaxConnections:     uint32Value(opts.MaxConnections),
+			MaxPendingRequests: uint32Value(opts.MaxPendingRequests),
+			MaxRequests:        uint32Value(opts.MaxRequests),
+			MaxRetries:         uint32Value(opts.MaxRetries),
+		}},
+	}
+}
+
+// UpstreamTransportSocket_v1 returns a TLS transport socket for the connections to the
+// upstream hosts. The client certificate and the CA used to verify the upstream hosts
+// are optional and are loaded from the SDS secrets with the given names.
+func UpstreamTransportSocket_v1(sni string, http2 bool, clientCertificate, ca *string) *envoy_config_core_v3.TransportSocket {
+	return &envoy_config_core_v3.TransportSocket{
+		Name: "envoy.transport_sockets.tls",
+		ConfigType: &envoy_config_core_v3.TransportSocket_TypedConfig{
+			TypedConfig: func() *anypb.Any {
+				tlsContext := &envoy_extensions_transport_sockets_tls_v3.UpstreamTlsContext{
+					Sni: sni,
+					CommonTlsContext: &envoy_extensions_transport_sockets_tls_v3.CommonTlsContext{
+						TlsParams: &envoy_extensions_transport_sockets_tls_v3.TlsParameters{
+							TlsMinimumProtocolVersion: envoy_extensions_transport_sockets_tls_v3.TlsParameters_TLSv1_2,
+						},
+						AlpnProtocols: func() []string {
+							if http2 {
+								return []string{"h2"}
+							}
+							return []string{"http/1.1"}
+						}(),
+					},
+				}
+				if clientCertificate != nil {
+					tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs = []*envoy_extensions_transport_sockets_tls_v3.SdsSecretConfig{
+						SdsSecretConfig_v1(*clientCertificate),
+					}
+				}
+				if ca != nil {
+					tlsContext.CommonTlsContext.ValidationContextType = &envoy_extensions_transport_sockets_tls_v3.CommonTlsContext_ValidationContextSdsSecretConfig{
+						ValidationContextSdsSecretConfig: SdsSecretConfig_v1(*ca),
+					}
+				}
+				any, err := anypb.New(tlsContext)
+				if err != nil {
+					panic(err)
+				}
+				return any
+			}(),
+		},
+	}
+}
+
+func ClusterShardedRedis_v1(name string, opts interface{}) (envoy.Resource, error) {
+	o := opts.(*saasv1alpha1.ClusterShardedRedis)
+
+	endpoints := make([]*envoy_config_endpoint_v3.LbEndpoint, 0, len(o.Shards))
+	for _, shard := range o.Shards {
+		endpoints = append(endpoints, &envoy_config_endpoint_v3.LbEndpoint{
+			HostIdentifier: &envoy_config_endpoint_v3.LbEndpoint_Endpoint{
+				Endpoint: &envoy_config_endpoint_v3.Endpoint{
+					Address: Address_v1(shard.Host, shard.Port),
+					// the hostname is used to place the shard in the hash ring
+					Hostname: shard.Name,
+				},
+			},
+		})
+	}
+
+	cluster := &envoy_config_cluster_v3.Cluster{
+		Name:           name,
+		ConnectTimeout: durationpb.New(1 * time.Second),
+		ClusterDiscoveryType: &envoy_config_cluster_v3.Cluster_Type{
+			Type: envoy_config_cluster_v3.Cluster_STATIC,
+		},
+		// The redis_proxy filter requires a consistent hashing load balancer
+		LbPolicy: envoy_config_cluster_v3.Cluster_RING_HASH,
+		CommonLbConfig: &envoy_config_cluster_v3.Cluster_CommonLbConfig{
+			ConsistentHashingLbConfig: &envoy_config_cluster_v3.Cluster_CommonLbConfig_ConsistentHashingLbConfig{
+				// Hash the shard names instead of the addresses, so keys are not
+				// redistributed when a shard changes its server (ie after a failover)
+				UseHostnameForHashing: true,
+			},
+		},
+		LoadAssignment: &envoy_config_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: name,
+			Endpoints: []*envoy_config_endpoint_v3.LocalityLbEndpoints{
+				{LbEndpoints: endpoints},
+			},
+		},
+	}
+
+	return cluster, nil
+}
@@ -2,11 +2,13 @@ package templates
 
 import (
 	"testing"
+	"time"
 
 	"github.com/3scale-ops/basereconciler/util"
 	envoy_serializer_v3 "github.com/3scale-ops/marin3r/pkg/envoy/serializer/v3"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	"github.com/MakeNowJust/heredoc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
 
@@ -79,6 +81,76 @@ func TestCluster_v1(t *testing.T) {
                         initial_stream_window_size: 65536
 			`),
 		},
+		{
+			name: "Generates a cluster weighted between the main and the canary",
+			args: args{
+				name: "my_cluster",
+				opts: &saasv1alpha1.Cluster{
+					Host:           "127.0.0.1",
+					Port:           8080,
+					IsHttp2:        util.Pointer(false),
+					CanaryWeighted: util.Pointer(true),
+					WeightedUpstreams: []saasv1alpha1.WeightedUpstream{
+						{Host: "main-direct", Port: 8080, Weight: 75},
+						{Host: "canary-direct", Port: 8080, Weight: 25},
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                connect_timeout: 1s
+                dns_lookup_family: V4_ONLY
+                load_assignment:
+                  cluster_name: my_cluster
+                  endpoints:
+                  - lb_endpoints:
+                    - endpoint:
+                        address:
+                          socket_address:
+                            address: main-direct
+                            port_value: 8080
+                      load_balancing_weight: 75
+                    - endpoint:
+                        address:
+                          socket_address:
+                            address: canary-direct
+                            port_value: 8080
+                      load_balancing_weight: 25
+                name: my_cluster
+                type: STRICT_DNS
+			`),
+		},
+		{
+			name: "Skips upstreams with zero weight",
+			args: args{
+				name: "my_cluster",
+				opts: &saasv1alpha1.Cluster{
+					Host:           "127.0.0.1",
+					Port:           8080,
+					IsHttp2:        util.Pointer(false),
+					CanaryWeighted: util.Pointer(true),
+					WeightedUpstreams: []saasv1alpha1.WeightedUpstream{
+						{Host: "main-direct", Port: 8080, Weight: 100},
+						{Host: "canary-direct", Port: 8080, Weight: 0},
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                connect_timeout: 1s
+                dns_lookup_family: V4_ONLY
+                load_assignment:
+                  cluster_name: my_cluster
+                  endpoints:
+                  - lb_endpoints:
+                    - endpoint:
+                        address:
+                          socket_address:
+                            address: main-direct
+                            port_value: 8080
+                      load_balancing_weight: 100
+                name: my_cluster
+                type: STRICT_DNS
+			`),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -98,3 +170,342 @@ func TestCluster_v1(t *testing.T) {
 		})
 	}
 }
+
+func TestCluster_v2(t *testing.T) {
+	type args struct {
+		name string
+		opts interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Generates a health checked grpc cluster with outlier detection, circuit breakers and tls",
+			args: args{
+				name: "my_cluster",
+				opts: &saasv1alpha1.Cluster{
+					Host:           "localhost",
+					Port:           8080,
+					IsHttp2:        util.Pointer(true),
+					ConnectTimeout: &metav1.Duration{Duration: 5 * time.Second},
+					HealthCheck: &saasv1alpha1.ClusterHealthCheck{
+						Protocol:           "grpc",
+						ServiceName:        util.Pointer("svc"),
+						Interval:           metav1.Duration{Duration: 10 * time.Second},
+						Timeout:            metav1.Duration{Duration: 1 * time.Second},
+						HealthyThreshold:   util.Pointer[uint32](1),
+						UnhealthyThreshold: util.Pointer[uint32](3),
+					},
+					OutlierDetection: &saasv1alpha1.ClusterOutlierDetection{
+						Consecutive5xx:     util.Pointer[uint32](5),
+						BaseEjectionTime:   &metav1.Duration{Duration: 30 * time.Second},
+						MaxEjectionPercent: util.Pointer[uint32](50),
+					},
+					CircuitBreakers: &saasv1alpha1.ClusterCircuitBreakers{
+						MaxConnections: util.Pointer[uint32](1000),
+						MaxRetries:     util.Pointer[uint32](3),
+					},
+					UpstreamTLS: &saasv1alpha1.ClusterUpstreamTLS{},
+				},
+			},
+			want: heredoc.Doc(`
+                circuit_breakers:
+                  thresholds:
+                  - max_connections: 1000
+                    max_retries: 3
+                connect_timeout: 5s
+                dns_lookup_family: V4_ONLY
+                health_checks:
+                - grpc_health_check:
+                    service_name: svc
+                  healthy_threshold: 1
+                  interval: 10s
+                  timeout: 1s
+                  unhealthy_threshold: 3
+                load_assignment:
+                  cluster_name: my_cluster
+                  endpoints:
+                  - lb_endpoints:
+                    - endpoint:
+                        address:
+                          socket_address:
+                            address: localhost
+                            port_value: 8080
+                name: my_cluster
+                outlier_detection:
+                  base_ejection_time: 30s
+                  consecutive_5xx: 5
+                  max_ejection_percent: 50
+                transport_socket:
+                  name: envoy.transport_sockets.tls
+                  typed_config:
+                    '@type': type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext
+                    common_tls_context:
+                      alpn_protocols:
+                      - h2
+                      tls_params:
+                        tls_minimum_protocol_version: TLSv1_2
+                    sni: localhost
+                type: STRICT_DNS
+                typed_extension_protocol_options:
+                  envoy.extensions.upstreams.http.v3.HttpProtocolOptions:
+                    '@type': type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions
+                    explicit_http_config:
+                      http2_protocol_options:
+                        initial_connection_window_size: 1048576
+                        initial_stream_window_size: 65536
+			`),
+		},
+		{
+			name: "Generates an mTLS cluster",
+			args: args{
+				name: "my_cluster",
+				opts: &saasv1alpha1.Cluster{
+					Host:    "backend",
+					Port:    8443,
+					IsHttp2: util.Pointer(false),
+					UpstreamTLS: &saasv1alpha1.ClusterUpstreamTLS{
+						SNI:                         util.Pointer("backend.example.com"),
+						ClientCertificateSecretName: util.Pointer("client-cert"),
+						CASecretName:                util.Pointer("backend-ca"),
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                connect_timeout: 1s
+                dns_lookup_family: V4_ONLY
+                load_assignment:
+                  cluster_name: my_cluster
+                  endpoints:
+                  - lb_endpoints:
+                    - endpoint:
+                        address:
+                          socket_address:
+                            address: backend
+                            port_value: 8443
+                name: my_cluster
+                transport_socket:
+                  name: envoy.transport_sockets.tls
+                  typed_config:
+                    '@type': type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext
+                    common_tls_context:
+                      alpn_protocols:
+                      - http/1.1
+                      tls_certificate_sds_secret_configs:
+                      - name: client-cert
+                        sds_config:
+                          ads: {}
+                          resource_api_version: V3
+                      tls_params:
+                        tls_minimum_protocol_version: TLSv1_2
+                      validation_context_sds_secret_config:
+                        name: backend-ca
+                        sds_config:
+                          ads: {}
+                          resource_api_version: V3
+                    sni: backend.example.com
+                type: STRICT_DNS
+			`),
+		},
+		{
+			name: "Generates an http health checked cluster",
+			args: args{
+				name: "my_cluster",
+				opts: &saasv1alpha1.Cluster{
+					Host:    "localhost",
+					Port:    8080,
+					IsHttp2: util.Pointer(false),
+					HealthCheck: &saasv1alpha1.ClusterHealthCheck{
+						Protocol:           "http",
+						Path:               util.Pointer("/status"),
+						Interval:           metav1.Duration{Duration: 10 * time.Second},
+						Timeout:            metav1.Duration{Duration: 1 * time.Second},
+						HealthyThreshold:   util.Pointer[uint32](1),
+						UnhealthyThreshold: util.Pointer[uint32](3),
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                connect_timeout: 1s
+                dns_lookup_family: V4_ONLY
+                health_checks:
+                - healthy_threshold: 1
+                  http_health_check:
+                    host: localhost
+                    path: /status
+                  interval: 10s
+                  timeout: 1s
+                  unhealthy_threshold: 3
+                load_assignment:
+                  cluster_name: my_cluster
+                  endpoints:
+                  - lb_endpoints:
+                    - endpoint:
+                        address:
+                          socket_address:
+                            address: localhost
+                            port_value: 8080
+                name: my_cluster
+                type: STRICT_DNS
+			`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := Cluster_v2(tt.args.name, tt.args.opts)
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("Cluster_v2():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterShardedRedis_v1(t *testing.T) {
+	type args struct {
+		name string
+		opts interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Generates sharded redis cluster",
+			args: args{
+				name: "redis",
+				opts: &saasv1alpha1.ClusterShardedRedis{
+					Shards: []saasv1alpha1.RedisShardEndpoint{
+						{Name: "s0", Host: "127.0.0.1", Port: 1000},
+						{Name: "s1", Host: "127.0.0.2", Port: 2000},
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                common_lb_config:
+                  consistent_hashing_lb_config:
+                    use_hostname_for_hashing: true
+                connect_timeout: 1s
+                lb_policy: RING_HASH
+                load_assignment:
+                  cluster_name: redis
+                  endpoints:
+                  - lb_endpoints:
+                    - endpoint:
+                        address:
+                          socket_address:
+                            address: 127.0.0.1
+                            port_value: 1000
+                        hostname: s0
+                    - endpoint:
+                        address:
+                          socket_address:
+                            address: 127.0.0.2
+                            port_value: 2000
+                        hostname: s1
+                name: redis
+                type: STATIC
+			`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := ClusterShardedRedis_v1(tt.args.name, tt.args.opts)
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("ClusterShardedRedis_v1():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthCheck_v1(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *saasv1alpha1.ClusterHealthCheck
+		want string
+	}{
+		{
+			name: "Defaults the unset options",
+			opts: &saasv1alpha1.ClusterHealthCheck{
+				Protocol: "http",
+				Interval: metav1.Duration{Duration: 10 * time.Second},
+				Timeout:  metav1.Duration{Duration: 1 * time.Second},
+			},
+			want: heredoc.Doc(`
+                healthy_threshold: 1
+                http_health_check:
+                  host: localhost
+                  path: /
+                interval: 10s
+                timeout: 1s
+                unhealthy_threshold: 3
+			`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HealthCheck_v1("localhost", tt.opts)
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("HealthCheck_v1():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
+
+func TestOutlierDetection_v1(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *saasv1alpha1.ClusterOutlierDetection
+		want string
+	}{
+		{
+			name: "Defaults the unset options",
+			opts: &saasv1alpha1.ClusterOutlierDetection{},
+			want: heredoc.Doc(`
+                consecutive_5xx: 5
+			`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OutlierDetection_v1(tt.opts)
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("OutlierDetection_v1():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
@@ -16,3 +16,13 @@ func Address_v1(host string, port uint32) *envoy_config_core_v3.Address {
 		},
 	}
 }
+
+// valueOrDefault returns the value of an optional field, or the given default if it
+// is unset. The defaults of the CRDs are not applied to all the options that reach
+// the templates, like those added by canary patches.
+func valueOrDefault[T any](v *T, def T) T {
+	if v == nil {
+		return def
+	}
+	return *v
+}
@@ -9,10 +9,15 @@ import (
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_config_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
+	envoy_config_trace_v3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
 	envoy_extensions_access_loggers_file_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
+	envoy_extensions_access_loggers_grpc_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
 	envoy_extensions_filters_http_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
 	http_connection_manager_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoy_extensions_filters_network_redis_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/redis_proxy/v3"
+	envoy_extensions_filters_network_tcp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	envoy_extensions_transport_sockets_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -89,6 +94,118 @@ func ListenerHTTP_v1(name string, opts interface{}) (envoy.Resource, error) {
 	return listener, nil
 }
 
+func ListenerHTTP_v2(name string, opts interface{}) (envoy.Resource, error) {
+	o := opts.(*saasv1alpha1.ListenerHttp)
+
+	resource, err := ListenerHTTP_v1(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	listener := resource.(*envoy_config_listener_v3.Listener)
+
+	filter := listener.FilterChains[0].Filters[0]
+	hcm := &http_connection_manager_v3.HttpConnectionManager{}
+	if err := filter.GetTypedConfig().UnmarshalTo(hcm); err != nil {
+		return nil, err
+	}
+
+	if o.AccessLog != nil {
+		hcm.AccessLog = AccessLogConfig_v2(name, o.CertificateSecretName != nil, o.AccessLog)
+	}
+	if o.Tracing != nil {
+		hcm.Tracing = Tracing_v1(name, o.Tracing)
+	}
+	if o.CertificateSecretName != nil && o.ClientValidation != nil {
+		listener.FilterChains[0].TransportSocket = TransportSocket_v2(*o.CertificateSecretName, *o.EnableHttp2, o.ClientValidation)
+	}
+
+	any, err := anypb.New(hcm)
+	if err != nil {
+		return nil, err
+	}
+	filter.ConfigType = &envoy_config_listener_v3.Filter_TypedConfig{TypedConfig: any}
+
+	return listener, nil
+}
+
+func ListenerRedisProxy_v1(name string, opts interface{}) (envoy.Resource, error) {
+	o := opts.(*saasv1alpha1.ListenerRedisProxy)
+
+	address := "0.0.0.0"
+	if o.Address != nil {
+		address = *o.Address
+	}
+
+	listener := &envoy_config_listener_v3.Listener{
+		Name:    name,
+		Address: Address_v1(address, o.Port),
+		FilterChains: []*envoy_config_listener_v3.FilterChain{{
+			Filters: []*envoy_config_listener_v3.Filter{{
+				Name: "envoy.filters.network.redis_proxy",
+				ConfigType: &envoy_config_listener_v3.Filter_TypedConfig{
+					TypedConfig: func() *anypb.Any {
+						any, err := anypb.New(
+							&envoy_extensions_filters_network_redis_proxy_v3.RedisProxy{
+								StatPrefix: name,
+								Settings: &envoy_extensions_filters_network_redis_proxy_v3.RedisProxy_ConnPoolSettings{
+									OpTimeout: durationpb.New(o.OpTimeout.Duration),
+									// same as the "{}" hash_tag used in twemproxy server pools
+									EnableHashtagging: true,
+								},
+								PrefixRoutes: &envoy_extensions_filters_network_redis_proxy_v3.RedisProxy_PrefixRoutes{
+									CatchAllRoute: &envoy_extensions_filters_network_redis_proxy_v3.RedisProxy_PrefixRoutes_Route{
+										Cluster: o.ClusterName,
+									},
+								},
+							})
+						if err != nil {
+							panic(err)
+						}
+						return any
+					}(),
+				},
+			}},
+		}},
+	}
+
+	return listener, nil
+}
+
+func ListenerTcpProxy_v1(name string, opts interface{}) (envoy.Resource, error) {
+	o := opts.(*saasv1alpha1.ListenerTcpProxy)
+
+	listener := &envoy_config_listener_v3.Listener{
+		Name:            name,
+		Address:         Address_v1(valueOrDefault(o.Address, "0.0.0.0"), o.Port),
+		ListenerFilters: ListenerFilters_v1(false, valueOrDefault(o.ProxyProtocol, false)),
+		FilterChains: []*envoy_config_listener_v3.FilterChain{{
+			Filters: []*envoy_config_listener_v3.Filter{{
+				Name: "envoy.filters.network.tcp_proxy",
+				ConfigType: &envoy_config_listener_v3.Filter_TypedConfig{
+					TypedConfig: func() *anypb.Any {
+						tcpProxy := &envoy_extensions_filters_network_tcp_proxy_v3.TcpProxy{
+							StatPrefix: name,
+							ClusterSpecifier: &envoy_extensions_filters_network_tcp_proxy_v3.TcpProxy_Cluster{
+								Cluster: o.ClusterName,
+							},
+						}
+						if o.IdleTimeout != nil {
+							tcpProxy.IdleTimeout = durationpb.New(o.IdleTimeout.Duration)
+						}
+						any, err := anypb.New(tcpProxy)
+						if err != nil {
+							panic(err)
+						}
+						return any
+					}(),
+				},
+			}},
+		}},
+	}
+
+	return listener, nil
+}
+
 func ListenerFilters_v1(tls, proxyProtocol bool) []*envoy_config_listener_v3.ListenerFilter {
 	filters := []*envoy_config_listener_v3.ListenerFilter{}
 	if tls {
@@ -159,42 +276,115 @@ func HttpFilterRateLimit_v1(opts *saasv1alpha1.RateLimitOptions) *http_connectio
 	}
 }
 
+// TransportSocket_v2 returns the TLS transport socket of an https listener that
+// validates the certificates presented by the clients using the CA loaded from the
+// SDS secret configured in the client validation options
+func TransportSocket_v2(secretName string, http2 bool, validation *saasv1alpha1.ListenerClientValidation) *envoy_config_core_v3.TransportSocket {
+	ts := TransportSocket_v1(secretName, http2)
+
+	tlsContext := &envoy_extensions_transport_sockets_tls_v3.DownstreamTlsContext{}
+	if err := ts.GetTypedConfig().UnmarshalTo(tlsContext); err != nil {
+		panic(err)
+	}
+	tlsContext.RequireClientCertificate = wrapperspb.Bool(*validation.RequireClientCertificate)
+	tlsContext.CommonTlsContext.ValidationContextType = &envoy_extensions_transport_sockets_tls_v3.CommonTlsContext_ValidationContextSdsSecretConfig{
+		ValidationContextSdsSecretConfig: SdsSecretConfig_v1(validation.CASecretName),
+	}
+
+	any, err := anypb.New(tlsContext)
+	if err != nil {
+		panic(err)
+	}
+	ts.ConfigType = &envoy_config_core_v3.TransportSocket_TypedConfig{TypedConfig: any}
+
+	return ts
+}
+
+func SdsSecretConfig_v1(name string) *envoy_extensions_transport_sockets_tls_v3.SdsSecretConfig {
+	return &envoy_extensions_transport_sockets_tls_v3.SdsSecretConfig{
+		Name: name,
+		SdsConfig: &envoy_config_core_v3.ConfigSource{
+			ConfigSourceSpecifier: &envoy_config_core_v3.ConfigSource_Ads{
+				Ads: &envoy_config_core_v3.AggregatedConfigSource{},
+			},
+			ResourceApiVersion: envoy_config_core_v3.ApiVersion_V3,
+		},
+	}
+}
+
 func AccessLogConfig_v1(name string, tls bool) []*envoy_config_accesslog_v3.AccessLog {
-	return []*envoy_config_accesslog_v3.AccessLog{{
+	return []*envoy_config_accesslog_v3.AccessLog{FileAccessLog_v1("/dev/stdout", defaultAccessLogFields(name, tls))}
+}
+
+func AccessLogConfig_v2(name string, tls bool, opts *saasv1alpha1.AccessLogOptions) []*envoy_config_accesslog_v3.AccessLog {
+	fields := defaultAccessLogFields(name, tls)
+	if opts.Fields != nil {
+		fields = make(map[string]*structpb.Value, len(opts.Fields))
+		for k, v := range opts.Fields {
+			fields[k] = structpb.NewStringValue(v)
+		}
+	}
+
+	sinks := opts.Sinks
+	if len(sinks) == 0 {
+		sinks = []saasv1alpha1.AccessLogSink{{Stdout: &saasv1alpha1.AccessLogStdoutSink{}}}
+	}
+
+	logs := make([]*envoy_config_accesslog_v3.AccessLog, 0, len(sinks))
+	for _, sink := range sinks {
+		switch {
+		case sink.Stdout != nil:
+			logs = append(logs, FileAccessLog_v1("/dev/stdout", fields))
+		case sink.File != nil:
+			logs = append(logs, FileAccessLog_v1(sink.File.Path, fields))
+		case sink.Grpc != nil:
+			logName := name
+			if sink.Grpc.LogName != nil {
+				logName = *sink.Grpc.LogName
+			}
+			logs = append(logs, GrpcAccessLog_v1(logName, sink.Grpc.ClusterName))
+		}
+	}
+
+	return logs
+}
+
+func defaultAccessLogFields(name string, tls bool) map[string]*structpb.Value {
+	m := map[string]*structpb.Value{
+		"authority":             structpb.NewStringValue("%REQ(:AUTHORITY)%"),
+		"bytes_received":        structpb.NewStringValue("%BYTES_RECEIVED%"),
+		"bytes_sent":            structpb.NewStringValue("%BYTES_SENT%"),
+		"duration":              structpb.NewStringValue("%DURATION%"),
+		"method":                structpb.NewStringValue("%REQ(:METHOD)%"),
+		"path":                  structpb.NewStringValue("%REQ(X-ENVOY-ORIGINAL-PATH?:PATH)%"),
+		"protocol":              structpb.NewStringValue("%PROTOCOL%"),
+		"response_code":         structpb.NewStringValue("%RESPONSE_CODE%"),
+		"response_code_details": structpb.NewStringValue("%RESPONSE_CODE_DETAILS%"),
+		"response_flags":        structpb.NewStringValue("%RESPONSE_FLAGS%"),
+		"listener":              structpb.NewStringValue(name),
+		"upstream_cluster":      structpb.NewStringValue("%UPSTREAM_CLUSTER%"),
+		"upstream_service_time": structpb.NewStringValue("%RESP(X-ENVOY-UPSTREAM-SERVICE-TIME)%"),
+		"user_agent":            structpb.NewStringValue("%REQ(USER-AGENT)%"),
+		"client_ip":             structpb.NewStringValue("%DOWNSTREAM_REMOTE_ADDRESS_WITHOUT_PORT%"),
+	}
+	if tls {
+		m["downstream_tls_cipher"] = structpb.NewStringValue("%DOWNSTREAM_TLS_CIPHER%")
+		m["downstream_tls_version"] = structpb.NewStringValue("%DOWNSTREAM_TLS_VERSION%")
+	}
+	return m
+}
+
+func FileAccessLog_v1(path string, fields map[string]*structpb.Value) *envoy_config_accesslog_v3.AccessLog {
+	return &envoy_config_accesslog_v3.AccessLog{
 		Name: "envoy.access_loggers.file",
 		ConfigType: &envoy_config_accesslog_v3.AccessLog_TypedConfig{
 			TypedConfig: func() *anypb.Any {
 				logfmt := &envoy_extensions_access_loggers_file_v3.FileAccessLog{
-					Path: "/dev/stdout",
+					Path: path,
 					AccessLogFormat: &envoy_extensions_access_loggers_file_v3.FileAccessLog_LogFormat{
 						LogFormat: &envoy_config_core_v3.SubstitutionFormatString{
 							Format: &envoy_config_core_v3.SubstitutionFormatString_JsonFormat{
-								JsonFormat: &structpb.Struct{
-									Fields: func() map[string]*structpb.Value {
-										m := map[string]*structpb.Value{
-											"authority":             structpb.NewStringValue("%REQ(:AUTHORITY)%"),
-											"bytes_received":        structpb.NewStringValue("%BYTES_RECEIVED%"),
-											"bytes_sent":            structpb.NewStringValue("%BYTES_SENT%"),
-											"duration":              structpb.NewStringValue("%DURATION%"),
-											"method":                structpb.NewStringValue("%REQ(:METHOD)%"),
-											"path":                  structpb.NewStringValue("%REQ(X-ENVOY-ORIGINAL-PATH?:PATH)%"),
-											"protocol":              structpb.NewStringValue("%PROTOCOL%"),
-											"response_code":         structpb.NewStringValue("%RESPONSE_CODE%"),
-											"response_code_details": structpb.NewStringValue("%RESPONSE_CODE_DETAILS%"),
-											"response_flags":        structpb.NewStringValue("%RESPONSE_FLAGS%"),
-											"listener":              structpb.NewStringValue(name),
-											"upstream_cluster":      structpb.NewStringValue("%UPSTREAM_CLUSTER%"),
-											"upstream_service_time": structpb.NewStringValue("%RESP(X-ENVOY-UPSTREAM-SERVICE-TIME)%"),
-											"user_agent":            structpb.NewStringValue("%REQ(USER-AGENT)%"),
-											"client_ip":             structpb.NewStringValue("%DOWNSTREAM_REMOTE_ADDRESS_WITHOUT_PORT%"),
-										}
-										if tls {
-											m["downstream_tls_cipher"] = structpb.NewStringValue("%DOWNSTREAM_TLS_CIPHER%")
-											m["downstream_tls_version"] = structpb.NewStringValue("%DOWNSTREAM_TLS_VERSION%")
-										}
-										return m
-									}(),
-								},
+								JsonFormat: &structpb.Struct{Fields: fields},
 							},
 						},
 					},
@@ -207,7 +397,90 @@ func AccessLogConfig_v1(name string, tls bool) []*envoy_config_accesslog_v3.Acce
 				return any
 			}(),
 		},
-	}}
+	}
+}
+
+func GrpcAccessLog_v1(logName, clusterName string) *envoy_config_accesslog_v3.AccessLog {
+	return &envoy_config_accesslog_v3.AccessLog{
+		Name: "envoy.access_loggers.http_grpc",
+		ConfigType: &envoy_config_accesslog_v3.AccessLog_TypedConfig{
+			TypedConfig: func() *anypb.Any {
+				any, err := anypb.New(&envoy_extensions_access_loggers_grpc_v3.HttpGrpcAccessLogConfig{
+					CommonConfig: &envoy_extensions_access_loggers_grpc_v3.CommonGrpcAccessLogConfig{
+						LogName: logName,
+						GrpcService: &envoy_config_core_v3.GrpcService{
+							TargetSpecifier: &envoy_config_core_v3.GrpcService_EnvoyGrpc_{
+								EnvoyGrpc: &envoy_config_core_v3.GrpcService_EnvoyGrpc{
+									ClusterName: clusterName,
+								},
+							},
+						},
+						TransportApiVersion: envoy_config_core_v3.ApiVersion_V3,
+					},
+				})
+				if err != nil {
+					panic(err)
+				}
+				return any
+			}(),
+		},
+	}
+}
+
+func Tracing_v1(name string, opts *saasv1alpha1.TracingOptions) *http_connection_manager_v3.HttpConnectionManager_Tracing {
+	var provider *envoy_config_trace_v3.Tracing_Http
+
+	switch opts.Provider {
+	case "zipkin":
+		provider = &envoy_config_trace_v3.Tracing_Http{
+			Name: "envoy.tracers.zipkin",
+			ConfigType: &envoy_config_trace_v3.Tracing_Http_TypedConfig{
+				TypedConfig: func() *anypb.Any {
+					any, err := anypb.New(&envoy_config_trace_v3.ZipkinConfig{
+						CollectorCluster:         opts.ClusterName,
+						CollectorEndpoint:        valueOrDefault(opts.CollectorEndpoint, "/api/v2/spans"),
+						CollectorEndpointVersion: envoy_config_trace_v3.ZipkinConfig_HTTP_JSON,
+					})
+					if err != nil {
+						panic(err)
+					}
+					return any
+				}(),
+			},
+		}
+
+	case "opentelemetry":
+		provider = &envoy_config_trace_v3.Tracing_Http{
+			Name: "envoy.tracers.opentelemetry",
+			ConfigType: &envoy_config_trace_v3.Tracing_Http_TypedConfig{
+				TypedConfig: func() *anypb.Any {
+					serviceName := name
+					if opts.ServiceName != nil {
+						serviceName = *opts.ServiceName
+					}
+					any, err := anypb.New(&envoy_config_trace_v3.OpenTelemetryConfig{
+						GrpcService: &envoy_config_core_v3.GrpcService{
+							TargetSpecifier: &envoy_config_core_v3.GrpcService_EnvoyGrpc_{
+								EnvoyGrpc: &envoy_config_core_v3.GrpcService_EnvoyGrpc{
+									ClusterName: opts.ClusterName,
+								},
+							},
+						},
+						ServiceName: serviceName,
+					})
+					if err != nil {
+						panic(err)
+					}
+					return any
+				}(),
+			},
+		}
+	}
+
+	return &http_connection_manager_v3.HttpConnectionManager_Tracing{
+		RandomSampling: &envoy_type_v3.Percent{Value: float64(valueOrDefault(opts.RandomSampling, 100))},
+		Provider:       provider,
+	}
 }
 
 func TransportSocket_v1(secretName string, http2 bool) *envoy_config_core_v3.TransportSocket {
@@ -237,3 +237,483 @@ func TestListenerHTTP_v1(t *testing.T) {
 		})
 	}
 }
+
+func TestListenerHTTP_v2(t *testing.T) {
+	type args struct {
+		name string
+		opts *saasv1alpha1.ListenerHttp
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Generates http listener with access log sinks and zipkin tracing",
+			args: args{
+				name: "test",
+				opts: &saasv1alpha1.ListenerHttp{
+					Port:            8080,
+					RouteConfigName: "my_route",
+					EnableHttp2:     util.Pointer(false),
+					ProxyProtocol:   util.Pointer(false),
+					AccessLog: &saasv1alpha1.AccessLogOptions{
+						Fields: map[string]string{
+							"path":          "%REQ(:PATH)%",
+							"response_code": "%RESPONSE_CODE%",
+						},
+						Sinks: []saasv1alpha1.AccessLogSink{
+							{File: &saasv1alpha1.AccessLogFileSink{Path: "/var/log/envoy/access.log"}},
+							{Grpc: &saasv1alpha1.AccessLogGrpcSink{ClusterName: "als"}},
+						},
+					},
+					Tracing: &saasv1alpha1.TracingOptions{
+						Provider:          "zipkin",
+						ClusterName:       "zipkin",
+						CollectorEndpoint: util.Pointer("/api/v2/spans"),
+						RandomSampling:    util.Pointer[uint32](10),
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                address:
+                  socket_address:
+                    address: 0.0.0.0
+                    port_value: 8080
+                filter_chains:
+                - filters:
+                  - name: envoy.filters.network.http_connection_manager
+                    typed_config:
+                      '@type': type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+                      access_log:
+                      - name: envoy.access_loggers.file
+                        typed_config:
+                          '@type': type.googleapis.com/envoy.extensions.access_loggers.file.v3.FileAccessLog
+                          log_format:
+                            json_format:
+                              path: '%REQ(:PATH)%'
+                              response_code: '%RESPONSE_CODE%'
+                          path: /var/log/envoy/access.log
+                      - name: envoy.access_loggers.http_grpc
+                        typed_config:
+                          '@type': type.googleapis.com/envoy.extensions.access_loggers.grpc.v3.HttpGrpcAccessLogConfig
+                          common_config:
+                            grpc_service:
+                              envoy_grpc:
+                                cluster_name: als
+                            log_name: test
+                            transport_api_version: V3
+                      common_http_protocol_options:
+                        headers_with_underscores_action: REJECT_REQUEST
+                        idle_timeout: 3600s
+                      http_filters:
+                      - name: envoy.filters.http.router
+                      http_protocol_options: {}
+                      http2_protocol_options:
+                        initial_connection_window_size: 1048576
+                        initial_stream_window_size: 65536
+                        max_concurrent_streams: 100
+                      rds:
+                        config_source:
+                          ads: {}
+                          resource_api_version: V3
+                        route_config_name: my_route
+                      request_timeout: 300s
+                      stat_prefix: test
+                      stream_idle_timeout: 300s
+                      tracing:
+                        provider:
+                          name: envoy.tracers.zipkin
+                          typed_config:
+                            '@type': type.googleapis.com/envoy.config.trace.v3.ZipkinConfig
+                            collector_cluster: zipkin
+                            collector_endpoint: /api/v2/spans
+                            collector_endpoint_version: HTTP_JSON
+                        random_sampling:
+                          value: 10
+                      use_remote_address: false
+                name: test
+                per_connection_buffer_limit_bytes: 32768
+			`),
+		},
+		{
+			name: "Generates http listener with opentelemetry tracing",
+			args: args{
+				name: "test",
+				opts: &saasv1alpha1.ListenerHttp{
+					Port:            8080,
+					RouteConfigName: "my_route",
+					EnableHttp2:     util.Pointer(false),
+					ProxyProtocol:   util.Pointer(false),
+					Tracing: &saasv1alpha1.TracingOptions{
+						Provider:       "opentelemetry",
+						ClusterName:    "otel",
+						RandomSampling: util.Pointer[uint32](100),
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                address:
+                  socket_address:
+                    address: 0.0.0.0
+                    port_value: 8080
+                filter_chains:
+                - filters:
+                  - name: envoy.filters.network.http_connection_manager
+                    typed_config:
+                      '@type': type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+                      access_log:
+                      - name: envoy.access_loggers.file
+                        typed_config:
+                          '@type': type.googleapis.com/envoy.extensions.access_loggers.file.v3.FileAccessLog
+                          log_format:
+                            json_format:
+                              authority: '%REQ(:AUTHORITY)%'
+                              bytes_received: '%BYTES_RECEIVED%'
+                              bytes_sent: '%BYTES_SENT%'
+                              client_ip: '%DOWNSTREAM_REMOTE_ADDRESS_WITHOUT_PORT%'
+                              duration: '%DURATION%'
+                              listener: test
+                              method: '%REQ(:METHOD)%'
+                              path: '%REQ(X-ENVOY-ORIGINAL-PATH?:PATH)%'
+                              protocol: '%PROTOCOL%'
+                              response_code: '%RESPONSE_CODE%'
+                              response_code_details: '%RESPONSE_CODE_DETAILS%'
+                              response_flags: '%RESPONSE_FLAGS%'
+                              upstream_cluster: '%UPSTREAM_CLUSTER%'
+                              upstream_service_time: '%RESP(X-ENVOY-UPSTREAM-SERVICE-TIME)%'
+                              user_agent: '%REQ(USER-AGENT)%'
+                          path: /dev/stdout
+                      common_http_protocol_options:
+                        headers_with_underscores_action: REJECT_REQUEST
+                        idle_timeout: 3600s
+                      http_filters:
+                      - name: envoy.filters.http.router
+                      http_protocol_options: {}
+                      http2_protocol_options:
+                        initial_connection_window_size: 1048576
+                        initial_stream_window_size: 65536
+                        max_concurrent_streams: 100
+                      rds:
+                        config_source:
+                          ads: {}
+                          resource_api_version: V3
+                        route_config_name: my_route
+                      request_timeout: 300s
+                      stat_prefix: test
+                      stream_idle_timeout: 300s
+                      tracing:
+                        provider:
+                          name: envoy.tracers.opentelemetry
+                          typed_config:
+                            '@type': type.googleapis.com/envoy.config.trace.v3.OpenTelemetryConfig
+                            grpc_service:
+                              envoy_grpc:
+                                cluster_name: otel
+                            service_name: test
+                        random_sampling:
+                          value: 100
+                      use_remote_address: false
+                name: test
+                per_connection_buffer_limit_bytes: 32768
+			`),
+		},
+		{
+			name: "Generates https listener with client certificate validation",
+			args: args{
+				name: "test",
+				opts: &saasv1alpha1.ListenerHttp{
+					Port:                  8443,
+					RouteConfigName:       "my_route",
+					CertificateSecretName: util.Pointer("my_certificate"),
+					EnableHttp2:           util.Pointer(false),
+					ProxyProtocol:         util.Pointer(false),
+					ClientValidation: &saasv1alpha1.ListenerClientValidation{
+						CASecretName:             "clients-ca",
+						RequireClientCertificate: util.Pointer(true),
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                address:
+                  socket_address:
+                    address: 0.0.0.0
+                    port_value: 8443
+                filter_chains:
+                - filters:
+                  - name: envoy.filters.network.http_connection_manager
+                    typed_config:
+                      '@type': type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+                      access_log:
+                      - name: envoy.access_loggers.file
+                        typed_config:
+                          '@type': type.googleapis.com/envoy.extensions.access_loggers.file.v3.FileAccessLog
+                          log_format:
+                            json_format:
+                              authority: '%REQ(:AUTHORITY)%'
+                              bytes_received: '%BYTES_RECEIVED%'
+                              bytes_sent: '%BYTES_SENT%'
+                              client_ip: '%DOWNSTREAM_REMOTE_ADDRESS_WITHOUT_PORT%'
+                              downstream_tls_cipher: '%DOWNSTREAM_TLS_CIPHER%'
+                              downstream_tls_version: '%DOWNSTREAM_TLS_VERSION%'
+                              duration: '%DURATION%'
+                              listener: test
+                              method: '%REQ(:METHOD)%'
+                              path: '%REQ(X-ENVOY-ORIGINAL-PATH?:PATH)%'
+                              protocol: '%PROTOCOL%'
+                              response_code: '%RESPONSE_CODE%'
+                              response_code_details: '%RESPONSE_CODE_DETAILS%'
+                              response_flags: '%RESPONSE_FLAGS%'
+                              upstream_cluster: '%UPSTREAM_CLUSTER%'
+                              upstream_service_time: '%RESP(X-ENVOY-UPSTREAM-SERVICE-TIME)%'
+                              user_agent: '%REQ(USER-AGENT)%'
+                          path: /dev/stdout
+                      common_http_protocol_options:
+                        headers_with_underscores_action: REJECT_REQUEST
+                        idle_timeout: 3600s
+                      http_filters:
+                      - name: envoy.filters.http.router
+                      http_protocol_options: {}
+                      http2_protocol_options:
+                        initial_connection_window_size: 1048576
+                        initial_stream_window_size: 65536
+                        max_concurrent_streams: 100
+                      rds:
+                        config_source:
+                          ads: {}
+                          resource_api_version: V3
+                        route_config_name: my_route
+                      request_timeout: 300s
+                      stat_prefix: test
+                      stream_idle_timeout: 300s
+                      use_remote_address: false
+                  transport_socket:
+                    name: envoy.transport_sockets.tls
+                    typed_config:
+                      '@type': type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.DownstreamTlsContext
+                      common_tls_context:
+                        alpn_protocols:
+                        - http/1.1
+                        tls_certificate_sds_secret_configs:
+                        - name: my_certificate
+                          sds_config:
+                            ads: {}
+                            resource_api_version: V3
+                        tls_params:
+                          tls_minimum_protocol_version: TLSv1_2
+                        validation_context_sds_secret_config:
+                          name: clients-ca
+                          sds_config:
+                            ads: {}
+                            resource_api_version: V3
+                      require_client_certificate: true
+                listener_filters:
+                - name: envoy.filters.listener.tls_inspector
+                name: test
+                per_connection_buffer_limit_bytes: 32768
+			`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := ListenerHTTP_v2(tt.args.name, tt.args.opts)
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("ListenerHTTP_v2():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
+
+func TestListenerRedisProxy_v1(t *testing.T) {
+	type args struct {
+		name string
+		opts *saasv1alpha1.ListenerRedisProxy
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Generates redis proxy listener",
+			args: args{
+				name: "test",
+				opts: &saasv1alpha1.ListenerRedisProxy{
+					Address:     util.Pointer("0.0.0.0"),
+					Port:        22121,
+					ClusterName: "redis",
+					OpTimeout:   metav1.Duration{Duration: 400 * time.Millisecond},
+				},
+			},
+			want: heredoc.Doc(`
+                address:
+                  socket_address:
+                    address: 0.0.0.0
+                    port_value: 22121
+                filter_chains:
+                - filters:
+                  - name: envoy.filters.network.redis_proxy
+                    typed_config:
+                      '@type': type.googleapis.com/envoy.extensions.filters.network.redis_proxy.v3.RedisProxy
+                      prefix_routes:
+                        catch_all_route:
+                          cluster: redis
+                      settings:
+                        enable_hashtagging: true
+                        op_timeout: 0.400s
+                      stat_prefix: test
+                name: test
+			`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := ListenerRedisProxy_v1(tt.args.name, tt.args.opts)
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("ListenerRedisProxy_v1():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
+
+func TestListenerTcpProxy_v1(t *testing.T) {
+	type args struct {
+		name string
+		opts *saasv1alpha1.ListenerTcpProxy
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Generates tcp proxy listener",
+			args: args{
+				name: "test",
+				opts: &saasv1alpha1.ListenerTcpProxy{
+					Address:       util.Pointer("0.0.0.0"),
+					Port:          5432,
+					ClusterName:   "db",
+					ProxyProtocol: util.Pointer(true),
+					IdleTimeout:   &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+			want: heredoc.Doc(`
+                address:
+                  socket_address:
+                    address: 0.0.0.0
+                    port_value: 5432
+                filter_chains:
+                - filters:
+                  - name: envoy.filters.network.tcp_proxy
+                    typed_config:
+                      '@type': type.googleapis.com/envoy.extensions.filters.network.tcp_proxy.v3.TcpProxy
+                      cluster: db
+                      idle_timeout: 600s
+                      stat_prefix: test
+                listener_filters:
+                - name: envoy.filters.listener.proxy_protocol
+                name: test
+			`),
+		},
+		{
+			name: "Defaults the unset options",
+			args: args{
+				name: "test",
+				opts: &saasv1alpha1.ListenerTcpProxy{
+					Port:        5432,
+					ClusterName: "db",
+				},
+			},
+			want: heredoc.Doc(`
+                address:
+                  socket_address:
+                    address: 0.0.0.0
+                    port_value: 5432
+                filter_chains:
+                - filters:
+                  - name: envoy.filters.network.tcp_proxy
+                    typed_config:
+                      '@type': type.googleapis.com/envoy.extensions.filters.network.tcp_proxy.v3.TcpProxy
+                      cluster: db
+                      stat_prefix: test
+                name: test
+			`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := ListenerTcpProxy_v1(tt.args.name, tt.args.opts)
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("ListenerTcpProxy_v1():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
+
+func TestTracing_v1(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *saasv1alpha1.TracingOptions
+		want string
+	}{
+		{
+			name: "Defaults the unset options",
+			opts: &saasv1alpha1.TracingOptions{
+				Provider:    "zipkin",
+				ClusterName: "zipkin",
+			},
+			want: heredoc.Doc(`
+                provider:
+                  name: envoy.tracers.zipkin
+                  typed_config:
+                    '@type': type.googleapis.com/envoy.config.trace.v3.ZipkinConfig
+                    collector_cluster: zipkin
+                    collector_endpoint: /api/v2/spans
+                    collector_endpoint_version: HTTP_JSON
+                random_sampling:
+                  value: 100
+			`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Tracing_v1("test", tt.opts)
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("Tracing_v1():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
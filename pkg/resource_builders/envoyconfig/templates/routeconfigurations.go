@@ -1,11 +1,15 @@
 package templates
 
 import (
+	"fmt"
+
 	"github.com/3scale-ops/marin3r/pkg/envoy"
 	envoy_serializer_v3 "github.com/3scale-ops/marin3r/pkg/envoy/serializer/v3"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	operatorutils "github.com/3scale-ops/saas-operator/pkg/util"
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func RouteConfiguration_v1(name string, opts interface{}) (envoy.Resource, error) {
@@ -17,11 +21,13 @@ func RouteConfiguration_v1(name string, opts interface{}) (envoy.Resource, error
 	}
 
 	merr := operatorutils.MultiError{}
-	for _, vhost := range o.VirtualHosts {
+	for i, vhost := range o.VirtualHosts {
 		vh := &envoy_config_route_v3.VirtualHost{}
 		err := envoy_serializer_v3.JSON{}.Unmarshal(string(vhost.Raw), vh)
 		if err != nil {
-			merr = append(merr, err)
+			merr = append(merr, fmt.Errorf("virtualHosts[%d]: %w", i, err))
+		} else if err := vh.ValidateAll(); err != nil {
+			merr = append(merr, fmt.Errorf("virtualHosts[%d]: %w", i, err))
 		}
 		rc.VirtualHosts = append(rc.VirtualHosts, vh)
 	}
@@ -29,5 +35,121 @@ func RouteConfiguration_v1(name string, opts interface{}) (envoy.Resource, error
 		return nil, merr
 	}
 
+	for i, policy := range o.RateLimits {
+		if err := applyRateLimitPolicy(rc, policy); err != nil {
+			merr = append(merr, fmt.Errorf("rateLimits[%d]: %w", i, err))
+		}
+	}
+	if len(merr) > 0 {
+		return nil, merr
+	}
+
 	return rc, nil
 }
+
+func RouteConfiguration_v2(name string, opts interface{}) (envoy.Resource, error) {
+	o := opts.(*saasv1alpha1.RouteConfiguration)
+
+	resource, err := RouteConfiguration_v1(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	rc := resource.(*envoy_config_route_v3.RouteConfiguration)
+
+	if o.RetryPolicy != nil {
+		for _, vh := range rc.VirtualHosts {
+			// retry policies defined in the virtual host take precedence
+			if vh.RetryPolicy == nil {
+				vh.RetryPolicy = RetryPolicy_v1(o.RetryPolicy)
+			}
+		}
+	}
+
+	return rc, nil
+}
+
+func RetryPolicy_v1(opts *saasv1alpha1.RetryPolicy) *envoy_config_route_v3.RetryPolicy {
+	rp := &envoy_config_route_v3.RetryPolicy{
+		RetryOn:    opts.RetryOn,
+		NumRetries: wrapperspb.UInt32(valueOrDefault(opts.NumRetries, 1)),
+	}
+	if opts.PerTryTimeout != nil {
+		rp.PerTryTimeout = durationpb.New(opts.PerTryTimeout.Duration)
+	}
+	return rp
+}
+
+// applyRateLimitPolicy adds the rate limits of the policy to the
+// virtual host or route it targets
+func applyRateLimitPolicy(rc *envoy_config_route_v3.RouteConfiguration, policy saasv1alpha1.RateLimitPolicy) error {
+	var vh *envoy_config_route_v3.VirtualHost
+	for _, v := range rc.VirtualHosts {
+		if v.Name == policy.VirtualHost {
+			vh = v
+			break
+		}
+	}
+	if vh == nil {
+		return fmt.Errorf("virtual host '%s' not found", policy.VirtualHost)
+	}
+
+	if policy.Route == nil {
+		for _, limit := range policy.Limits {
+			vh.RateLimits = append(vh.RateLimits, RateLimit_v1(limit))
+		}
+		return nil
+	}
+
+	for _, route := range vh.Routes {
+		if route.Name != *policy.Route {
+			continue
+		}
+		action := route.GetRoute()
+		if action == nil {
+			return fmt.Errorf("route '%s' in virtual host '%s' does not route to a cluster", route.Name, vh.Name)
+		}
+		for _, limit := range policy.Limits {
+			action.RateLimits = append(action.RateLimits, RateLimit_v1(limit))
+		}
+		return nil
+	}
+
+	return fmt.Errorf("route '%s' not found in virtual host '%s'", *policy.Route, vh.Name)
+}
+
+func RateLimit_v1(opts saasv1alpha1.RateLimit) *envoy_config_route_v3.RateLimit {
+	rl := &envoy_config_route_v3.RateLimit{
+		Actions: make([]*envoy_config_route_v3.RateLimit_Action, 0, len(opts.Actions)),
+	}
+
+	for _, action := range opts.Actions {
+		switch {
+		case action.RequestHeader != nil:
+			rl.Actions = append(rl.Actions, &envoy_config_route_v3.RateLimit_Action{
+				ActionSpecifier: &envoy_config_route_v3.RateLimit_Action_RequestHeaders_{
+					RequestHeaders: &envoy_config_route_v3.RateLimit_Action_RequestHeaders{
+						HeaderName:    action.RequestHeader.HeaderName,
+						DescriptorKey: action.RequestHeader.DescriptorKey,
+					},
+				},
+			})
+		case action.RemoteAddress != nil:
+			rl.Actions = append(rl.Actions, &envoy_config_route_v3.RateLimit_Action{
+				ActionSpecifier: &envoy_config_route_v3.RateLimit_Action_RemoteAddress_{
+					RemoteAddress: &envoy_config_route_v3.RateLimit_Action_RemoteAddress{},
+				},
+			})
+		case action.GenericKey != nil:
+			rl.Actions = append(rl.Actions, &envoy_config_route_v3.RateLimit_Action{
+				ActionSpecifier: &envoy_config_route_v3.RateLimit_Action_GenericKey_{
+					GenericKey: &envoy_config_route_v3.RateLimit_Action_GenericKey{
+						DescriptorKey:   *action.GenericKey.DescriptorKey,
+						DescriptorValue: action.GenericKey.DescriptorValue,
+					},
+				},
+			})
+		}
+	}
+
+	return rl
+}
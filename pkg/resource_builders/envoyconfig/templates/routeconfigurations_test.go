@@ -2,10 +2,13 @@ package templates
 
 import (
 	"testing"
+	"time"
 
+	"github.com/3scale-ops/basereconciler/util"
 	envoy_serializer_v3 "github.com/3scale-ops/marin3r/pkg/envoy/serializer/v3"
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	"github.com/MakeNowJust/heredoc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/yaml"
 )
@@ -61,6 +64,105 @@ func TestRouteConfiguration_v1(t *testing.T) {
 			`),
 			wantErr: false,
 		},
+		{
+			name: "Generate a route with rate limits",
+			args: args{
+				name: "my_route",
+				opts: &saasv1alpha1.RouteConfiguration{
+					VirtualHosts: []runtime.RawExtension{
+						{
+							Raw: []byte(`{"name":"example","domains":["example.com"],"routes":[{"name":"api","route":{"cluster":"example_cluster"},"match":{"prefix":"/api"}}]}`),
+						},
+					},
+					RateLimits: []saasv1alpha1.RateLimitPolicy{
+						{
+							VirtualHost: "example",
+							Limits: []saasv1alpha1.RateLimit{{
+								Actions:         []saasv1alpha1.RateLimitAction{{RemoteAddress: &saasv1alpha1.RateLimitRemoteAddress{}}},
+								RequestsPerUnit: 100,
+								Unit:            "second",
+							}},
+						},
+						{
+							VirtualHost: "example",
+							Route:       util.Pointer("api"),
+							Limits: []saasv1alpha1.RateLimit{{
+								Actions: []saasv1alpha1.RateLimitAction{
+									{GenericKey: &saasv1alpha1.RateLimitGenericKey{DescriptorKey: util.Pointer("generic_key"), DescriptorValue: "api"}},
+									{RequestHeader: &saasv1alpha1.RateLimitRequestHeader{HeaderName: "x-user", DescriptorKey: "user"}},
+								},
+								RequestsPerUnit: 10,
+								Unit:            "minute",
+							}},
+						},
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                name: my_route
+                virtual_hosts:
+                - domains:
+                  - example.com
+                  name: example
+                  rate_limits:
+                  - actions:
+                    - remote_address: {}
+                  routes:
+                  - match:
+                      prefix: /api
+                    name: api
+                    route:
+                      cluster: example_cluster
+                      rate_limits:
+                      - actions:
+                        - generic_key:
+                            descriptor_key: generic_key
+                            descriptor_value: api
+                        - request_headers:
+                            descriptor_key: user
+                            header_name: x-user
+			`),
+			wantErr: false,
+		},
+		{
+			name: "Fails if the rate limited route does not exist",
+			args: args{
+				name: "my_route",
+				opts: &saasv1alpha1.RouteConfiguration{
+					VirtualHosts: []runtime.RawExtension{
+						{
+							Raw: []byte(`{"name":"example","domains":["example.com"],"routes":[{"route":{"cluster":"example_cluster"},"match":{"prefix":"/"}}]}`),
+						},
+					},
+					RateLimits: []saasv1alpha1.RateLimitPolicy{{
+						VirtualHost: "example",
+						Route:       util.Pointer("api"),
+						Limits: []saasv1alpha1.RateLimit{{
+							Actions:         []saasv1alpha1.RateLimitAction{{RemoteAddress: &saasv1alpha1.RateLimitRemoteAddress{}}},
+							RequestsPerUnit: 100,
+							Unit:            "second",
+						}},
+					}},
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Fails if a virtual host is not valid",
+			args: args{
+				name: "my_route",
+				opts: &saasv1alpha1.RouteConfiguration{
+					VirtualHosts: []runtime.RawExtension{
+						{
+							Raw: []byte(`{"name":"example","routes":[{"route":{"cluster":"example_cluster"},"match":{"prefix":"/"}}]}`),
+						},
+					},
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -69,6 +171,9 @@ func TestRouteConfiguration_v1(t *testing.T) {
 				t.Errorf("RouteConfiguration_v1() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
 
 			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
 			if err != nil {
@@ -84,3 +189,120 @@ func TestRouteConfiguration_v1(t *testing.T) {
 		})
 	}
 }
+
+func TestRouteConfiguration_v2(t *testing.T) {
+	type args struct {
+		name string
+		opts *saasv1alpha1.RouteConfiguration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Applies the retry policy to the virtual hosts without one",
+			args: args{
+				name: "my_route",
+				opts: &saasv1alpha1.RouteConfiguration{
+					VirtualHosts: []runtime.RawExtension{
+						{
+							Raw: []byte(`{"name":"example","domains":["example.com"],"routes":[{"route":{"cluster":"example_cluster"},"match":{"prefix":"/"}}]}`),
+						},
+						{
+							Raw: []byte(`{"name":"example2","domains":["example2.com"],"routes":[{"route":{"cluster":"example_cluster"},"match":{"prefix":"/"}}],"retry_policy":{"retry_on":"reset","num_retries":2}}`),
+						},
+					},
+					RetryPolicy: &saasv1alpha1.RetryPolicy{
+						RetryOn:       "5xx,connect-failure",
+						NumRetries:    util.Pointer[uint32](1),
+						PerTryTimeout: &metav1.Duration{Duration: 2 * time.Second},
+					},
+				},
+			},
+			want: heredoc.Doc(`
+                name: my_route
+                virtual_hosts:
+                - domains:
+                  - example.com
+                  name: example
+                  retry_policy:
+                    num_retries: 1
+                    per_try_timeout: 2s
+                    retry_on: 5xx,connect-failure
+                  routes:
+                  - match:
+                      prefix: /
+                    route:
+                      cluster: example_cluster
+                - domains:
+                  - example2.com
+                  name: example2
+                  retry_policy:
+                    num_retries: 2
+                    retry_on: reset
+                  routes:
+                  - match:
+                      prefix: /
+                    route:
+                      cluster: example_cluster
+			`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RouteConfiguration_v2(tt.args.name, tt.args.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RouteConfiguration_v2() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("RouteConfiguration_v2():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_v1(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *saasv1alpha1.RetryPolicy
+		want string
+	}{
+		{
+			name: "Defaults the unset options",
+			opts: &saasv1alpha1.RetryPolicy{RetryOn: "5xx"},
+			want: heredoc.Doc(`
+                num_retries: 1
+                retry_on: 5xx
+			`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RetryPolicy_v1(tt.opts)
+			j, err := envoy_serializer_v3.JSON{}.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			y, err := yaml.JSONToYAML([]byte(j))
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("RetryPolicy_v1():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package hpa
 
 import (
+	"time"
+
 	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
@@ -11,10 +13,14 @@ import (
 )
 
 // New returns a basereconciler_types.GeneratorFunction function that will return an HorizontalPodAutoscaler
-// resource when called
-func New(key types.NamespacedName, labels map[string]string, cfg saasv1alpha1.HorizontalPodAutoscalerSpec) func(client.Object) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+// resource when called. The replica limits of the scaling profile active at the time of the call are used.
+// The resource metric is omitted if the resource name is "none", so only the custom and external metrics are used.
+// The suggested metrics are added if requested in the spec, unless a metric with the same type and name is configured.
+func New(key types.NamespacedName, labels map[string]string, spec saasv1alpha1.HorizontalPodAutoscalerSpec,
+	suggested ...autoscalingv2.MetricSpec) func(client.Object) (*autoscalingv2.HorizontalPodAutoscaler, error) {
 
 	return func(client.Object) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+		cfg := spec.Scheduled(time.Now())
 		hpa := autoscalingv2.HorizontalPodAutoscaler{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      key.Name,
@@ -39,7 +45,7 @@ func New(key types.NamespacedName, labels map[string]string, cfg saasv1alpha1.Ho
 			hpa.Spec.MaxReplicas = *cfg.MaxReplicas
 		}
 
-		if cfg.ResourceName != nil {
+		if cfg.HasResourceMetric() {
 			hpa.Spec.Metrics = []autoscalingv2.MetricSpec{
 				{
 					Type: autoscalingv2.ResourceMetricSourceType,
@@ -54,6 +60,41 @@ func New(key types.NamespacedName, labels map[string]string, cfg saasv1alpha1.Ho
 			}
 		}
 
+		for _, metric := range cfg.Metrics {
+			hpa.Spec.Metrics = append(hpa.Spec.Metrics, *metric.DeepCopy())
+		}
+
+		if cfg.SuggestedMetrics != nil && *cfg.SuggestedMetrics {
+			for _, metric := range suggested {
+				if !hasMetric(cfg.Metrics, metric) {
+					hpa.Spec.Metrics = append(hpa.Spec.Metrics, *metric.DeepCopy())
+				}
+			}
+		}
+
 		return &hpa, nil
 	}
 }
+
+// hasMetric returns true if a metric with the same type and name as
+// the given one is in the list
+func hasMetric(metrics []autoscalingv2.MetricSpec, metric autoscalingv2.MetricSpec) bool {
+	for _, m := range metrics {
+		if m.Type == metric.Type && metricName(m) == metricName(metric) {
+			return true
+		}
+	}
+	return false
+}
+
+func metricName(metric autoscalingv2.MetricSpec) string {
+	switch {
+	case metric.Pods != nil:
+		return metric.Pods.Metric.Name
+	case metric.Object != nil:
+		return metric.Object.Metric.Name
+	case metric.External != nil:
+		return metric.External.Metric.Name
+	}
+	return ""
+}
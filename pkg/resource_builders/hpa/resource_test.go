@@ -0,0 +1,148 @@
+package hpa
+
+import (
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNew(t *testing.T) {
+	custom := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "custom"},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: util.Pointer(resource.MustParse("10")),
+			},
+		},
+	}
+	cpu := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: util.Pointer[int32](90),
+			},
+		},
+	}
+	suggested := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "suggested"},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: util.Pointer(resource.MustParse("5")),
+			},
+		},
+	}
+	override := *suggested.DeepCopy()
+	override.External.Target.AverageValue = util.Pointer(resource.MustParse("20"))
+	tests := []struct {
+		name      string
+		spec      saasv1alpha1.HorizontalPodAutoscalerSpec
+		suggested []autoscalingv2.MetricSpec
+		want      []autoscalingv2.MetricSpec
+	}{
+		{
+			name: "Scales on the resource metric",
+			spec: saasv1alpha1.HorizontalPodAutoscalerSpec{
+				ResourceName: util.Pointer("cpu"), ResourceUtilization: util.Pointer[int32](90),
+			},
+			want: []autoscalingv2.MetricSpec{cpu},
+		},
+		{
+			name: "Appends the metrics to the resource metric",
+			spec: saasv1alpha1.HorizontalPodAutoscalerSpec{
+				ResourceName: util.Pointer("cpu"), ResourceUtilization: util.Pointer[int32](90),
+				Metrics: []autoscalingv2.MetricSpec{custom},
+			},
+			want: []autoscalingv2.MetricSpec{cpu, custom},
+		},
+		{
+			name: "Omits the resource metric",
+			spec: saasv1alpha1.HorizontalPodAutoscalerSpec{
+				ResourceName: util.Pointer("none"), ResourceUtilization: util.Pointer[int32](90),
+				Metrics: []autoscalingv2.MetricSpec{custom},
+			},
+			want: []autoscalingv2.MetricSpec{custom},
+		},
+		{
+			name: "Adds the suggested metrics if requested",
+			spec: saasv1alpha1.HorizontalPodAutoscalerSpec{
+				ResourceName: util.Pointer("cpu"), ResourceUtilization: util.Pointer[int32](90),
+				Metrics: []autoscalingv2.MetricSpec{custom}, SuggestedMetrics: util.Pointer(true),
+			},
+			suggested: []autoscalingv2.MetricSpec{suggested},
+			want:      []autoscalingv2.MetricSpec{cpu, custom, suggested},
+		},
+		{
+			name: "Configured metrics take precedence over the suggested ones",
+			spec: saasv1alpha1.HorizontalPodAutoscalerSpec{
+				ResourceName: util.Pointer("none"),
+				Metrics:      []autoscalingv2.MetricSpec{override}, SuggestedMetrics: util.Pointer(true),
+			},
+			suggested: []autoscalingv2.MetricSpec{suggested},
+			want:      []autoscalingv2.MetricSpec{override},
+		},
+		{
+			name: "Does not add the suggested metrics if not requested",
+			spec: saasv1alpha1.HorizontalPodAutoscalerSpec{
+				ResourceName: util.Pointer("cpu"), ResourceUtilization: util.Pointer[int32](90),
+				SuggestedMetrics: util.Pointer(false),
+			},
+			suggested: []autoscalingv2.MetricSpec{suggested},
+			want:      []autoscalingv2.MetricSpec{cpu},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.MinReplicas = util.Pointer[int32](1)
+			tt.spec.MaxReplicas = util.Pointer[int32](2)
+			got, err := New(types.NamespacedName{Name: "test", Namespace: "ns"}, nil, tt.spec, tt.suggested...)(nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if diff := deep.Equal(got.Spec.Metrics, tt.want); len(diff) > 0 {
+				t.Errorf("New() = diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestNew_SuggestedMetricsTurnedOff(t *testing.T) {
+	suggested := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "suggested"},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: util.Pointer(resource.MustParse("5")),
+			},
+		},
+	}
+	spec := saasv1alpha1.HorizontalPodAutoscalerSpec{
+		MinReplicas: util.Pointer[int32](1), MaxReplicas: util.Pointer[int32](2),
+		ResourceName: util.Pointer("none"), SuggestedMetrics: util.Pointer(true),
+	}
+	key := types.NamespacedName{Name: "test", Namespace: "ns"}
+
+	got, _ := New(key, nil, spec, suggested)(nil)
+	if diff := deep.Equal(got.Spec.Metrics, []autoscalingv2.MetricSpec{suggested}); len(diff) > 0 {
+		t.Errorf("New() = diff %v", diff)
+	}
+
+	// the suggested metrics go away when the flag is turned off
+	spec.SuggestedMetrics = util.Pointer(false)
+	got, _ = New(key, nil, spec, suggested)(nil)
+	if len(got.Spec.Metrics) != 0 {
+		t.Errorf("New() = %v, want no metrics", got.Spec.Metrics)
+	}
+}
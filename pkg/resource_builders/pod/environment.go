@@ -31,20 +31,28 @@ type SecretValue struct {
 func (sv *SecretValue) ToEnvVar(key string) corev1.EnvVar {
 	s := strings.Split(key, ":")
 	envvar := s[0]
-	secret := s[1]
+	secret, secretKey := sv.source(envvar, s[1])
 
-	if sv.Value.Override != nil {
+	switch {
+	case sv.Value.Override != nil:
 		return corev1.EnvVar{
 			Name:  envvar,
 			Value: *sv.Value.Override,
 		}
+
+	case sv.Value.IsMountedAsFile():
+		// the value is read from a file projected into the secret files volume
+		return corev1.EnvVar{
+			Name:  envvar + SecretFileEnvVarSuffix,
+			Value: SecretFilePath(secret, secretKey),
+		}
 	}
 
 	return corev1.EnvVar{
 		Name: envvar,
 		ValueFrom: &corev1.EnvVarSource{
 			SecretKeyRef: &corev1.SecretKeySelector{
-				Key: envvar,
+				Key: secretKey,
 				LocalObjectReference: corev1.LocalObjectReference{
 					Name: secret,
 				},
@@ -53,6 +61,20 @@ func (sv *SecretValue) ToEnvVar(key string) corev1.EnvVar {
 	}
 }
 
+// source returns the Secret and the key within that Secret that hold the value. This is
+// the referenced Secret when reading directly from a Kubernetes Secret or the Secret
+// generated by the ExternalSecret otherwise.
+func (sv *SecretValue) source(envvar, secret string) (string, string) {
+	if sv.Value.FromKubernetesSecret != nil {
+		return sv.Value.FromKubernetesSecret.Name, sv.Value.FromKubernetesSecret.Key
+	}
+	return secret, envvar
+}
+
+// BuildEnvironment returns the list of environment variables for the given options
+// struct. It panics if a field is not well formed. The whole options struct, including
+// the fields that are not set, is checked in the tests of each options struct, see
+// ValidateOptions.
 func BuildEnvironment(opts interface{}) []corev1.EnvVar {
 	env := []corev1.EnvVar{}
 
@@ -105,3 +127,36 @@ func BuildEnvironment(opts interface{}) []corev1.EnvVar {
 
 	return env
 }
+
+// MergeEnvironment applies the customizations of the given EnvironmentSpec to the
+// environment generated by the operator: overrides replace the variables with the same
+// name, keeping their position, and extra variables not already present are appended.
+func MergeEnvironment(env []corev1.EnvVar, spec saasv1alpha1.EnvironmentSpec) []corev1.EnvVar {
+	merged := make([]corev1.EnvVar, 0, len(env)+len(spec.EnvOverrides)+len(spec.ExtraEnv))
+	merged = append(merged, env...)
+
+	index := func(name string) int {
+		for i := range merged {
+			if merged[i].Name == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, override := range spec.EnvOverrides {
+		if i := index(override.Name); i >= 0 {
+			merged[i] = override
+		} else {
+			merged = append(merged, override)
+		}
+	}
+
+	for _, extra := range spec.ExtraEnv {
+		if index(extra.Name) < 0 {
+			merged = append(merged, extra)
+		}
+	}
+
+	return merged
+}
@@ -77,6 +77,55 @@ func TestSecretValue_ToEnvVar(t *testing.T) {
 			args:   args{key: "key:my-secret"},
 			want:   corev1.EnvVar{Name: "key", Value: "override"},
 		},
+		{
+			name: "Returns EnvVar from a Kubernetes Secret",
+			fields: fields{Value: saasv1alpha1.SecretReference{
+				FromKubernetesSecret: &saasv1alpha1.KubernetesSecretReference{Name: "other-secret", Key: "other-key"}}},
+			args: args{key: "key:my-secret"},
+			want: corev1.EnvVar{
+				Name: "key",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						Key: "other-key",
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "other-secret",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Returns a file pointer EnvVar for a secret mounted as file",
+			fields: fields{Value: saasv1alpha1.SecretReference{
+				FromVault: &saasv1alpha1.VaultSecretReference{}, MountAsFile: util.Pointer(true)}},
+			args: args{key: "key:my-secret"},
+			want: corev1.EnvVar{Name: "key_FILE", Value: "/var/run/secrets/saas-operator/my-secret/key"},
+		},
+		{
+			name: "Returns a file pointer EnvVar for a Kubernetes Secret mounted as file",
+			fields: fields{Value: saasv1alpha1.SecretReference{
+				FromKubernetesSecret: &saasv1alpha1.KubernetesSecretReference{Name: "other-secret", Key: "other-key"},
+				MountAsFile:          util.Pointer(true)}},
+			args: args{key: "key:my-secret"},
+			want: corev1.EnvVar{Name: "key_FILE", Value: "/var/run/secrets/saas-operator/other-secret/other-key"},
+		},
+		{
+			name: "Returns EnvVar from an AWS Secrets Manager secret",
+			fields: fields{Value: saasv1alpha1.SecretReference{
+				FromAWSSecretsManager: &saasv1alpha1.AWSSecretsManagerSecretReference{Name: "aws-secret"}}},
+			args: args{key: "key:my-secret"},
+			want: corev1.EnvVar{
+				Name: "key",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						Key: "key",
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "my-secret",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -226,3 +275,51 @@ func TestBuildEnvironment(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeEnvironment(t *testing.T) {
+	type args struct {
+		env  []corev1.EnvVar
+		spec saasv1alpha1.EnvironmentSpec
+	}
+	tests := []struct {
+		name string
+		args args
+		want []corev1.EnvVar
+	}{
+		{
+			name: "Returns the generated environment",
+			args: args{
+				env:  []corev1.EnvVar{{Name: "A", Value: "a"}},
+				spec: saasv1alpha1.EnvironmentSpec{},
+			},
+			want: []corev1.EnvVar{{Name: "A", Value: "a"}},
+		},
+		{
+			name: "Applies overrides and extra variables",
+			args: args{
+				env: []corev1.EnvVar{{Name: "A", Value: "a"}, {Name: "B", Value: "b"}, {Name: "C", Value: "c"}},
+				spec: saasv1alpha1.EnvironmentSpec{
+					EnvOverrides: []corev1.EnvVar{
+						{Name: "B", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "key"}}},
+						{Name: "D", Value: "d"},
+					},
+					ExtraEnv: []corev1.EnvVar{{Name: "E", Value: "$(A)-e"}, {Name: "C", Value: "ignored"}},
+				},
+			},
+			want: []corev1.EnvVar{
+				{Name: "A", Value: "a"},
+				{Name: "B", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "key"}}},
+				{Name: "C", Value: "c"},
+				{Name: "D", Value: "d"},
+				{Name: "E", Value: "$(A)-e"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeEnvironment(tt.args.env, tt.args.spec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
 	externalsecretsv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -76,20 +77,65 @@ func keysSlice(name string, opts interface{}) []externalsecretsv1beta1.ExternalS
 		}
 
 		secretValue := value.Elem().Elem().Interface().(SecretValue)
-		if secretValue.Value.Override != nil {
-			continue
+		if data := externalSecretData(keyName, secretValue.Value); data != nil {
+			s = append(s, *data)
 		}
-
-		s = append(s, externalsecretsv1beta1.ExternalSecretData{
-			SecretKey: keyName,
-			RemoteRef: externalsecretsv1beta1.ExternalSecretDataRemoteRef{
-				Key:                strings.TrimPrefix(secretValue.Value.FromVault.Path, "secret/data/"),
-				Property:           secretValue.Value.FromVault.Key,
-				ConversionStrategy: "Default",
-				DecodingStrategy:   "None",
-			},
-		})
 	}
 
 	return s
 }
+
+// externalSecretData returns the ExternalSecret data for the given SecretReference,
+// or nil if the value of the SecretReference is not fetched using an ExternalSecret
+func externalSecretData(key string, ref saasv1alpha1.SecretReference) *externalsecretsv1beta1.ExternalSecretData {
+	data := &externalsecretsv1beta1.ExternalSecretData{
+		SecretKey: key,
+		RemoteRef: externalsecretsv1beta1.ExternalSecretDataRemoteRef{
+			ConversionStrategy: "Default",
+			DecodingStrategy:   "None",
+		},
+	}
+
+	switch {
+	case ref.FromVault != nil:
+		data.RemoteRef.Key = strings.TrimPrefix(ref.FromVault.Path, "secret/data/")
+		data.RemoteRef.Property = ref.FromVault.Key
+
+	case ref.FromAWSSecretsManager != nil:
+		data.RemoteRef.Key = ref.FromAWSSecretsManager.Name
+		data.RemoteRef.Property = ref.FromAWSSecretsManager.Key
+		data.RemoteRef.Version = ref.FromAWSSecretsManager.Version
+		data.SourceRef = sourceRef(ref.FromAWSSecretsManager.SecretStoreRef)
+
+	case ref.FromExternalSecretsProvider != nil:
+		data.RemoteRef.Key = ref.FromExternalSecretsProvider.Key
+		data.RemoteRef.Property = ref.FromExternalSecretsProvider.Property
+		data.RemoteRef.Version = ref.FromExternalSecretsProvider.Version
+		data.SourceRef = sourceRef(ref.FromExternalSecretsProvider.SecretStoreRef)
+
+	default:
+		// overrides and Kubernetes Secrets do not require an ExternalSecret
+		return nil
+	}
+
+	return data
+}
+
+// sourceRef returns the SourceRef that overrides the secret store of
+// the ExternalSecret, or nil if no secret store is given
+func sourceRef(store *saasv1alpha1.ExternalSecretSecretStoreReferenceSpec) *externalsecretsv1beta1.SourceRef {
+	if store == nil || store.Name == nil {
+		return nil
+	}
+	ref := &externalsecretsv1beta1.SecretStoreRef{Name: *store.Name}
+	if store.Kind != nil {
+		ref.Kind = *store.Kind
+	}
+	return &externalsecretsv1beta1.SourceRef{SecretStoreRef: ref}
+}
+
+// HasExternalSecretData returns true if any of the values stored in the
+// Secret with the given name needs to be fetched using an ExternalSecret
+func HasExternalSecretData(name string, opts interface{}) bool {
+	return len(keysSlice(name, opts)) > 0
+}
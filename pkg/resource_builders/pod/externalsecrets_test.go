@@ -234,6 +234,54 @@ func Test_keysSlice(t *testing.T) {
 			},
 			wantPanic: false,
 		},
+		{
+			name: "Generates a DataSources map, with several providers",
+			args: args{
+				name: "my-secret",
+				opts: struct {
+					Option1 EnvVarValue `env:"OPTION1" secret:"my-secret"`
+					Option2 EnvVarValue `env:"OPTION2" secret:"my-secret"`
+					Option3 EnvVarValue `env:"OPTION3" secret:"my-secret"`
+				}{
+					Option1: &SecretValue{Value: saasv1alpha1.SecretReference{
+						FromKubernetesSecret: &saasv1alpha1.KubernetesSecretReference{Name: "secret1", Key: "key1"}}},
+					Option2: &SecretValue{Value: saasv1alpha1.SecretReference{
+						FromAWSSecretsManager: &saasv1alpha1.AWSSecretsManagerSecretReference{
+							Name: "secret2", Key: "key2", Version: "AWSPREVIOUS",
+							SecretStoreRef: &saasv1alpha1.ExternalSecretSecretStoreReferenceSpec{
+								Name: util.Pointer("aws"), Kind: util.Pointer("ClusterSecretStore")},
+						}}},
+					Option3: &SecretValue{Value: saasv1alpha1.SecretReference{
+						FromExternalSecretsProvider: &saasv1alpha1.ExternalSecretsProviderSecretReference{
+							Key: "secret3", Property: "key3"}}},
+				},
+			},
+			want: []externalsecretsv1beta1.ExternalSecretData{
+				{
+					SecretKey: "OPTION2",
+					RemoteRef: externalsecretsv1beta1.ExternalSecretDataRemoteRef{
+						Key:                "secret2",
+						Property:           "key2",
+						Version:            "AWSPREVIOUS",
+						ConversionStrategy: "Default",
+						DecodingStrategy:   "None",
+					},
+					SourceRef: &externalsecretsv1beta1.SourceRef{
+						SecretStoreRef: &externalsecretsv1beta1.SecretStoreRef{Name: "aws", Kind: "ClusterSecretStore"},
+					},
+				},
+				{
+					SecretKey: "OPTION3",
+					RemoteRef: externalsecretsv1beta1.ExternalSecretDataRemoteRef{
+						Key:                "secret3",
+						Property:           "key3",
+						ConversionStrategy: "Default",
+						DecodingStrategy:   "None",
+					},
+				},
+			},
+			wantPanic: false,
+		},
 		{
 			name: "Panics if value is not a SecretValue",
 			args: args{
@@ -0,0 +1,67 @@
+package pod
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ValidateOptions checks that the given options struct is well formed so it can be
+// consumed by BuildEnvironment: all its fields must be of EnvVarValue type and have a
+// unique 'env' tag, and fields holding a SecretValue must have a 'secret' tag, which
+// is not allowed in fields holding a ClearTextValue. Fields that are not set are only
+// checked for their type and tags.
+func ValidateOptions(opts interface{}) error {
+	t := reflect.TypeOf(opts)
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("options must be a struct, got '%v'", t)
+	}
+
+	envVars := map[string]string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Ensure field is of EnvVarValue type
+		if field.Type.String() != "pod.EnvVarValue" {
+			return fmt.Errorf("field in '%s/%s' is not a 'pod.EnvVarValue'", t.Name(), field.Name)
+		}
+
+		// Ensure the "env" tag is set and not repeated
+		envVarName, ok := field.Tag.Lookup("env")
+		if !ok || envVarName == "" {
+			return fmt.Errorf("missing 'env' tag in field %s/%s", t.Name(), field.Name)
+		}
+		if other, ok := envVars[envVarName]; ok {
+			return fmt.Errorf("duplicated 'env' tag %q in fields %s/%s and %s/%s",
+				envVarName, t.Name(), other, t.Name(), field.Name)
+		}
+		envVars[envVarName] = field.Name
+
+		secretName, hasSecretTag := field.Tag.Lookup("secret")
+		if hasSecretTag && secretName == "" {
+			return fmt.Errorf("empty 'secret' tag in field %s/%s", t.Name(), field.Name)
+		}
+
+		value := reflect.ValueOf(opts).FieldByName(field.Name)
+		// Skip field if its value is not set
+		if value.IsZero() {
+			continue
+		}
+
+		switch valueType := value.Elem().Type().String(); valueType {
+		case "*pod.ClearTextValue":
+			// If value is of ClearTextValue type it shoud not have the 'secret' tag
+			if hasSecretTag {
+				return fmt.Errorf("unexpected 'secret' tag in field %s/%s", t.Name(), field.Name)
+			}
+		case "*pod.SecretValue":
+			// If value is of SecretValue type it shoud have the 'secret' tag
+			if !hasSecretTag {
+				return fmt.Errorf("missing 'secret' tag in field %s/%s", t.Name(), field.Name)
+			}
+		default:
+			return fmt.Errorf("unsupported type '%s' for field %s/%s", valueType, t.Name(), field.Name)
+		}
+	}
+
+	return nil
+}
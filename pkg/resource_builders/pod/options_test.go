@@ -0,0 +1,87 @@
+package pod
+
+import (
+	"testing"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+)
+
+func TestValidateOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    interface{}
+		wantErr bool
+	}{
+		{
+			name: "Well formed options",
+			opts: struct {
+				Option1 EnvVarValue `env:"OPTION1"`
+				Option2 EnvVarValue `env:"OPTION2" secret:"my-secret"`
+				Option3 EnvVarValue `env:"OPTION3" secret:"my-secret"`
+			}{
+				Option1: &ClearTextValue{Value: "value1"},
+				Option2: &SecretValue{Value: saasv1alpha1.SecretReference{}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Not a struct",
+			opts:    "string",
+			wantErr: true,
+		},
+		{
+			name: "Field not being an EnvVarValue",
+			opts: struct {
+				Option1 string `env:"OPTION1"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "Missing 'env' tag in field not set",
+			opts: struct {
+				Option1 EnvVarValue
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "Duplicated 'env' tag",
+			opts: struct {
+				Option1 EnvVarValue `env:"OPTION1"`
+				Option2 EnvVarValue `env:"OPTION1"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "Empty 'secret' tag",
+			opts: struct {
+				Option1 EnvVarValue `env:"OPTION1" secret:""`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "Missing 'secret' tag",
+			opts: struct {
+				Option1 EnvVarValue `env:"OPTION1"`
+			}{
+				Option1: &SecretValue{Value: saasv1alpha1.SecretReference{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unexpected 'secret' tag",
+			opts: struct {
+				Option1 EnvVarValue `env:"OPTION1" secret:"my-secret"`
+			}{
+				Option1: &ClearTextValue{Value: "value1"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateOptions(tt.opts); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package pod
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/3scale-ops/basereconciler/mutators"
+	"github.com/3scale-ops/basereconciler/resource"
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SecretNames returns the sorted list of Secrets that the environment built by
+// BuildEnvironment for the given options reads values from, either directly or through
+// the secret files volume. This includes both the Secrets generated from ExternalSecrets
+// and the Kubernetes Secrets directly referenced, also from the extraEnv and envOverrides
+// of the given EnvironmentSpecs.
+func SecretNames(opts interface{}, envs ...saasv1alpha1.EnvironmentSpec) []string {
+	env := BuildEnvironment(opts)
+	for _, spec := range envs {
+		env = MergeEnvironment(env, spec)
+	}
+
+	names := []string{}
+	for _, envvar := range env {
+		if envvar.ValueFrom == nil || envvar.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		names = appendIfMissing(names, envvar.ValueFrom.SecretKeyRef.Name)
+	}
+	for _, f := range secretFiles(opts) {
+		names = appendIfMissing(names, f.secret)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SecretRolloutTriggers returns the template mutations that annotate the pod template
+// with the hash of the data of each of the Secrets returned by SecretNames, so
+// a rollout of the pods is triggered whenever the value of any of them changes.
+// No mutations are returned if the triggers are not enabled.
+func SecretRolloutTriggers(enabled bool, opts interface{}, envs ...saasv1alpha1.EnvironmentSpec) []resource.TemplateMutationFunction {
+	if !enabled {
+		return nil
+	}
+	secrets := SecretNames(opts, envs...)
+	triggers := make([]resource.TemplateMutationFunction, 0, len(secrets))
+	for _, secret := range secrets {
+		triggers = append(triggers, SecretRolloutTrigger(secret))
+	}
+	return triggers
+}
+
+// SecretRolloutTrigger returns a template mutation that annotates the pod template with
+// the hash of the data of the given Secret
+func SecretRolloutTrigger(secret string) resource.TemplateMutationFunction {
+	return mutators.RolloutTrigger{Name: secret, SecretName: util.Pointer(secret)}.Add()
+}
+
+// SecretHashes returns the hashes of the Secrets that have been added
+// to the pod template by the rollout triggers, keyed by Secret name
+func SecretHashes(domain string, template corev1.PodTemplateSpec) map[string]string {
+	hashes := map[string]string{}
+	prefix, suffix := domain+"/", ".secret-hash"
+	for key, value := range template.GetAnnotations() {
+		if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, suffix) {
+			hashes[strings.TrimSuffix(strings.TrimPrefix(key, prefix), suffix)] = value
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	return hashes
+}
+
+func appendIfMissing(list []string, item string) []string {
+	for _, i := range list {
+		if i == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
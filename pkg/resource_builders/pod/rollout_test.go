@@ -0,0 +1,125 @@
+package pod
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretNames(t *testing.T) {
+	type args struct {
+		opts interface{}
+		envs []saasv1alpha1.EnvironmentSpec
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "Returns the Secrets the environment reads from",
+			args: args{opts: struct {
+				Option1 EnvVarValue `env:"OPTION1" secret:"secret-b"`
+				Option2 EnvVarValue `env:"OPTION2" secret:"secret-a"`
+				Option3 EnvVarValue `env:"OPTION3" secret:"secret-b"`
+				Option4 EnvVarValue `env:"OPTION4"`
+				Option5 EnvVarValue `env:"OPTION5" secret:"secret-c"`
+				Option6 EnvVarValue `env:"OPTION6" secret:"secret-d"`
+				Option7 EnvVarValue `env:"OPTION7" secret:"secret-e"`
+			}{
+				Option1: &SecretValue{Value: saasv1alpha1.SecretReference{FromVault: &saasv1alpha1.VaultSecretReference{}}},
+				Option2: &SecretValue{Value: saasv1alpha1.SecretReference{FromVault: &saasv1alpha1.VaultSecretReference{}}},
+				Option3: &SecretValue{Value: saasv1alpha1.SecretReference{FromVault: &saasv1alpha1.VaultSecretReference{}}},
+				Option4: &ClearTextValue{Value: "value"},
+				Option5: &SecretValue{Value: saasv1alpha1.SecretReference{Override: util.Pointer("override")}},
+				Option6: &SecretValue{Value: saasv1alpha1.SecretReference{
+					FromKubernetesSecret: &saasv1alpha1.KubernetesSecretReference{Name: "my-secret", Key: "key"}}},
+			}},
+			want: []string{"my-secret", "secret-a", "secret-b"},
+		},
+		{
+			name: "Returns the Secrets referenced from extraEnv and envOverrides",
+			args: args{
+				opts: struct {
+					Option1 EnvVarValue `env:"OPTION1" secret:"secret-a"`
+					Option2 EnvVarValue `env:"OPTION2" secret:"secret-b"`
+				}{
+					Option1: &SecretValue{Value: saasv1alpha1.SecretReference{FromVault: &saasv1alpha1.VaultSecretReference{}}},
+					Option2: &SecretValue{Value: saasv1alpha1.SecretReference{FromVault: &saasv1alpha1.VaultSecretReference{}}},
+				},
+				envs: []saasv1alpha1.EnvironmentSpec{{
+					ExtraEnv: []corev1.EnvVar{{Name: "EXTRA", ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "extra-secret"}, Key: "key"}}}},
+					EnvOverrides: []corev1.EnvVar{
+						{Name: "OPTION2", Value: "overridden"},
+						{Name: "OVERRIDE", ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "override-secret"}, Key: "key"}}},
+					},
+				}},
+			},
+			want: []string{"extra-secret", "override-secret", "secret-a"},
+		},
+		{
+			name: "Returns an empty list",
+			args: args{opts: struct {
+				Option1 EnvVarValue `env:"OPTION1"`
+			}{
+				Option1: &ClearTextValue{Value: "value"},
+			}},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SecretNames(tt.args.opts, tt.args.envs...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SecretNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretHashes(t *testing.T) {
+	type args struct {
+		domain   string
+		template corev1.PodTemplateSpec
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]string
+	}{
+		{
+			name: "Returns the hashes of the Secrets",
+			args: args{
+				domain: "example.com",
+				template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					"example.com/secret-a.secret-hash":     "1111",
+					"example.com/secret-b.secret-hash":     "2222",
+					"example.com/cm.configmap-hash":        "3333",
+					"other.com/secret-c.secret-hash":       "4444",
+					"example.com/blue-green-template-hash": "5555",
+				}}},
+			},
+			want: map[string]string{"secret-a": "1111", "secret-b": "2222"},
+		},
+		{
+			name: "Returns nil if there are no hashes",
+			args: args{
+				domain:   "example.com",
+				template: corev1.PodTemplateSpec{},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SecretHashes(tt.args.domain, tt.args.template); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SecretHashes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package pod
+
+import (
+	"path"
+	"reflect"
+
+	"github.com/3scale-ops/basereconciler/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SecretFilesVolumeName is the name of the volume
+	// where secret values are projected as files
+	SecretFilesVolumeName string = "secret-files"
+	// SecretFilesMountPath is the path where the
+	// secret files volume is mounted in the containers
+	SecretFilesMountPath string = "/var/run/secrets/saas-operator"
+	// SecretFileEnvVarSuffix is appended to the name of the environment
+	// variable that points to the file holding a secret value
+	SecretFileEnvVarSuffix string = "_FILE"
+)
+
+// SecretFilePath returns the path of the file that holds
+// the value of the given key of a Secret
+func SecretFilePath(secret, key string) string {
+	return path.Join(SecretFilesMountPath, secret, key)
+}
+
+type secretFile struct {
+	secret string
+	key    string
+}
+
+// secretFiles returns the Secret keys that the given options
+// struct delivers as files, in order of appearance
+func secretFiles(opts interface{}) []secretFile {
+	files := []secretFile{}
+
+	t := reflect.TypeOf(opts)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := reflect.ValueOf(opts).FieldByName(field.Name)
+		if value.IsZero() {
+			continue
+		}
+		sv, ok := value.Interface().(*SecretValue)
+		if !ok || !sv.Value.IsMountedAsFile() {
+			continue
+		}
+		secret, key := sv.source(field.Tag.Get("env"), field.Tag.Get("secret"))
+		if f := (secretFile{secret: secret, key: key}); !containsSecretFile(files, f) {
+			files = append(files, f)
+		}
+	}
+
+	return files
+}
+
+// SecretFilesVolume returns a read-only projected volume with the Secret keys
+// that the given options struct delivers as files. Returns nil if there are none.
+func SecretFilesVolume(opts interface{}) *corev1.Volume {
+	files := secretFiles(opts)
+	if len(files) == 0 {
+		return nil
+	}
+
+	sources := []corev1.VolumeProjection{}
+	for _, f := range files {
+		item := corev1.KeyToPath{Key: f.key, Path: path.Join(f.secret, f.key)}
+		var projection *corev1.SecretProjection
+		for i := range sources {
+			if sources[i].Secret.Name == f.secret {
+				projection = sources[i].Secret
+			}
+		}
+		if projection != nil {
+			projection.Items = append(projection.Items, item)
+			continue
+		}
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: f.secret},
+				Items:                []corev1.KeyToPath{item},
+			},
+		})
+	}
+
+	return &corev1.Volume{
+		Name: SecretFilesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources:     sources,
+				DefaultMode: util.Pointer[int32](420),
+			},
+		},
+	}
+}
+
+// SecretFilesVolumeMount returns the read-only mount for the secret files volume
+func SecretFilesVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      SecretFilesVolumeName,
+		ReadOnly:  true,
+		MountPath: SecretFilesMountPath,
+	}
+}
+
+// AddSecretFiles adds the secret files volume to the pod template and mounts it in all
+// of its containers, so the "_FILE" environment variables generated by BuildEnvironment
+// point to existing files. The template is returned unmodified if the given options
+// struct does not deliver any secret value as a file.
+func AddSecretFiles(template corev1.PodTemplateSpec, opts interface{}) corev1.PodTemplateSpec {
+	volume := SecretFilesVolume(opts)
+	if volume == nil {
+		return template
+	}
+
+	template.Spec.Volumes = append(template.Spec.Volumes, *volume)
+	for i := range template.Spec.InitContainers {
+		template.Spec.InitContainers[i].VolumeMounts = append(template.Spec.InitContainers[i].VolumeMounts, SecretFilesVolumeMount())
+	}
+	for i := range template.Spec.Containers {
+		template.Spec.Containers[i].VolumeMounts = append(template.Spec.Containers[i].VolumeMounts, SecretFilesVolumeMount())
+	}
+
+	return template
+}
+
+func containsSecretFile(files []secretFile, f secretFile) bool {
+	for _, file := range files {
+		if file == f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package pod
+
+import (
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSecretFilesVolume(t *testing.T) {
+	type args struct {
+		opts interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want *corev1.Volume
+	}{
+		{
+			name: "Projects the keys of the secrets mounted as files",
+			args: args{opts: struct {
+				Option1 EnvVarValue `env:"OPTION1" secret:"secret-a"`
+				Option2 EnvVarValue `env:"OPTION2" secret:"secret-a"`
+				Option3 EnvVarValue `env:"OPTION3" secret:"secret-b"`
+				Option4 EnvVarValue `env:"OPTION4" secret:"secret-b"`
+				Option5 EnvVarValue `env:"OPTION5"`
+			}{
+				Option1: &SecretValue{Value: saasv1alpha1.SecretReference{
+					FromVault: &saasv1alpha1.VaultSecretReference{}, MountAsFile: util.Pointer(true)}},
+				Option2: &SecretValue{Value: saasv1alpha1.SecretReference{
+					FromVault: &saasv1alpha1.VaultSecretReference{}, MountAsFile: util.Pointer(true)}},
+				Option3: &SecretValue{Value: saasv1alpha1.SecretReference{
+					FromKubernetesSecret: &saasv1alpha1.KubernetesSecretReference{Name: "other", Key: "key"},
+					MountAsFile:          util.Pointer(true)}},
+				Option4: &SecretValue{Value: saasv1alpha1.SecretReference{FromVault: &saasv1alpha1.VaultSecretReference{}}},
+				Option5: &ClearTextValue{Value: "value"},
+			}},
+			want: &corev1.Volume{
+				Name: "secret-files",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{
+							{Secret: &corev1.SecretProjection{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "secret-a"},
+								Items: []corev1.KeyToPath{
+									{Key: "OPTION1", Path: "secret-a/OPTION1"},
+									{Key: "OPTION2", Path: "secret-a/OPTION2"},
+								},
+							}},
+							{Secret: &corev1.SecretProjection{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "other"},
+								Items:                []corev1.KeyToPath{{Key: "key", Path: "other/key"}},
+							}},
+						},
+						DefaultMode: util.Pointer[int32](420),
+					},
+				},
+			},
+		},
+		{
+			name: "Returns nil if no secret is mounted as file",
+			args: args{opts: struct {
+				Option1 EnvVarValue `env:"OPTION1" secret:"secret-a"`
+				Option2 EnvVarValue `env:"OPTION2" secret:"secret-a"`
+			}{
+				Option1: &SecretValue{Value: saasv1alpha1.SecretReference{FromVault: &saasv1alpha1.VaultSecretReference{}}},
+				Option2: &SecretValue{Value: saasv1alpha1.SecretReference{
+					Override: util.Pointer("value"), MountAsFile: util.Pointer(true)}},
+			}},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(SecretFilesVolume(tt.args.opts), tt.want); len(diff) > 0 {
+				t.Errorf("SecretFilesVolume() got diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestAddSecretFiles(t *testing.T) {
+	opts := struct {
+		Option1 EnvVarValue `env:"OPTION1" secret:"secret-a"`
+	}{
+		Option1: &SecretValue{Value: saasv1alpha1.SecretReference{
+			FromVault: &saasv1alpha1.VaultSecretReference{}, MountAsFile: util.Pointer(true)}},
+	}
+	template := corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init"}},
+		Containers:     []corev1.Container{{Name: "main"}, {Name: "other"}},
+	}}
+
+	got := AddSecretFiles(template, opts)
+
+	if len(got.Spec.Volumes) != 1 || got.Spec.Volumes[0].Name != SecretFilesVolumeName {
+		t.Errorf("AddSecretFiles() volumes = %v", got.Spec.Volumes)
+	}
+	for _, c := range append(got.Spec.InitContainers, got.Spec.Containers...) {
+		if diff := deep.Equal(c.VolumeMounts, []corev1.VolumeMount{SecretFilesVolumeMount()}); len(diff) > 0 {
+			t.Errorf("AddSecretFiles() container %s got diff %v", c.Name, diff)
+		}
+	}
+	if env := BuildEnvironment(opts); env[0].Name != "OPTION1_FILE" || env[0].Value != "/var/run/secrets/saas-operator/secret-a/OPTION1" {
+		t.Errorf("BuildEnvironment() = %v", env)
+	}
+	if names := SecretNames(opts); len(names) != 1 || names[0] != "secret-a" {
+		t.Errorf("SecretNames() = %v", names)
+	}
+}
@@ -0,0 +1,132 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sort"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+)
+
+// Config is the configuration of a rate limit domain in the
+// format expected by the envoyproxy/ratelimit service
+type Config struct {
+	Domain      string        `json:"domain"`
+	Descriptors []*Descriptor `json:"descriptors"`
+}
+
+// Descriptor is a node in the tree of descriptors of a rate limit domain
+type Descriptor struct {
+	Key         string        `json:"key"`
+	Value       string        `json:"value,omitempty"`
+	RateLimit   *Limit        `json:"rate_limit,omitempty"`
+	Descriptors []*Descriptor `json:"descriptors,omitempty"`
+}
+
+// Limit is the number of requests allowed per unit of time for a descriptor
+type Limit struct {
+	Unit            string `json:"unit"`
+	RequestsPerUnit uint32 `json:"requests_per_unit"`
+}
+
+// Enabled returns true if any of the given envoy dynamic
+// configurations defines rate limits
+func Enabled(resources ...descriptor.EnvoyDynamicConfigDescriptor) bool {
+	for _, res := range resources {
+		if rc, ok := res.GetOptions().(*saasv1alpha1.RouteConfiguration); ok && len(rc.RateLimits) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateConfigs returns the rate limit service configuration of each of the domains
+// used by the listeners within the given envoy dynamic configurations. The descriptors
+// of each domain are generated from the rate limits of the route configurations used
+// by the listeners of the domain.
+func GenerateConfigs(resources ...descriptor.EnvoyDynamicConfigDescriptor) ([]Config, error) {
+
+	routes := map[string]*saasv1alpha1.RouteConfiguration{}
+	for _, res := range resources {
+		if rc, ok := res.GetOptions().(*saasv1alpha1.RouteConfiguration); ok {
+			routes[res.GetName()] = rc
+		}
+	}
+
+	// domain -> names of the route configurations used in the domain
+	domains := map[string][]string{}
+	for _, res := range resources {
+		l, ok := res.GetOptions().(*saasv1alpha1.ListenerHttp)
+		if !ok || l.RateLimitOptions == nil {
+			continue
+		}
+		if rc, ok := routes[l.RouteConfigName]; !ok || len(rc.RateLimits) == 0 {
+			continue
+		}
+		if !contains(domains[l.RateLimitOptions.Domain], l.RouteConfigName) {
+			domains[l.RateLimitOptions.Domain] = append(domains[l.RateLimitOptions.Domain], l.RouteConfigName)
+		}
+	}
+
+	configs := make([]Config, 0, len(domains))
+	for domain, names := range domains {
+		config := Config{Domain: domain, Descriptors: []*Descriptor{}}
+		for _, name := range names {
+			for _, policy := range routes[name].RateLimits {
+				for _, limit := range policy.Limits {
+					if err := config.add(limit); err != nil {
+						return nil, fmt.Errorf("route configuration '%s': %w", name, err)
+					}
+				}
+			}
+		}
+		configs = append(configs, config)
+	}
+
+	// ensure consistent order of configs
+	sort.Slice(configs, func(a, b int) bool {
+		return configs[a].Domain < configs[b].Domain
+	})
+
+	return configs, nil
+}
+
+// add merges the descriptor generated by the actions of the rate limit
+// into the tree of descriptors of the domain
+func (c *Config) add(limit saasv1alpha1.RateLimit) error {
+	var node *Descriptor
+	children := &c.Descriptors
+
+	for i := range limit.Actions {
+		key, value := limit.Actions[i].DescriptorEntry()
+		node = nil
+		for _, d := range *children {
+			if d.Key == key && d.Value == value {
+				node = d
+				break
+			}
+		}
+		if node == nil {
+			node = &Descriptor{Key: key, Value: value}
+			*children = append(*children, node)
+		}
+		children = &node.Descriptors
+	}
+
+	l := &Limit{Unit: limit.Unit, RequestsPerUnit: limit.RequestsPerUnit}
+	if node.RateLimit != nil && *node.RateLimit != *l {
+		return fmt.Errorf("descriptor '%s' has conflicting rate limits", node.Key)
+	}
+	node.RateLimit = l
+
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+	"github.com/MakeNowJust/heredoc"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+func testResources(routeRateLimits []saasv1alpha1.RateLimitPolicy) []descriptor.EnvoyDynamicConfigDescriptor {
+	return saasv1alpha1.MapOfEnvoyDynamicConfig{
+		"http": {
+			GeneratorVersion: util.Pointer("v1"),
+			ListenerHttp: &saasv1alpha1.ListenerHttp{
+				Port:            8080,
+				RouteConfigName: "router",
+				RateLimitOptions: &saasv1alpha1.RateLimitOptions{
+					Domain:           "apicast",
+					RateLimitCluster: "limitador",
+				},
+			},
+		},
+		"router": {
+			GeneratorVersion: util.Pointer("v1"),
+			RouteConfiguration: &saasv1alpha1.RouteConfiguration{
+				VirtualHosts: []runtime.RawExtension{},
+				RateLimits:   routeRateLimits,
+			},
+		},
+	}.AsList()
+}
+
+func TestGenerateConfigs(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []descriptor.EnvoyDynamicConfigDescriptor
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "Generates the descriptors of the domain",
+			resources: testResources([]saasv1alpha1.RateLimitPolicy{
+				{
+					VirtualHost: "example",
+					Limits: []saasv1alpha1.RateLimit{
+						{
+							Actions:         []saasv1alpha1.RateLimitAction{{RemoteAddress: &saasv1alpha1.RateLimitRemoteAddress{}}},
+							RequestsPerUnit: 100,
+							Unit:            "second",
+						},
+						{
+							Actions: []saasv1alpha1.RateLimitAction{
+								{GenericKey: &saasv1alpha1.RateLimitGenericKey{DescriptorKey: util.Pointer("generic_key"), DescriptorValue: "api"}},
+								{RequestHeader: &saasv1alpha1.RateLimitRequestHeader{HeaderName: "x-user", DescriptorKey: "user"}},
+							},
+							RequestsPerUnit: 10,
+							Unit:            "minute",
+						},
+					},
+				},
+				{
+					VirtualHost: "example",
+					Route:       util.Pointer("api"),
+					Limits: []saasv1alpha1.RateLimit{{
+						Actions: []saasv1alpha1.RateLimitAction{
+							{GenericKey: &saasv1alpha1.RateLimitGenericKey{DescriptorKey: util.Pointer("generic_key"), DescriptorValue: "api"}},
+						},
+						RequestsPerUnit: 1000,
+						Unit:            "hour",
+					}},
+				},
+			}),
+			want: heredoc.Doc(`
+				- descriptors:
+				  - key: remote_address
+				    rate_limit:
+				      requests_per_unit: 100
+				      unit: second
+				  - descriptors:
+				    - key: user
+				      rate_limit:
+				        requests_per_unit: 10
+				        unit: minute
+				    key: generic_key
+				    rate_limit:
+				      requests_per_unit: 1000
+				      unit: hour
+				    value: api
+				  domain: apicast
+			`),
+			wantErr: false,
+		},
+		{
+			name:      "No rate limits",
+			resources: testResources(nil),
+			want: heredoc.Doc(`
+				[]
+			`),
+			wantErr: false,
+		},
+		{
+			name: "Fails on conflicting limits",
+			resources: testResources([]saasv1alpha1.RateLimitPolicy{{
+				VirtualHost: "example",
+				Limits: []saasv1alpha1.RateLimit{
+					{
+						Actions:         []saasv1alpha1.RateLimitAction{{RemoteAddress: &saasv1alpha1.RateLimitRemoteAddress{}}},
+						RequestsPerUnit: 100,
+						Unit:            "second",
+					},
+					{
+						Actions:         []saasv1alpha1.RateLimitAction{{RemoteAddress: &saasv1alpha1.RateLimitRemoteAddress{}}},
+						RequestsPerUnit: 10,
+						Unit:            "second",
+					},
+				},
+			}}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateConfigs(tt.resources...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateConfigs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			y, err := yaml.Marshal(got)
+			if err != nil {
+				t.Error(err)
+			}
+			if string(y) != tt.want {
+				t.Errorf("GenerateConfigs():\n# got:\n%v\n# want:\n%v", string(y), tt.want)
+			}
+		})
+	}
+}
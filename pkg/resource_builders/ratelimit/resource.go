@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// New returns a basereconciler_types.GeneratorFunction function that will return a ConfigMap
+// with the rate limit service configuration of the given envoy dynamic configurations. There
+// is one key in the ConfigMap per rate limit domain.
+func New(key types.NamespacedName, labels map[string]string,
+	resources ...descriptor.EnvoyDynamicConfigDescriptor) func(client.Object) (*corev1.ConfigMap, error) {
+
+	return func(client.Object) (*corev1.ConfigMap, error) {
+
+		configs, err := GenerateConfigs(resources...)
+		if err != nil {
+			return nil, err
+		}
+
+		data := make(map[string]string, len(configs))
+		for _, config := range configs {
+			y, err := yaml.Marshal(config)
+			if err != nil {
+				return nil, err
+			}
+			data[config.Domain+".yaml"] = string(y)
+		}
+
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels:    labels,
+			},
+			Data: data,
+		}, nil
+	}
+}
@@ -0,0 +1,122 @@
+package twemproxy
+
+import (
+	"crypto/md5"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// These values match the ones used by twemproxy
+	// to build the ketama continuum (see nc_ketama.c)
+	ketamaPointsPerServer int = 160
+	ketamaPointsPerHash   int = 4
+	// size of the hash space (32 bit)
+	ketamaHashSpace float64 = 1 << 32
+)
+
+type continuumPoint struct {
+	value  uint32
+	server int
+}
+
+// Continuum is a ketama hash ring built in the same way twemproxy does
+// for server pools using the "ketama" distribution
+type Continuum struct {
+	servers []Server
+	points  []continuumPoint
+}
+
+// NewContinuum returns the ketama hash ring for the given list of servers
+func NewContinuum(servers []Server) *Continuum {
+	c := &Continuum{servers: servers, points: []continuumPoint{}}
+
+	totalWeight := 0
+	for _, srv := range servers {
+		totalWeight += srv.Priority
+	}
+
+	for idx, srv := range servers {
+		if srv.Priority <= 0 {
+			continue
+		}
+		pct := float64(srv.Priority) / float64(totalWeight)
+		pointsPerServer := int(math.Floor(pct*float64(ketamaPointsPerServer)/float64(ketamaPointsPerHash)*
+			float64(len(servers))+0.0000000001)) * ketamaPointsPerHash
+
+		for p := 0; p < pointsPerServer/ketamaPointsPerHash; p++ {
+			digest := md5.Sum([]byte(fmt.Sprintf("%s-%d", srv.ketamaName(), p)))
+			for x := 0; x < ketamaPointsPerHash; x++ {
+				value := uint32(digest[3+x*4])<<24 | uint32(digest[2+x*4])<<16 |
+					uint32(digest[1+x*4])<<8 | uint32(digest[x*4])
+				c.points = append(c.points, continuumPoint{value: value, server: idx})
+			}
+		}
+	}
+
+	sort.SliceStable(c.points, func(i, j int) bool { return c.points[i].value < c.points[j].value })
+	return c
+}
+
+// Dispatch returns the server that owns the given hash. The second return
+// value is false if the continuum is empty.
+func (c *Continuum) Dispatch(hash uint32) (Server, bool) {
+	if len(c.points) == 0 {
+		return Server{}, false
+	}
+	idx := sort.Search(len(c.points), func(i int) bool { return c.points[i].value >= hash })
+	if idx == len(c.points) {
+		idx = 0
+	}
+	return c.servers[c.points[idx].server], true
+}
+
+// KeysRemapped returns the fraction (a value between 0 and 1) of the hash
+// space that is routed to a different server address in the desired continuum
+// when compared with the current one. Assuming that keys are evenly distributed
+// across the hash space, this is an estimation of the fraction of keys affected
+// by a change in the configuration of a server pool.
+func KeysRemapped(current, desired *Continuum) float64 {
+	values := make([]uint32, 0, len(current.points)+len(desired.points))
+	for _, p := range current.points {
+		values = append(values, p.value)
+	}
+	for _, p := range desired.points {
+		values = append(values, p.value)
+	}
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	// Each interval between two consecutive points of the merged continuums
+	// is owned by the same server in each of the continuums
+	remapped := 0.0
+	for i, value := range values {
+		var size float64
+		if i == 0 {
+			// the first interval wraps around the end of the hash space
+			size = float64(value) + ketamaHashSpace - float64(values[len(values)-1])
+		} else {
+			size = float64(value) - float64(values[i-1])
+		}
+
+		csrv, cok := current.Dispatch(value)
+		dsrv, dok := desired.Dispatch(value)
+		if cok != dok || csrv.Address != dsrv.Address {
+			remapped += size
+		}
+	}
+
+	return remapped / ketamaHashSpace
+}
+
+// ketamaName returns the name used to compute the server's points in
+// the continuum. Twemproxy uses the server name if set or the address otherwise.
+func (srv *Server) ketamaName() string {
+	if srv.Name != "" {
+		return srv.Name
+	}
+	return srv.Address
+}
@@ -0,0 +1,93 @@
+package twemproxy
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testServers(addresses ...string) []Server {
+	servers := make([]Server, 0, len(addresses))
+	for idx, address := range addresses {
+		servers = append(servers, Server{Address: address, Priority: 1, Name: fmt.Sprintf("shard%02d", idx)})
+	}
+	return servers
+}
+
+func TestNewContinuum(t *testing.T) {
+	c := NewContinuum(testServers("127.0.0.1:1000", "127.0.0.1:2000", "127.0.0.1:3000"))
+	if got, want := len(c.points), 3*ketamaPointsPerServer; got != want {
+		t.Errorf("NewContinuum() points = %v, want %v", got, want)
+	}
+	for i := 1; i < len(c.points); i++ {
+		if c.points[i-1].value > c.points[i].value {
+			t.Fatalf("NewContinuum() points are not sorted")
+		}
+	}
+}
+
+func TestContinuum_Dispatch(t *testing.T) {
+	c := NewContinuum(testServers("127.0.0.1:1000", "127.0.0.1:2000"))
+
+	// hash equal to a point is owned by the server of the point
+	srv, ok := c.Dispatch(c.points[10].value)
+	if !ok || srv.Name != c.servers[c.points[10].server].Name {
+		t.Errorf("Continuum.Dispatch() = %v, want %v", srv.Name, c.servers[c.points[10].server].Name)
+	}
+	// hashes beyond the last point wrap around to the first one
+	if c.points[len(c.points)-1].value < ^uint32(0) {
+		srv, _ := c.Dispatch(^uint32(0))
+		if srv.Name != c.servers[c.points[0].server].Name {
+			t.Errorf("Continuum.Dispatch() = %v, want %v", srv.Name, c.servers[c.points[0].server].Name)
+		}
+	}
+	// empty continuum
+	if _, ok := NewContinuum([]Server{}).Dispatch(0); ok {
+		t.Errorf("Continuum.Dispatch() expected to fail for an empty continuum")
+	}
+}
+
+func TestKeysRemapped(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []Server
+		desired  []Server
+		min, max float64
+	}{
+		{
+			name:    "No changes",
+			current: testServers("127.0.0.1:1000", "127.0.0.1:1000", "127.0.0.1:2000", "127.0.0.1:2000"),
+			desired: testServers("127.0.0.1:1000", "127.0.0.1:1000", "127.0.0.1:2000", "127.0.0.1:2000"),
+			min:     0,
+			max:     0,
+		},
+		{
+			name:    "One of four logical shards remapped",
+			current: testServers("127.0.0.1:1000", "127.0.0.1:1000", "127.0.0.1:2000", "127.0.0.1:2000"),
+			desired: testServers("127.0.0.1:1000", "127.0.0.1:1000", "127.0.0.1:2000", "127.0.0.1:3000"),
+			min:     0.15,
+			max:     0.35,
+		},
+		{
+			name:    "Logical shard added",
+			current: testServers("127.0.0.1:1000", "127.0.0.1:2000", "127.0.0.1:3000"),
+			desired: testServers("127.0.0.1:1000", "127.0.0.1:2000", "127.0.0.1:3000", "127.0.0.1:4000"),
+			min:     0.15,
+			max:     0.35,
+		},
+		{
+			name:    "New pool",
+			current: []Server{},
+			desired: testServers("127.0.0.1:1000"),
+			min:     1,
+			max:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := KeysRemapped(NewContinuum(tt.current), NewContinuum(tt.desired))
+			if got < tt.min || got > tt.max {
+				t.Errorf("KeysRemapped() = %v, want value in range [%v, %v]", got, tt.min, tt.max)
+			}
+		})
+	}
+}
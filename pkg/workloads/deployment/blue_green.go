@@ -0,0 +1,344 @@
+package delpoyment_workload
+
+import (
+	"context"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/resource"
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// blueGreenTemplateHashAnnotation holds the hash of the pod template that a color runs
+	blueGreenTemplateHashAnnotation string = saasv1alpha1.AnnotationsDomain + "/blue-green-template-hash"
+	// blueGreenActiveSinceAnnotation holds the time at which a color started receiving traffic
+	blueGreenActiveSinceAnnotation string = saasv1alpha1.AnnotationsDomain + "/blue-green-active-since"
+	// marin3rNodeIDAnnotation is the pod annotation that configures the node-id of the Envoy sidecar
+	marin3rNodeIDAnnotation string = "marin3r.3scale.net/node-id"
+)
+
+// blueGreen holds the two colors of a workload that uses the blue/green deployment strategy
+type blueGreen struct {
+	workload DeploymentWorkload
+	strategy *saasv1alpha1.DeploymentStrategySpec
+	blue     *colorWorkload
+	green    *colorWorkload
+}
+
+// newBlueGreen returns a blueGreen if the workload is configured to use
+// the blue/green deployment strategy, or nil otherwise
+func newBlueGreen(w DeploymentWorkload) *blueGreen {
+	if unwrapNil(w) == nil {
+		return nil
+	}
+	wbg, ok := w.(WithBlueGreen)
+	if !ok || !wbg.DeploymentStrategySpec().IsBlueGreen() {
+		return nil
+	}
+	bg := &blueGreen{workload: w, strategy: wbg.DeploymentStrategySpec()}
+	bg.blue = &colorWorkload{DeploymentWorkload: w, color: saasv1alpha1.BlueDeploymentColor, bg: bg}
+	bg.green = &colorWorkload{DeploymentWorkload: w, color: saasv1alpha1.GreenDeploymentColor, bg: bg}
+	return bg
+}
+
+func (bg *blueGreen) colors() []*colorWorkload {
+	return []*colorWorkload{bg.blue, bg.green}
+}
+
+func (bg *blueGreen) color(c saasv1alpha1.DeploymentColor) *colorWorkload {
+	if c == saasv1alpha1.GreenDeploymentColor {
+		return bg.green
+	}
+	return bg.blue
+}
+
+// colorWorkload is a DeploymentWorkload that generates the resources of one of the colors
+// of a blue/green workload. The blue color uses the name and selector of the workload, so
+// the existing Deployment is adopted as the active blue color when the blue/green strategy
+// is enabled. The green color appends the "-green" suffix to them.
+type colorWorkload struct {
+	DeploymentWorkload
+	color saasv1alpha1.DeploymentColor
+	bg    *blueGreen
+}
+
+func (cw *colorWorkload) suffix(s string) string {
+	if cw.color == saasv1alpha1.BlueDeploymentColor {
+		return s
+	}
+	return s + "-" + string(cw.color)
+}
+
+func (cw *colorWorkload) GetKey() types.NamespacedName {
+	key := cw.DeploymentWorkload.GetKey()
+	return types.NamespacedName{Name: cw.suffix(key.Name), Namespace: key.Namespace}
+}
+
+func (cw *colorWorkload) GetSelector() map[string]string {
+	selector := map[string]string{}
+	for k, v := range cw.DeploymentWorkload.GetSelector() {
+		selector[k] = cw.suffix(v)
+	}
+	return selector
+}
+
+func (cw *colorWorkload) EnvoyDynamicConfigurations() []descriptor.EnvoyDynamicConfigDescriptor {
+	if w, ok := cw.DeploymentWorkload.(WithEnvoySidecar); ok {
+		return w.EnvoyDynamicConfigurations()
+	}
+	return nil
+}
+
+// Deployment returns the Deployment template of the color. The pods of both colors carry
+// the traffic selector of the workload, so the Services only need to switch the Deployment
+// selector to move the traffic from one color to the other.
+func (cw *colorWorkload) Deployment() *resource.Template[*appsv1.Deployment] {
+	return cw.DeploymentWorkload.Deployment().
+		Apply(trafficSelectorToDeployment(cw.DeploymentWorkload)).
+		Apply(cw.envoyNodeID()).
+		WithMutation(cw.bg.deploymentMutation(cw))
+}
+
+func (cw *colorWorkload) envoyNodeID() resource.TemplateBuilderFunction[*appsv1.Deployment] {
+	return func(o client.Object) (*appsv1.Deployment, error) {
+		dep := o.(*appsv1.Deployment)
+		if nodeID, ok := dep.Spec.Template.GetAnnotations()[marin3rNodeIDAnnotation]; ok {
+			dep.Spec.Template.Annotations[marin3rNodeIDAnnotation] = cw.suffix(nodeID)
+		}
+		return dep, nil
+	}
+}
+
+// blueGreenState is the state of a blue/green workload, computed
+// from the live Deployments of both colors
+type blueGreenState struct {
+	// the color that receives the traffic
+	active      saasv1alpha1.DeploymentColor
+	activeSince time.Time
+	// the hash of the desired pod template
+	hash string
+	// pending is true while the inactive color is being
+	// rolled out with the desired pod template
+	pending bool
+	// expired is true once the rollback window has passed
+	expired bool
+	live    map[saasv1alpha1.DeploymentColor]*appsv1.Deployment
+}
+
+// scaledDown returns true if the color is scaled down to zero replicas, which happens to the
+// inactive color once the rollback window has expired or if it has never been rolled out
+func (s *blueGreenState) scaledDown(c saasv1alpha1.DeploymentColor) bool {
+	return c != s.active && !s.pending && (s.expired || s.live[c] == nil)
+}
+
+func (s *blueGreenState) inactive() saasv1alpha1.DeploymentColor {
+	if s.active == saasv1alpha1.BlueDeploymentColor {
+		return saasv1alpha1.GreenDeploymentColor
+	}
+	return saasv1alpha1.BlueDeploymentColor
+}
+
+// upToDate returns true if the color runs the desired pod template. An adopted
+// Deployment is considered up to date, so it is updated in place.
+func (s *blueGreenState) upToDate(c saasv1alpha1.DeploymentColor) bool {
+	return s.live[c] != nil &&
+		(s.live[c].GetAnnotations()[blueGreenTemplateHashAnnotation] == s.hash || s.adopted(c))
+}
+
+// adopted returns true if the color is the Deployment that the workload ran before
+// the blue/green strategy was enabled. It becomes the active color instead of rolling
+// out the other color, which is created with zero replicas.
+func (s *blueGreenState) adopted(c saasv1alpha1.DeploymentColor) bool {
+	if c != saasv1alpha1.BlueDeploymentColor || s.live[c] == nil {
+		return false
+	}
+	_, hash := s.live[c].GetAnnotations()[blueGreenTemplateHashAnnotation]
+	_, since := s.live[c].GetAnnotations()[blueGreenActiveSinceAnnotation]
+	return !hash && !since
+}
+
+// state computes the state of the blue/green workload. The active color is the one that
+// started receiving traffic most recently. When the desired pod template changes, the
+// inactive color is rolled out with it and traffic is switched to the inactive color as
+// soon as all its replicas are ready.
+func (bg *blueGreen) state(ctx context.Context, cl client.Client, now time.Time) (*blueGreenState, error) {
+	hash, err := bg.templateHash(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	s := &blueGreenState{
+		active: saasv1alpha1.BlueDeploymentColor,
+		hash:   hash,
+		live:   map[saasv1alpha1.DeploymentColor]*appsv1.Deployment{},
+	}
+
+	for _, cw := range bg.colors() {
+		dep := &appsv1.Deployment{}
+		if err := cl.Get(ctx, cw.GetKey(), dep); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		s.live[cw.color] = dep
+		// Deployments created before the blue/green strategy was enabled do
+		// not have the annotation, which makes the blue color the active one
+		if since, err := time.Parse(time.RFC3339, dep.GetAnnotations()[blueGreenActiveSinceAnnotation]); err == nil &&
+			since.After(s.activeSince) {
+			s.active, s.activeSince = cw.color, since
+		}
+	}
+
+	switch inactive := s.inactive(); {
+	case s.live[s.active] == nil || s.upToDate(s.active):
+		// nothing to roll out
+	case s.upToDate(inactive) && desiredReplicas(s.live[inactive]) > 0 && rolloutComplete(s.live[inactive]):
+		// the inactive color is ready, switch the traffic to it
+		s.active, s.activeSince = inactive, now
+	default:
+		s.pending = true
+	}
+
+	if s.activeSince.IsZero() {
+		s.activeSince = now
+	}
+	s.expired = !s.pending && !now.Before(s.activeSince.Add(bg.strategy.GetRollbackWindow()))
+
+	return s, nil
+}
+
+// templateHash returns the hash of the desired pod template of the workload
+func (bg *blueGreen) templateHash(ctx context.Context, cl client.Client) (string, error) {
+	o, err := bg.workload.Deployment().Apply(meta[*appsv1.Deployment](bg.blue)).Build(ctx, cl, nil)
+	if err != nil {
+		return "", err
+	}
+	return util.Hash(o.(*appsv1.Deployment).Spec.Template), nil
+}
+
+// deploymentMutation returns a mutation that configures the Deployment of the given color
+// according to the state of the blue/green workload:
+//   - the active color and the color being rolled out get the desired pod template
+//   - the active color keeps its pod template while the inactive one is being rolled out
+//   - the inactive color keeps its pod template and replicas during the rollback window
+//     and is scaled down to zero replicas afterwards
+func (bg *blueGreen) deploymentMutation(cw *colorWorkload) resource.TemplateMutationFunction {
+	return func(ctx context.Context, cl client.Client, o client.Object) error {
+		dep := o.(*appsv1.Deployment)
+
+		s, err := bg.state(ctx, cl, time.Now())
+		if err != nil {
+			return err
+		}
+		live := s.live[cw.color]
+		annotations := map[string]string{}
+
+		active := cw.color == s.active
+		if active != s.pending {
+			annotations[blueGreenTemplateHashAnnotation] = s.hash
+			if !active && live != nil && dep.Spec.Replicas != nil && *dep.Spec.Replicas == 0 &&
+				!cw.HPASpec().IsDeactivated() {
+				// the autoscaler does not act on Deployments with zero replicas
+				dep.Spec.Replicas = cw.HPASpec().MinReplicas
+			}
+		} else if live != nil {
+			dep.Spec.Template = live.Spec.Template
+			if hash, ok := live.GetAnnotations()[blueGreenTemplateHashAnnotation]; ok {
+				annotations[blueGreenTemplateHashAnnotation] = hash
+			}
+		}
+
+		if active {
+			annotations[blueGreenActiveSinceAnnotation] = s.activeSince.Format(time.RFC3339)
+		} else {
+			if live != nil {
+				if since, ok := live.GetAnnotations()[blueGreenActiveSinceAnnotation]; ok {
+					annotations[blueGreenActiveSinceAnnotation] = since
+				}
+			}
+			if s.scaledDown(cw.color) {
+				dep.Spec.Replicas = util.Pointer[int32](0)
+			} else if !s.pending {
+				dep.Spec.Replicas = live.Spec.Replicas
+			}
+		}
+
+		dep.SetAnnotations(util.MergeMaps(map[string]string{}, dep.GetAnnotations(), annotations))
+		return nil
+	}
+}
+
+// colorHPA is the HorizontalPodAutoscaler template of a color. The HPA is removed while
+// the color is scaled down to zero replicas, as it would otherwise scale the color back
+// up. This depends on the live state of the blue/green workload, so it is resolved when
+// the template is built.
+type colorHPA struct {
+	resource.TemplateInterface
+	cw      *colorWorkload
+	enabled bool
+}
+
+func (t *colorHPA) Build(ctx context.Context, cl client.Client, o client.Object) (client.Object, error) {
+	s, err := t.cw.bg.state(ctx, cl, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	t.enabled = t.TemplateInterface.Enabled() && !s.scaledDown(t.cw.color)
+	return t.TemplateInterface.Build(ctx, cl, o)
+}
+
+func (t *colorHPA) Enabled() bool { return t.enabled }
+
+// serviceMutation returns a mutation that points the Service
+// selector to the pods of the active color
+func (bg *blueGreen) serviceMutation(w WithTraffic) resource.TemplateMutationFunction {
+	return func(ctx context.Context, cl client.Client, o client.Object) error {
+		svc := o.(*corev1.Service)
+
+		s, err := bg.state(ctx, cl, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if w.SendTraffic() {
+			svc.Spec.Selector = util.MergeMaps(map[string]string{}, bg.color(s.active).GetSelector(), w.TrafficSelector())
+		} else {
+			svc.Spec.Selector = map[string]string{}
+		}
+		return nil
+	}
+}
+
+// RollbackWindowEnd returns the time until the rollback window of the blue/green
+// workloads expires, so the reconcile can be requeued to scale down the inactive
+// colors. Returns zero if there are no rollback windows pending to expire.
+func RollbackWindowEnd(ctx context.Context, cl client.Client, workloads ...WithCanary) (time.Duration, error) {
+	var next time.Duration
+	now := time.Now()
+
+	for _, w := range workloads {
+		bg := newBlueGreen(w.Main)
+		if bg == nil {
+			continue
+		}
+		s, err := bg.state(ctx, cl, now)
+		if err != nil {
+			return 0, err
+		}
+		if s.pending || s.expired {
+			continue
+		}
+		if remaining := s.activeSince.Add(bg.strategy.GetRollbackWindow()).Sub(now); next == 0 || remaining < next {
+			next = remaining
+		}
+	}
+
+	return next, nil
+}
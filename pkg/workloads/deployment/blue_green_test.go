@@ -0,0 +1,454 @@
+package delpoyment_workload
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type testBlueGreenGenerator struct {
+	TestWorkloadGenerator
+	TStrategy *saasv1alpha1.DeploymentStrategySpec
+}
+
+var _ WithBlueGreen = &testBlueGreenGenerator{}
+
+func (gen *testBlueGreenGenerator) DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec {
+	return gen.TStrategy
+}
+
+func testBlueGreenWorkload() *testBlueGreenGenerator {
+	return &testBlueGreenGenerator{
+		TestWorkloadGenerator: TestWorkloadGenerator{
+			TName:            "main",
+			TNamespace:       "ns",
+			TTraffic:         true,
+			TLabels:          map[string]string{"key": "value"},
+			TSelector:        map[string]string{"deployment": "main"},
+			TTrafficSelector: map[string]string{"traffic": "main"},
+		},
+		TStrategy: &saasv1alpha1.DeploymentStrategySpec{
+			Type: saasv1alpha1.BlueGreenDeploymentStrategyType,
+			BlueGreen: &saasv1alpha1.BlueGreenStrategySpec{
+				RollbackWindow: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+}
+
+func testColorDeployment(name, hash string, since time.Time, replicas int32, status appsv1.DeploymentStatus) *appsv1.Deployment {
+	dep := testDeployment(name, replicas, status)
+	dep.SetAnnotations(map[string]string{})
+	if hash != "" {
+		dep.Annotations[blueGreenTemplateHashAnnotation] = hash
+	}
+	if !since.IsZero() {
+		dep.Annotations[blueGreenActiveSinceAnnotation] = since.Format(time.RFC3339)
+	}
+	return dep
+}
+
+func testBlueGreenClient(t *testing.T, objects ...client.Object) client.Client {
+	s := runtime.NewScheme()
+	if err := scheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	objects = append(objects, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "ns"}})
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build()
+}
+
+func Test_blueGreen_state(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	bg := newBlueGreen(testBlueGreenWorkload())
+	hash, err := bg.templateHash(context.TODO(), testBlueGreenClient(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		objects         []client.Object
+		wantActive      saasv1alpha1.DeploymentColor
+		wantActiveSince time.Time
+		wantPending     bool
+		wantExpired     bool
+	}{
+		{
+			name:            "Blue is active when there are no Deployments",
+			objects:         []client.Object{},
+			wantActive:      saasv1alpha1.BlueDeploymentColor,
+			wantActiveSince: now,
+		},
+		{
+			name: "A Deployment created before enabling blue/green is adopted as the active color",
+			objects: []client.Object{
+				testColorDeployment("main", "", time.Time{}, 2, completeStatus(2)),
+			},
+			wantActive:      saasv1alpha1.BlueDeploymentColor,
+			wantActiveSince: now,
+		},
+		{
+			name: "The green Deployment is not adopted",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", "", time.Time{}, 0, completeStatus(0)),
+			},
+			wantActive:      saasv1alpha1.BlueDeploymentColor,
+			wantActiveSince: now.Add(-2 * time.Hour),
+			wantPending:     true,
+		},
+		{
+			name: "Green is rolled out while blue keeps the traffic",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", hash, time.Time{}, 2, completeStatus(1)),
+			},
+			wantActive:      saasv1alpha1.BlueDeploymentColor,
+			wantActiveSince: now.Add(-2 * time.Hour),
+			wantPending:     true,
+		},
+		{
+			name: "Traffic is switched once green is ready",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", hash, time.Time{}, 2, completeStatus(2)),
+			},
+			wantActive:      saasv1alpha1.GreenDeploymentColor,
+			wantActiveSince: now,
+		},
+		{
+			name: "Green is active within the rollback window",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", hash, now.Add(-10*time.Minute), 2, completeStatus(2)),
+			},
+			wantActive:      saasv1alpha1.GreenDeploymentColor,
+			wantActiveSince: now.Add(-10 * time.Minute),
+		},
+		{
+			name: "Rollback window has expired",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-3*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", hash, now.Add(-2*time.Hour), 2, completeStatus(2)),
+			},
+			wantActive:      saasv1alpha1.GreenDeploymentColor,
+			wantActiveSince: now.Add(-2 * time.Hour),
+			wantExpired:     true,
+		},
+		{
+			name: "Reverting the spec switches back to the previous color",
+			objects: []client.Object{
+				testColorDeployment("main", hash, now.Add(-3*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", "new", now.Add(-10*time.Minute), 2, completeStatus(2)),
+			},
+			wantActive:      saasv1alpha1.BlueDeploymentColor,
+			wantActiveSince: now,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bg.state(context.TODO(), testBlueGreenClient(t, tt.objects...), now)
+			if err != nil {
+				t.Fatalf("blueGreen.state() error = %v", err)
+			}
+			if got.active != tt.wantActive {
+				t.Errorf("blueGreen.state() active = %v, want %v", got.active, tt.wantActive)
+			}
+			if !got.activeSince.Equal(tt.wantActiveSince) {
+				t.Errorf("blueGreen.state() activeSince = %v, want %v", got.activeSince, tt.wantActiveSince)
+			}
+			if got.pending != tt.wantPending {
+				t.Errorf("blueGreen.state() pending = %v, want %v", got.pending, tt.wantPending)
+			}
+			if got.expired != tt.wantExpired {
+				t.Errorf("blueGreen.state() expired = %v, want %v", got.expired, tt.wantExpired)
+			}
+		})
+	}
+}
+
+func Test_blueGreen_deploymentMutation(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	bg := newBlueGreen(testBlueGreenWorkload())
+	hash, err := bg.templateHash(context.TODO(), testBlueGreenClient(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type want struct {
+		image       string
+		replicas    int32
+		annotations map[string]string
+	}
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    map[saasv1alpha1.DeploymentColor]want
+	}{
+		{
+			name: "Green gets the new pod template while blue keeps the old one",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+			},
+			want: map[saasv1alpha1.DeploymentColor]want{
+				saasv1alpha1.BlueDeploymentColor: {
+					image:    "example.com:main",
+					replicas: 1,
+					annotations: map[string]string{
+						blueGreenTemplateHashAnnotation: "old",
+						blueGreenActiveSinceAnnotation:  now.Add(-2 * time.Hour).Format(time.RFC3339),
+					},
+				},
+				saasv1alpha1.GreenDeploymentColor: {
+					image:       "example.com:latest",
+					replicas:    1,
+					annotations: map[string]string{blueGreenTemplateHashAnnotation: hash},
+				},
+			},
+		},
+		{
+			name: "A Deployment created before enabling blue/green is updated in place",
+			objects: []client.Object{
+				testColorDeployment("main", "", time.Time{}, 2, completeStatus(2)),
+			},
+			want: map[saasv1alpha1.DeploymentColor]want{
+				saasv1alpha1.BlueDeploymentColor: {
+					image:    "example.com:latest",
+					replicas: 1,
+					annotations: map[string]string{
+						blueGreenTemplateHashAnnotation: hash,
+						blueGreenActiveSinceAnnotation:  now.Format(time.RFC3339),
+					},
+				},
+				saasv1alpha1.GreenDeploymentColor: {
+					image:       "example.com:latest",
+					replicas:    0,
+					annotations: map[string]string{},
+				},
+			},
+		},
+		{
+			name: "Blue is kept running within the rollback window",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", hash, now.Add(-10*time.Minute), 1, completeStatus(1)),
+			},
+			want: map[saasv1alpha1.DeploymentColor]want{
+				saasv1alpha1.BlueDeploymentColor: {
+					image:    "example.com:main",
+					replicas: 2,
+					annotations: map[string]string{
+						blueGreenTemplateHashAnnotation: "old",
+						blueGreenActiveSinceAnnotation:  now.Add(-2 * time.Hour).Format(time.RFC3339),
+					},
+				},
+				saasv1alpha1.GreenDeploymentColor: {
+					image:    "example.com:latest",
+					replicas: 1,
+					annotations: map[string]string{
+						blueGreenTemplateHashAnnotation: hash,
+						blueGreenActiveSinceAnnotation:  now.Add(-10 * time.Minute).Format(time.RFC3339),
+					},
+				},
+			},
+		},
+		{
+			name: "Blue is scaled down after the rollback window",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-3*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", hash, now.Add(-2*time.Hour), 1, completeStatus(1)),
+			},
+			want: map[saasv1alpha1.DeploymentColor]want{
+				saasv1alpha1.BlueDeploymentColor: {
+					image:    "example.com:main",
+					replicas: 0,
+					annotations: map[string]string{
+						blueGreenTemplateHashAnnotation: "old",
+						blueGreenActiveSinceAnnotation:  now.Add(-3 * time.Hour).Format(time.RFC3339),
+					},
+				},
+				saasv1alpha1.GreenDeploymentColor: {
+					image:    "example.com:latest",
+					replicas: 1,
+					annotations: map[string]string{
+						blueGreenTemplateHashAnnotation: hash,
+						blueGreenActiveSinceAnnotation:  now.Add(-2 * time.Hour).Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := testBlueGreenClient(t, tt.objects...)
+			for _, cw := range bg.colors() {
+				o, err := cw.Deployment().Apply(meta[*appsv1.Deployment](cw)).Build(context.TODO(), cl, nil)
+				if err != nil {
+					t.Fatalf("blueGreen.deploymentMutation() error = %v", err)
+				}
+				dep := o.(*appsv1.Deployment)
+				// the activation time of a newly active color is the time of the reconcile
+				if since, err := time.Parse(time.RFC3339, dep.GetAnnotations()[blueGreenActiveSinceAnnotation]); err == nil &&
+					since.Sub(now) > 0 && since.Sub(now) < 5*time.Second {
+					dep.Annotations[blueGreenActiveSinceAnnotation] = now.Format(time.RFC3339)
+				}
+				got := want{
+					image:       dep.Spec.Template.Spec.Containers[0].Image,
+					replicas:    *dep.Spec.Replicas,
+					annotations: dep.GetAnnotations(),
+				}
+				if diff := deep.Equal(got, tt.want[cw.color]); len(diff) > 0 {
+					t.Errorf("blueGreen.deploymentMutation() %s = diff %v", cw.color, diff)
+				}
+			}
+		})
+	}
+}
+
+func Test_colorHPA(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	w := testBlueGreenWorkload()
+	hash, err := newBlueGreen(w).templateHash(context.TODO(), testBlueGreenClient(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    map[string]bool
+	}{
+		{
+			name: "Green has no HPA before it is rolled out",
+			objects: []client.Object{
+				testColorDeployment("main", "", time.Time{}, 2, completeStatus(2)),
+			},
+			want: map[string]bool{"main": true, "main-green": false},
+		},
+		{
+			name: "Green has an HPA while it is being rolled out",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+			},
+			want: map[string]bool{"main": true, "main-green": true},
+		},
+		{
+			name: "Blue keeps its HPA within the rollback window",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", hash, now.Add(-10*time.Minute), 1, completeStatus(1)),
+			},
+			want: map[string]bool{"main": true, "main-green": true},
+		},
+		{
+			name: "Blue HPA is removed after the rollback window",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-3*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", hash, now.Add(-2*time.Hour), 1, completeStatus(1)),
+			},
+			want: map[string]bool{"main": false, "main-green": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := testBlueGreenClient(t, tt.objects...)
+			templates, err := New(w, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			got := map[string]bool{}
+			for _, tmpl := range templates {
+				o, err := tmpl.Build(context.TODO(), cl, nil)
+				if err != nil {
+					t.Fatalf("New() error = %v", err)
+				}
+				if _, ok := o.(*autoscalingv2.HorizontalPodAutoscaler); ok {
+					got[o.GetName()] = tmpl.Enabled()
+				}
+			}
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Errorf("colorHPA.Enabled() = diff %v", diff)
+			}
+		})
+	}
+}
+
+func Test_blueGreen_serviceMutation(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	w := testBlueGreenWorkload()
+	bg := newBlueGreen(w)
+	hash, err := bg.templateHash(context.TODO(), testBlueGreenClient(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    map[string]string
+	}{
+		{
+			name: "Sends traffic to blue",
+			objects: []client.Object{
+				testColorDeployment("main", hash, now.Add(-2*time.Hour), 2, completeStatus(2)),
+			},
+			want: map[string]string{"deployment": "main", "traffic": "main"},
+		},
+		{
+			name: "Sends traffic to green",
+			objects: []client.Object{
+				testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+				testColorDeployment("main-green", hash, now.Add(-10*time.Minute), 2, completeStatus(2)),
+			},
+			want: map[string]string{"deployment": "main-green", "traffic": "main"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{}
+			if err := bg.serviceMutation(w)(context.TODO(), testBlueGreenClient(t, tt.objects...), svc); err != nil {
+				t.Fatalf("blueGreen.serviceMutation() error = %v", err)
+			}
+			if diff := deep.Equal(svc.Spec.Selector, tt.want); len(diff) > 0 {
+				t.Errorf("blueGreen.serviceMutation() = diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestRollbackWindowEnd(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	w := testBlueGreenWorkload()
+	hash, err := newBlueGreen(w).templateHash(context.TODO(), testBlueGreenClient(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := testBlueGreenClient(t,
+		testColorDeployment("main", "old", now.Add(-2*time.Hour), 2, completeStatus(2)),
+		testColorDeployment("main-green", hash, now.Add(-20*time.Minute), 2, completeStatus(2)),
+	)
+	got, err := RollbackWindowEnd(context.TODO(), cl, WithCanary{Main: w})
+	if err != nil {
+		t.Fatalf("RollbackWindowEnd() error = %v", err)
+	}
+	if got <= 39*time.Minute || got > 40*time.Minute {
+		t.Errorf("RollbackWindowEnd() = %v, want ~40m", got)
+	}
+
+	got, err = RollbackWindowEnd(context.TODO(), cl, WithCanary{Main: &TestWorkloadGenerator{TName: "other", TNamespace: "ns"}})
+	if err != nil || got != 0 {
+		t.Errorf("RollbackWindowEnd() = %v, %v, want 0", got, err)
+	}
+}
@@ -2,15 +2,20 @@ package delpoyment_workload
 
 import (
 	"reflect"
+	"sort"
 
 	"github.com/3scale-ops/basereconciler/resource"
 	"github.com/3scale-ops/basereconciler/util"
 	marin3rv1alpha1 "github.com/3scale-ops/marin3r/apis/marin3r/v1alpha1"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/certificate"
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig"
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/factory"
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/hpa"
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/pdb"
 	"github.com/3scale-ops/saas-operator/pkg/resource_builders/podmonitor"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/ratelimit"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
@@ -23,22 +28,57 @@ import (
 
 func New(main DeploymentWorkload, canary DeploymentWorkload) ([]resource.TemplateInterface, error) {
 
-	resources := workloadResources(main)
+	weighted := newWeightedTraffic(main, canary)
+	bg := newBlueGreen(main)
+
+	var resources []resource.TemplateInterface
+	if bg != nil {
+		// each color has its own Deployment, HPA, PDB and PodMonitor
+		for _, cw := range bg.colors() {
+			resources = append(resources, workloadResources(cw, nil)...)
+		}
+	} else {
+		resources = workloadResources(main, weighted)
+	}
 
 	if unwrapNil(canary) != nil {
-		resources = append(resources, workloadResources(canary)...)
+		resources = append(resources, workloadResources(canary, weighted)...)
 	}
 
+	// the client certificates of the envoy clusters are shared by the
+	// main and the canary Deployments
+	resources = append(resources, clientCertificates(main, unwrapNil(canary))...)
+
 	// Generate services if the workload implements WithTraffic interface
 	if _, ok := main.(WithTraffic); ok {
 		for _, svct := range main.(WithTraffic).Services() {
-			resources = append(resources,
-				svct.Apply(meta[*corev1.Service](main)).
-					Apply(trafficSelectorToService(main.(WithTraffic), toWithTraffic(canary))),
-			)
+			if bg != nil {
+				// the Services only send traffic to the active color
+				resources = append(resources,
+					svct.Apply(meta[*corev1.Service](main)).
+						WithMutation(bg.serviceMutation(main.(WithTraffic))),
+				)
+			} else if weighted != nil {
+				// traffic is split by the Envoy sidecars of the main Deployment, so
+				// the Services only send traffic to the main Deployment
+				resources = append(resources,
+					svct.Apply(meta[*corev1.Service](main)).
+						Apply(trafficSelectorToService(main.(WithTraffic), nil)),
+				)
+			} else {
+				resources = append(resources,
+					svct.Apply(meta[*corev1.Service](main)).
+						Apply(trafficSelectorToService(main.(WithTraffic), toWithTraffic(canary))),
+				)
+			}
 		}
 	}
 
+	// Generate the per-Deployment Services used to split traffic with weights
+	if weighted != nil {
+		resources = append(resources, weighted.directServices()...)
+	}
+
 	return resources, nil
 }
 
@@ -48,14 +88,33 @@ var (
 	EmptySelector map[string]string    = map[string]string{}
 )
 
-func workloadResources(workload DeploymentWorkload) []resource.TemplateInterface {
+func workloadResources(workload DeploymentWorkload, weighted *weightedTraffic) []resource.TemplateInterface {
+
+	deployment := workload.Deployment().
+		Apply(meta[*appsv1.Deployment](workload)).
+		Apply(selector[*appsv1.Deployment](workload)).
+		Apply(trafficSelectorToDeployment(workload))
+	if weighted != nil && workload.GetKey() == weighted.canary.GetKey() {
+		deployment = deployment.WithMutation(weighted.scheduleMutation())
+	}
+
+	var suggested []autoscalingv2.MetricSpec
+	if w, ok := workload.(WithSuggestedMetrics); ok {
+		suggested = w.SuggestedMetrics()
+	}
+
+	var hpat resource.TemplateInterface = resource.NewTemplate[*autoscalingv2.HorizontalPodAutoscaler](
+		hpa.New(EmptyKey, EmptyLabel, *workload.HPASpec(), suggested...)).
+		WithEnabled(!workload.HPASpec().IsDeactivated()).
+		Apply(meta[*autoscalingv2.HorizontalPodAutoscaler](workload)).
+		Apply(scaleTargetRefToHPA(workload))
+	if cw, ok := workload.(*colorWorkload); ok {
+		hpat = &colorHPA{TemplateInterface: hpat, cw: cw}
+	}
 
 	resources := []resource.TemplateInterface{
 
-		workload.Deployment().
-			Apply(meta[*appsv1.Deployment](workload)).
-			Apply(selector[*appsv1.Deployment](workload)).
-			Apply(trafficSelectorToDeployment(workload)),
+		deployment,
 
 		resource.NewTemplate[*policyv1.PodDisruptionBudget](
 			pdb.New(EmptyKey, EmptyLabel, EmptySelector, *workload.PDBSpec())).
@@ -63,11 +122,7 @@ func workloadResources(workload DeploymentWorkload) []resource.TemplateInterface
 			Apply(meta[*policyv1.PodDisruptionBudget](workload)).
 			Apply(selector[*policyv1.PodDisruptionBudget](workload)),
 
-		resource.NewTemplate[*autoscalingv2.HorizontalPodAutoscaler](
-			hpa.New(EmptyKey, EmptyLabel, *workload.HPASpec())).
-			WithEnabled(!workload.HPASpec().IsDeactivated()).
-			Apply(meta[*autoscalingv2.HorizontalPodAutoscaler](workload)).
-			Apply(scaleTargetRefToHPA(workload)),
+		hpat,
 
 		resource.NewTemplate[*monitoringv1.PodMonitor](
 			podmonitor.New(EmptyKey, EmptyLabel, EmptySelector, workload.MonitoredEndpoints()...)).
@@ -78,18 +133,67 @@ func workloadResources(workload DeploymentWorkload) []resource.TemplateInterface
 
 	// if workload implements WithEnvoySidecar add the EnvoyConfig
 	if w, ok := workload.(WithEnvoySidecar); ok {
+		ect := resource.NewTemplate[*marin3rv1alpha1.EnvoyConfig](
+			envoyconfig.New(EmptyKey, EmptyKey.Name, factory.Default(), w.EnvoyDynamicConfigurations()...)).
+			WithEnabled(len(w.EnvoyDynamicConfigurations()) > 0).
+			Apply(meta[*marin3rv1alpha1.EnvoyConfig](w)).
+			Apply(nodeIdToEnvoyConfig(w))
+		// only the Envoy sidecars of the main Deployment split the traffic
+		if weighted != nil && workload.GetKey() == weighted.main.GetKey() {
+			ect = ect.WithMutation(weighted.envoyConfigMutation(w))
+		}
+		resources = append(resources, ect)
+
+		// the rate limit service configuration is generated from the same
+		// envoy dynamic configurations so descriptors and limits don't drift
 		resources = append(resources,
-			resource.NewTemplate[*marin3rv1alpha1.EnvoyConfig](
-				envoyconfig.New(EmptyKey, EmptyKey.Name, factory.Default(), w.EnvoyDynamicConfigurations()...)).
-				WithEnabled(len(w.EnvoyDynamicConfigurations()) > 0).
-				Apply(meta[*marin3rv1alpha1.EnvoyConfig](w)).
-				Apply(nodeIdToEnvoyConfig(w)),
+			resource.NewTemplate[*corev1.ConfigMap](
+				ratelimit.New(ratelimitConfigKey(w), w.GetLabels(), w.EnvoyDynamicConfigurations()...)).
+				WithEnabled(ratelimit.Enabled(w.EnvoyDynamicConfigurations()...)),
 		)
 	}
 
 	return resources
 }
 
+// clientCertificates returns the templates of the cert-manager Certificates that issue
+// the client certificates of the envoy clusters of the given workloads
+func clientCertificates(workloads ...DeploymentWorkload) []resource.TemplateInterface {
+	certs := map[string]saasv1alpha1.ClusterClientCertificate{}
+	for _, workload := range workloads {
+		w, ok := workload.(WithEnvoySidecar)
+		if !ok {
+			continue
+		}
+		for name, cert := range certificate.ClientCertificates(w.EnvoyDynamicConfigurations()...) {
+			if _, ok := certs[name]; !ok {
+				certs[name] = cert
+			}
+		}
+	}
+
+	names := make([]string, 0, len(certs))
+	for name := range certs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resources := make([]resource.TemplateInterface, 0, len(names))
+	for _, name := range names {
+		key := types.NamespacedName{Name: name, Namespace: workloads[0].GetKey().Namespace}
+		resources = append(resources,
+			resource.NewTemplate[*certmanagerv1.Certificate](
+				certificate.New(key, workloads[0].GetLabels(), certs[name])))
+	}
+	return resources
+}
+
+// ratelimitConfigKey returns the key of the ConfigMap that holds
+// the rate limit service configuration of the workload
+func ratelimitConfigKey(w WithWorkloadMeta) types.NamespacedName {
+	return types.NamespacedName{Name: w.GetKey().Name + "-ratelimit", Namespace: w.GetKey().Namespace}
+}
+
 func meta[T client.Object](w WithWorkloadMeta) resource.TemplateBuilderFunction[T] {
 	return func(o client.Object) (T, error) {
 
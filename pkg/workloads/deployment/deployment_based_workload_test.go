@@ -34,11 +34,14 @@ type TestWorkloadGenerator struct {
 	TLabels          map[string]string
 	TSelector        map[string]string
 	TTrafficSelector map[string]string
+	TEnvoyConfigs    []descriptor.EnvoyDynamicConfigDescriptor
+	TWeights         *saasv1alpha1.Canary
 }
 
 var _ DeploymentWorkload = &TestWorkloadGenerator{}
 var _ WithTraffic = &TestWorkloadGenerator{}
 var _ WithEnvoySidecar = &TestWorkloadGenerator{}
+var _ WithWeightedTraffic = &TestWorkloadGenerator{}
 
 func (gen *TestWorkloadGenerator) Deployment() *resource.Template[*appsv1.Deployment] {
 	return &resource.Template[*appsv1.Deployment]{
@@ -119,9 +122,11 @@ func (gen *TestWorkloadGenerator) TrafficSelector() map[string]string {
 }
 
 func (gen *TestWorkloadGenerator) EnvoyDynamicConfigurations() []descriptor.EnvoyDynamicConfigDescriptor {
-	return nil
+	return gen.TEnvoyConfigs
 }
 
+func (gen *TestWorkloadGenerator) TrafficWeights() *saasv1alpha1.Canary { return gen.TWeights }
+
 // // TESTS START HERE
 
 func TestWorkloadReconciler_NewDeploymentWorkload(t *testing.T) {
@@ -229,6 +234,13 @@ func TestWorkloadReconciler_NewDeploymentWorkload(t *testing.T) {
 							Runtimes:  []marin3rv1alpha1.EnvoyResource{},
 							Secrets:   []marin3rv1alpha1.EnvoySecretResource{},
 						}}},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-workload-ratelimit", Namespace: "ns",
+						Labels: map[string]string{"l-key": "l-value"},
+					},
+					Data: map[string]string{},
+				},
 				&corev1.Service{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "service", Namespace: "ns",
@@ -0,0 +1,26 @@
+package delpoyment_workload
+
+import (
+	"time"
+)
+
+// NextHPAScheduleChange returns the time until the next activation or expiration of
+// the scaling profiles of the given workloads, so the reconcile can be requeued to
+// update the HorizontalPodAutoscalers. Returns zero if there are no scaling profiles.
+func NextHPAScheduleChange(workloads ...WithCanary) time.Duration {
+	var next time.Duration
+	now := time.Now()
+
+	for _, w := range workloads {
+		for _, dw := range []DeploymentWorkload{w.Main, unwrapNil(w.Canary)} {
+			if dw == nil || dw.HPASpec().IsDeactivated() {
+				continue
+			}
+			if d := dw.HPASpec().NextScheduleChange(now); d > 0 && (next == 0 || d < next) {
+				next = d
+			}
+		}
+	}
+
+	return next
+}
@@ -6,6 +6,7 @@ import (
 	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -44,6 +45,12 @@ type WithHorizontalPodAutoscaler interface {
 	HPASpec() *saasv1alpha1.HorizontalPodAutoscalerSpec
 }
 
+type WithSuggestedMetrics interface {
+	// SuggestedMetrics returns the metrics added to the HorizontalPodAutoscaler
+	// of the workload when the suggested metrics are requested in the HPA spec
+	SuggestedMetrics() []autoscalingv2.MetricSpec
+}
+
 type WithTraffic interface {
 	WithWorkloadMeta
 	WithSelector
@@ -57,6 +64,20 @@ type WithEnvoySidecar interface {
 	EnvoyDynamicConfigurations() []descriptor.EnvoyDynamicConfigDescriptor
 }
 
+type WithWeightedTraffic interface {
+	WithTraffic
+	WithEnvoySidecar
+	// TrafficWeights returns the canary configuration that holds the
+	// traffic weights. It returns nil if the workload is not a canary.
+	TrafficWeights() *saasv1alpha1.Canary
+}
+
+type WithBlueGreen interface {
+	// DeploymentStrategySpec returns the deployment strategy of the workload. The
+	// blue/green deployment mode is used when the strategy type is BlueGreen.
+	DeploymentStrategySpec() *saasv1alpha1.DeploymentStrategySpec
+}
+
 type DeploymentWorkload interface {
 	WithWorkloadMeta
 	WithMonitoring
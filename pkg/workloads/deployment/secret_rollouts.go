@@ -0,0 +1,55 @@
+package delpoyment_workload
+
+import (
+	"sort"
+	"time"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TrackSecretRollouts sets the LastSecretRollout field of the Deployments in the current
+// status summary by comparing their Secret hashes with the ones recorded in the previous
+// summary. Secrets that were not tracked in the previous summary are not considered a
+// change, as the rollout is caused by a configuration change in that case.
+func TrackSecretRollouts(previous, current []saasv1alpha1.WorkloadStatus, now time.Time) {
+	recorded := map[string]*saasv1alpha1.DeploymentStatus{}
+	for i := range previous {
+		for _, ds := range deploymentStatuses(&previous[i]) {
+			recorded[ds.Name] = ds
+		}
+	}
+
+	for i := range current {
+		for _, ds := range deploymentStatuses(&current[i]) {
+			prev, ok := recorded[ds.Name]
+			if !ok {
+				continue
+			}
+			changed := []string{}
+			for secret, hash := range ds.SecretHashes {
+				if prevHash, ok := prev.SecretHashes[secret]; ok && prevHash != hash {
+					changed = append(changed, secret)
+				}
+			}
+			if len(changed) > 0 {
+				sort.Strings(changed)
+				ds.LastSecretRollout = &saasv1alpha1.SecretRolloutStatus{Secrets: changed, Time: metav1.NewTime(now)}
+			} else {
+				ds.LastSecretRollout = prev.LastSecretRollout
+			}
+		}
+	}
+}
+
+// deploymentStatuses returns pointers to all the DeploymentStatus within a WorkloadStatus
+func deploymentStatuses(ws *saasv1alpha1.WorkloadStatus) []*saasv1alpha1.DeploymentStatus {
+	list := []*saasv1alpha1.DeploymentStatus{&ws.Deployment}
+	if ws.Canary != nil {
+		list = append(list, ws.Canary)
+	}
+	if ws.BlueGreen != nil && ws.BlueGreen.Inactive != nil {
+		list = append(list, ws.BlueGreen.Inactive)
+	}
+	return list
+}
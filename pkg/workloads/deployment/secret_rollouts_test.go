@@ -0,0 +1,115 @@
+package delpoyment_workload
+
+import (
+	"testing"
+	"time"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTrackSecretRollouts(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := metav1.NewTime(now.Add(-time.Hour))
+	type args struct {
+		previous []saasv1alpha1.WorkloadStatus
+		current  []saasv1alpha1.WorkloadStatus
+	}
+	tests := []struct {
+		name string
+		args args
+		want []saasv1alpha1.WorkloadStatus
+	}{
+		{
+			name: "Records the Secrets that changed",
+			args: args{
+				previous: []saasv1alpha1.WorkloadStatus{{
+					Name:       "workload",
+					Deployment: saasv1alpha1.DeploymentStatus{Name: "workload", SecretHashes: map[string]string{"a": "1", "b": "1", "c": "1"}},
+					Canary:     &saasv1alpha1.DeploymentStatus{Name: "workload-canary", SecretHashes: map[string]string{"a": "1"}},
+				}},
+				current: []saasv1alpha1.WorkloadStatus{{
+					Name:       "workload",
+					Deployment: saasv1alpha1.DeploymentStatus{Name: "workload", SecretHashes: map[string]string{"a": "2", "b": "1", "c": "2"}},
+					Canary:     &saasv1alpha1.DeploymentStatus{Name: "workload-canary", SecretHashes: map[string]string{"a": "2"}},
+				}},
+			},
+			want: []saasv1alpha1.WorkloadStatus{{
+				Name: "workload",
+				Deployment: saasv1alpha1.DeploymentStatus{Name: "workload", SecretHashes: map[string]string{"a": "2", "b": "1", "c": "2"},
+					LastSecretRollout: &saasv1alpha1.SecretRolloutStatus{Secrets: []string{"a", "c"}, Time: metav1.NewTime(now)}},
+				Canary: &saasv1alpha1.DeploymentStatus{Name: "workload-canary", SecretHashes: map[string]string{"a": "2"},
+					LastSecretRollout: &saasv1alpha1.SecretRolloutStatus{Secrets: []string{"a"}, Time: metav1.NewTime(now)}},
+			}},
+		},
+		{
+			name: "Keeps the last recorded rollout",
+			args: args{
+				previous: []saasv1alpha1.WorkloadStatus{{
+					Name: "workload",
+					Deployment: saasv1alpha1.DeploymentStatus{Name: "workload", SecretHashes: map[string]string{"a": "1"},
+						LastSecretRollout: &saasv1alpha1.SecretRolloutStatus{Secrets: []string{"a"}, Time: before}},
+				}},
+				current: []saasv1alpha1.WorkloadStatus{{
+					Name:       "workload",
+					Deployment: saasv1alpha1.DeploymentStatus{Name: "workload", SecretHashes: map[string]string{"a": "1", "b": "1"}},
+				}},
+			},
+			want: []saasv1alpha1.WorkloadStatus{{
+				Name: "workload",
+				Deployment: saasv1alpha1.DeploymentStatus{Name: "workload", SecretHashes: map[string]string{"a": "1", "b": "1"},
+					LastSecretRollout: &saasv1alpha1.SecretRolloutStatus{Secrets: []string{"a"}, Time: before}},
+			}},
+		},
+		{
+			name: "Tracks the inactive color of blue/green workloads",
+			args: args{
+				previous: []saasv1alpha1.WorkloadStatus{{
+					Name:       "workload",
+					Deployment: saasv1alpha1.DeploymentStatus{Name: "workload-blue", SecretHashes: map[string]string{"a": "1"}},
+					BlueGreen: &saasv1alpha1.BlueGreenStatus{
+						Inactive: &saasv1alpha1.DeploymentStatus{Name: "workload-green", SecretHashes: map[string]string{"a": "1"}},
+					},
+				}},
+				current: []saasv1alpha1.WorkloadStatus{{
+					Name:       "workload",
+					Deployment: saasv1alpha1.DeploymentStatus{Name: "workload-green", SecretHashes: map[string]string{"a": "2"}},
+					BlueGreen: &saasv1alpha1.BlueGreenStatus{
+						Inactive: &saasv1alpha1.DeploymentStatus{Name: "workload-blue", SecretHashes: map[string]string{"a": "1"}},
+					},
+				}},
+			},
+			want: []saasv1alpha1.WorkloadStatus{{
+				Name: "workload",
+				Deployment: saasv1alpha1.DeploymentStatus{Name: "workload-green", SecretHashes: map[string]string{"a": "2"},
+					LastSecretRollout: &saasv1alpha1.SecretRolloutStatus{Secrets: []string{"a"}, Time: metav1.NewTime(now)}},
+				BlueGreen: &saasv1alpha1.BlueGreenStatus{
+					Inactive: &saasv1alpha1.DeploymentStatus{Name: "workload-blue", SecretHashes: map[string]string{"a": "1"}},
+				},
+			}},
+		},
+		{
+			name: "Nothing to compare on first status",
+			args: args{
+				previous: nil,
+				current: []saasv1alpha1.WorkloadStatus{{
+					Name:       "workload",
+					Deployment: saasv1alpha1.DeploymentStatus{Name: "workload", SecretHashes: map[string]string{"a": "1"}},
+				}},
+			},
+			want: []saasv1alpha1.WorkloadStatus{{
+				Name:       "workload",
+				Deployment: saasv1alpha1.DeploymentStatus{Name: "workload", SecretHashes: map[string]string{"a": "1"}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			TrackSecretRollouts(tt.args.previous, tt.args.current, now)
+			if diff := deep.Equal(tt.args.current, tt.want); len(diff) > 0 {
+				t.Errorf("TrackSecretRollouts() got diff %v", diff)
+			}
+		})
+	}
+}
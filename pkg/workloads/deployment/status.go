@@ -0,0 +1,237 @@
+package delpoyment_workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/pod"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WithCanary groups the main DeploymentWorkload of a workload
+// with its canary. The canary can be nil.
+type WithCanary struct {
+	Main   DeploymentWorkload
+	Canary DeploymentWorkload
+	// Spec is the canary configuration of the workload
+	Spec *saasv1alpha1.Canary
+	// SpecPath is the JSON pointer to the canary configuration
+	// within the custom resource (eg "/spec/listener/canary")
+	SpecPath string
+	// SpecTests are checked before removing the canary configuration from the custom
+	// resource, so it is not removed if SpecPath points to a different workload, for
+	// example when the items of a list have been reordered
+	SpecTests []SpecTest
+}
+
+// SpecTest is a value expected at a JSON pointer within the custom resource
+type SpecTest struct {
+	Path  string
+	Value string
+}
+
+// rollout describes the state of the rollout of a set of Deployments
+type rollout struct {
+	progressing []string
+	failures    []string
+}
+
+// Status returns a summary of the status of the given workloads, computed from the
+// Deployments and HorizontalPodAutoscalers generated by New(). It also returns the Ready,
+// Progressing and Degraded conditions of the workloads. The reconcileError parameter is
+// the error (if any) returned when reconciling the workload resources, which is reported
+// in the Degraded condition.
+func Status(ctx context.Context, cl client.Client, reconcileError error,
+	workloads ...WithCanary) ([]saasv1alpha1.WorkloadStatus, []metav1.Condition, error) {
+
+	statuses := make([]saasv1alpha1.WorkloadStatus, 0, len(workloads))
+	r := &rollout{}
+
+	for _, w := range workloads {
+		var status saasv1alpha1.WorkloadStatus
+
+		if bg := newBlueGreen(w.Main); bg != nil {
+			s, err := bg.state(ctx, cl, time.Now())
+			if err != nil {
+				return nil, nil, err
+			}
+			active, err := deploymentStatus(ctx, cl, bg.color(s.active), r)
+			if err != nil {
+				return nil, nil, err
+			}
+			inactive, err := deploymentStatus(ctx, cl, bg.color(s.inactive()), r)
+			if err != nil {
+				return nil, nil, err
+			}
+			status = saasv1alpha1.WorkloadStatus{
+				Name:       w.Main.GetKey().Name,
+				Deployment: *active,
+				BlueGreen: &saasv1alpha1.BlueGreenStatus{
+					ActiveColor: s.active,
+					ActiveSince: &metav1.Time{Time: s.activeSince},
+					Inactive:    inactive,
+				},
+			}
+		} else {
+			main, err := deploymentStatus(ctx, cl, w.Main, r)
+			if err != nil {
+				return nil, nil, err
+			}
+			status = saasv1alpha1.WorkloadStatus{Name: w.Main.GetKey().Name, Deployment: *main}
+		}
+
+		if canary := unwrapNil(w.Canary); canary != nil {
+			var err error
+			status.Canary, err = deploymentStatus(ctx, cl, canary, r)
+			if err != nil {
+				return nil, nil, err
+			}
+			if wt := newWeightedTraffic(w.Main, canary); wt != nil {
+				weight, _, err := CanaryWeight(ctx, cl, wt.canary)
+				if err != nil {
+					return nil, nil, err
+				}
+				status.CanaryWeight = &weight
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, r.conditions(reconcileError), nil
+}
+
+func deploymentStatus(ctx context.Context, cl client.Client, w DeploymentWorkload,
+	r *rollout) (*saasv1alpha1.DeploymentStatus, error) {
+
+	status := &saasv1alpha1.DeploymentStatus{Name: w.GetKey().Name}
+
+	dep := &appsv1.Deployment{}
+	if err := cl.Get(ctx, w.GetKey(), dep); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.progressing = append(r.progressing, w.GetKey().Name)
+			return status, nil
+		}
+		return nil, err
+	}
+
+	status.DesiredReplicas = desiredReplicas(dep)
+	status.ReadyReplicas = dep.Status.ReadyReplicas
+	status.UpdatedReplicas = dep.Status.UpdatedReplicas
+	if len(dep.Spec.Template.Spec.Containers) > 0 {
+		status.Image = dep.Spec.Template.Spec.Containers[0].Image
+	}
+	status.SecretHashes = pod.SecretHashes(saasv1alpha1.AnnotationsDomain, dep.Spec.Template)
+
+	if !w.HPASpec().IsDeactivated() {
+		if sched := w.HPASpec().ActiveSchedule(time.Now()); sched != nil {
+			status.HPAActiveSchedule = sched.Name
+		}
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := cl.Get(ctx, w.GetKey(), hpa); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+		} else {
+			status.HPACurrentReplicas = &hpa.Status.CurrentReplicas
+		}
+	}
+
+	if msg := rolloutFailure(dep); msg != "" {
+		r.failures = append(r.failures, fmt.Sprintf("%s: %s", dep.GetName(), msg))
+	} else if !rolloutComplete(dep) {
+		r.progressing = append(r.progressing, dep.GetName())
+	}
+
+	return status, nil
+}
+
+func desiredReplicas(dep *appsv1.Deployment) int32 {
+	if dep.Spec.Replicas == nil {
+		return 1
+	}
+	return *dep.Spec.Replicas
+}
+
+// rolloutComplete returns true if the latest version of the Deployment has been
+// rolled out and all its replicas are ready. This follows the same logic as
+// 'kubectl rollout status'.
+func rolloutComplete(dep *appsv1.Deployment) bool {
+	desired := desiredReplicas(dep)
+	return dep.Status.ObservedGeneration >= dep.GetGeneration() &&
+		dep.Status.UpdatedReplicas == desired &&
+		dep.Status.Replicas == desired &&
+		dep.Status.AvailableReplicas == desired &&
+		dep.Status.ReadyReplicas == desired
+}
+
+// rolloutFailure returns the reason of a failed rollout
+// or an empty string if the rollout has not failed
+func rolloutFailure(dep *appsv1.Deployment) string {
+	for _, c := range dep.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse &&
+			c.Reason == "ProgressDeadlineExceeded" {
+			return c.Message
+		}
+		if c.Type == appsv1.DeploymentReplicaFailure && c.Status == corev1.ConditionTrue {
+			return c.Message
+		}
+	}
+	return ""
+}
+
+func (r *rollout) conditions(reconcileError error) []metav1.Condition {
+
+	degraded := metav1.Condition{
+		Type:    saasv1alpha1.WorkloadDegradedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "AsExpected",
+		Message: "",
+	}
+	if reconcileError != nil {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = "ReconcileError"
+		degraded.Message = reconcileError.Error()
+	} else if len(r.failures) > 0 {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = "RolloutFailed"
+		degraded.Message = strings.Join(r.failures, "; ")
+	}
+
+	progressing := metav1.Condition{
+		Type:    saasv1alpha1.WorkloadProgressingCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "RolloutComplete",
+		Message: "",
+	}
+	if len(r.progressing) > 0 {
+		progressing.Status = metav1.ConditionTrue
+		progressing.Reason = "RolloutInProgress"
+		progressing.Message = fmt.Sprintf("Deployments being rolled out: %s", strings.Join(r.progressing, ", "))
+	}
+
+	ready := metav1.Condition{
+		Type:    saasv1alpha1.WorkloadReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AllReplicasReady",
+		Message: "",
+	}
+	if degraded.Status == metav1.ConditionTrue {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "Degraded"
+		ready.Message = degraded.Message
+	} else if progressing.Status == metav1.ConditionTrue {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "RolloutInProgress"
+		ready.Message = progressing.Message
+	}
+
+	return []metav1.Condition{ready, progressing, degraded}
+}
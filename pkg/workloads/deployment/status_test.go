@@ -0,0 +1,271 @@
+package delpoyment_workload
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testDeployment(name string, replicas int32, status appsv1.DeploymentStatus) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Generation: 1},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: util.Pointer(replicas),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "container", Image: "example.com:" + name}},
+				},
+			},
+		},
+		Status: status,
+	}
+}
+
+func testHPA(name string, current int32) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Status:     autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: current},
+	}
+}
+
+func completeStatus(replicas int32) appsv1.DeploymentStatus {
+	return appsv1.DeploymentStatus{
+		ObservedGeneration: 1,
+		Replicas:           replicas,
+		UpdatedReplicas:    replicas,
+		ReadyReplicas:      replicas,
+		AvailableReplicas:  replicas,
+	}
+}
+
+func conditionsStatus(conditions []metav1.Condition) map[string]metav1.ConditionStatus {
+	m := map[string]metav1.ConditionStatus{}
+	for _, c := range conditions {
+		m[c.Type] = c.Status
+	}
+	return m
+}
+
+func TestStatus(t *testing.T) {
+	activeSince := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	type args struct {
+		reconcileError error
+		workloads      []WithCanary
+	}
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		args           args
+		want           []saasv1alpha1.WorkloadStatus
+		wantConditions map[string]metav1.ConditionStatus
+		wantErr        bool
+	}{
+		{
+			name: "Workload and canary are ready",
+			objects: []client.Object{
+				testDeployment("main", 2, completeStatus(2)),
+				testHPA("main", 2),
+				testDeployment("canary", 1, completeStatus(1)),
+				testHPA("canary", 1),
+			},
+			args: args{
+				workloads: []WithCanary{{
+					Main:   &TestWorkloadGenerator{TName: "main", TNamespace: "ns"},
+					Canary: &TestWorkloadGenerator{TName: "canary", TNamespace: "ns"},
+				}},
+			},
+			want: []saasv1alpha1.WorkloadStatus{{
+				Name: "main",
+				Deployment: saasv1alpha1.DeploymentStatus{
+					Name:               "main",
+					DesiredReplicas:    2,
+					ReadyReplicas:      2,
+					UpdatedReplicas:    2,
+					HPACurrentReplicas: util.Pointer[int32](2),
+					Image:              "example.com:main",
+				},
+				Canary: &saasv1alpha1.DeploymentStatus{
+					Name:               "canary",
+					DesiredReplicas:    1,
+					ReadyReplicas:      1,
+					UpdatedReplicas:    1,
+					HPACurrentReplicas: util.Pointer[int32](1),
+					Image:              "example.com:canary",
+				},
+			}},
+			wantConditions: map[string]metav1.ConditionStatus{
+				saasv1alpha1.WorkloadReadyCondition:       metav1.ConditionTrue,
+				saasv1alpha1.WorkloadProgressingCondition: metav1.ConditionFalse,
+				saasv1alpha1.WorkloadDegradedCondition:    metav1.ConditionFalse,
+			},
+		},
+		{
+			name: "Workload is being rolled out",
+			objects: []client.Object{
+				testDeployment("main", 2, appsv1.DeploymentStatus{
+					ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 1, ReadyReplicas: 2, AvailableReplicas: 2,
+				}),
+				testHPA("main", 2),
+				testDeployment("other", 1, completeStatus(1)),
+				testHPA("other", 1),
+			},
+			args: args{
+				workloads: []WithCanary{
+					{Main: &TestWorkloadGenerator{TName: "main", TNamespace: "ns"}},
+					{Main: &TestWorkloadGenerator{TName: "other", TNamespace: "ns"}},
+				},
+			},
+			want: []saasv1alpha1.WorkloadStatus{
+				{
+					Name: "main",
+					Deployment: saasv1alpha1.DeploymentStatus{
+						Name:               "main",
+						DesiredReplicas:    2,
+						ReadyReplicas:      2,
+						UpdatedReplicas:    1,
+						HPACurrentReplicas: util.Pointer[int32](2),
+						Image:              "example.com:main",
+					},
+				},
+				{
+					Name: "other",
+					Deployment: saasv1alpha1.DeploymentStatus{
+						Name:               "other",
+						DesiredReplicas:    1,
+						ReadyReplicas:      1,
+						UpdatedReplicas:    1,
+						HPACurrentReplicas: util.Pointer[int32](1),
+						Image:              "example.com:other",
+					},
+				},
+			},
+			wantConditions: map[string]metav1.ConditionStatus{
+				saasv1alpha1.WorkloadReadyCondition:       metav1.ConditionFalse,
+				saasv1alpha1.WorkloadProgressingCondition: metav1.ConditionTrue,
+				saasv1alpha1.WorkloadDegradedCondition:    metav1.ConditionFalse,
+			},
+		},
+		{
+			name: "Workload rollout failed",
+			objects: []client.Object{
+				testDeployment("main", 1, appsv1.DeploymentStatus{
+					ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 0,
+					Conditions: []appsv1.DeploymentCondition{{
+						Type:    appsv1.DeploymentProgressing,
+						Status:  corev1.ConditionFalse,
+						Reason:  "ProgressDeadlineExceeded",
+						Message: "deadline exceeded",
+					}},
+				}),
+			},
+			args: args{
+				workloads: []WithCanary{{Main: &TestWorkloadGenerator{TName: "main", TNamespace: "ns"}}},
+			},
+			want: []saasv1alpha1.WorkloadStatus{{
+				Name: "main",
+				Deployment: saasv1alpha1.DeploymentStatus{
+					Name:            "main",
+					DesiredReplicas: 1,
+					Image:           "example.com:main",
+				},
+			}},
+			wantConditions: map[string]metav1.ConditionStatus{
+				saasv1alpha1.WorkloadReadyCondition:       metav1.ConditionFalse,
+				saasv1alpha1.WorkloadProgressingCondition: metav1.ConditionFalse,
+				saasv1alpha1.WorkloadDegradedCondition:    metav1.ConditionTrue,
+			},
+		},
+		{
+			name:    "Reconcile error and Deployment not yet created",
+			objects: []client.Object{},
+			args: args{
+				reconcileError: errors.New("error"),
+				workloads:      []WithCanary{{Main: &TestWorkloadGenerator{TName: "main", TNamespace: "ns"}}},
+			},
+			want: []saasv1alpha1.WorkloadStatus{{
+				Name:       "main",
+				Deployment: saasv1alpha1.DeploymentStatus{Name: "main"},
+			}},
+			wantConditions: map[string]metav1.ConditionStatus{
+				saasv1alpha1.WorkloadReadyCondition:       metav1.ConditionFalse,
+				saasv1alpha1.WorkloadProgressingCondition: metav1.ConditionTrue,
+				saasv1alpha1.WorkloadDegradedCondition:    metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "Blue/green workload reports the active color",
+			objects: []client.Object{
+				testColorDeployment("main", "old", activeSince.Add(-time.Hour), 2, completeStatus(2)),
+				testHPA("main", 2),
+				testColorDeployment("main-green", "new", activeSince, 2, completeStatus(2)),
+				testHPA("main-green", 2),
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "ns"}},
+			},
+			args: args{
+				workloads: []WithCanary{{Main: testBlueGreenWorkload()}},
+			},
+			want: []saasv1alpha1.WorkloadStatus{{
+				Name: "main",
+				Deployment: saasv1alpha1.DeploymentStatus{
+					Name:               "main-green",
+					DesiredReplicas:    2,
+					ReadyReplicas:      2,
+					UpdatedReplicas:    2,
+					HPACurrentReplicas: util.Pointer[int32](2),
+					Image:              "example.com:main-green",
+				},
+				BlueGreen: &saasv1alpha1.BlueGreenStatus{
+					ActiveColor: saasv1alpha1.GreenDeploymentColor,
+					ActiveSince: &metav1.Time{Time: activeSince},
+					Inactive: &saasv1alpha1.DeploymentStatus{
+						Name:               "main",
+						DesiredReplicas:    2,
+						ReadyReplicas:      2,
+						UpdatedReplicas:    2,
+						HPACurrentReplicas: util.Pointer[int32](2),
+						Image:              "example.com:main",
+					},
+				},
+			}},
+			wantConditions: map[string]metav1.ConditionStatus{
+				saasv1alpha1.WorkloadReadyCondition:       metav1.ConditionTrue,
+				saasv1alpha1.WorkloadProgressingCondition: metav1.ConditionFalse,
+				saasv1alpha1.WorkloadDegradedCondition:    metav1.ConditionFalse,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := scheme.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			cl := fake.NewClientBuilder().WithScheme(s).WithObjects(tt.objects...).Build()
+
+			got, conditions, err := Status(context.TODO(), cl, tt.args.reconcileError, tt.args.workloads...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Status() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Errorf("Status() = diff %v", diff)
+			}
+			if diff := deep.Equal(conditionsStatus(conditions), tt.wantConditions); len(diff) > 0 {
+				t.Errorf("Status() conditions = diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package delpoyment_workload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/resource"
+	"github.com/3scale-ops/basereconciler/util"
+	marin3rv1alpha1 "github.com/3scale-ops/marin3r/apis/marin3r/v1alpha1"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig"
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+	"github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/factory"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// weightedTraffic holds the main and canary workloads when traffic
+// is split between them using weights in the Envoy sidecars
+type weightedTraffic struct {
+	main   WithWeightedTraffic
+	canary WithWeightedTraffic
+}
+
+// newWeightedTraffic returns a weightedTraffic if the canary is configured to
+// receive a percentage of the traffic, or nil otherwise. Weights are not supported
+// for workloads that use the blue/green deployment strategy.
+func newWeightedTraffic(main, canary DeploymentWorkload) *weightedTraffic {
+	c := toWithWeightedTraffic(canary)
+	if c == nil || !c.TrafficWeights().IsWeighted() || newBlueGreen(main) != nil {
+		return nil
+	}
+	m, ok := main.(WithWeightedTraffic)
+	if !ok {
+		return nil
+	}
+	return &weightedTraffic{main: m, canary: c}
+}
+
+// directServiceName returns the name of the Service that
+// sends traffic directly to the pods of a Deployment
+func directServiceName(w WithWorkloadMeta) string {
+	return w.GetKey().Name + "-direct"
+}
+
+// directServices returns one Service for each of the Deployments, selecting
+// only the pods of that Deployment. The ports of the Services are the ports of
+// the Envoy clusters marked as 'canaryWeighted'.
+func (wt *weightedTraffic) directServices() []resource.TemplateInterface {
+	ports := []corev1.ServicePort{}
+	for _, cluster := range canaryWeightedClusters(wt.main.EnvoyDynamicConfigurations()) {
+		ports = append(ports, corev1.ServicePort{
+			Name:       fmt.Sprintf("port-%d", cluster.Port),
+			Port:       int32(cluster.Port),
+			TargetPort: intstr.FromInt(int(cluster.Port)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
+	templates := make([]resource.TemplateInterface, 0, 2)
+	for _, w := range []WithWeightedTraffic{wt.main, wt.canary} {
+		w := w
+		templates = append(templates,
+			resource.NewTemplate(func(client.Object) (*corev1.Service, error) {
+				return &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      directServiceName(w),
+						Namespace: w.GetKey().Namespace,
+						Labels:    util.MergeMaps(map[string]string{}, w.GetLabels()),
+					},
+					Spec: corev1.ServiceSpec{
+						Type:     corev1.ServiceTypeClusterIP,
+						Ports:    ports,
+						Selector: w.GetSelector(),
+					},
+				}, nil
+			}).WithEnabled(len(ports) > 0),
+		)
+	}
+
+	return templates
+}
+
+// envoyConfigMutation returns a mutation that sets the weighted upstreams of the Envoy
+// clusters marked as 'canaryWeighted' in the EnvoyConfig of the given workload. The
+// weights are computed from the canary step schedule, which requires access to the
+// canary Deployment.
+func (wt *weightedTraffic) envoyConfigMutation(w WithEnvoySidecar) resource.TemplateMutationFunction {
+	return func(ctx context.Context, cl client.Client, o client.Object) error {
+		ec := o.(*marin3rv1alpha1.EnvoyConfig)
+
+		weight, _, err := CanaryWeight(ctx, cl, wt.canary)
+		if err != nil {
+			return err
+		}
+
+		resources := wt.weightedClusters(w.EnvoyDynamicConfigurations(), weight)
+		desired, err := envoyconfig.New(EmptyKey, ec.Spec.NodeID, factory.Default(), resources...)(nil)
+		if err != nil {
+			return err
+		}
+		ec.Spec.EnvoyResources = desired.Spec.EnvoyResources
+		ec.Spec.Resources = desired.Spec.Resources
+
+		return nil
+	}
+}
+
+// weightedClusters returns the list of descriptors with the weighted upstreams set
+// in those clusters marked as 'canaryWeighted'
+func (wt *weightedTraffic) weightedClusters(list []descriptor.EnvoyDynamicConfigDescriptor,
+	weight int32) []descriptor.EnvoyDynamicConfigDescriptor {
+
+	out := make([]descriptor.EnvoyDynamicConfigDescriptor, 0, len(list))
+	for _, desc := range list {
+		cluster, ok := desc.GetOptions().(*saasv1alpha1.Cluster)
+		if !ok || cluster.CanaryWeighted == nil || !*cluster.CanaryWeighted {
+			out = append(out, desc)
+			continue
+		}
+
+		weighted := cluster.DeepCopy()
+		weighted.WeightedUpstreams = []saasv1alpha1.WeightedUpstream{
+			{Host: directServiceName(wt.main), Port: cluster.Port, Weight: uint32(100 - weight)},
+			{Host: directServiceName(wt.canary), Port: cluster.Port, Weight: uint32(weight)},
+		}
+		out = append(out, &clusterDescriptor{EnvoyDynamicConfigDescriptor: desc, cluster: weighted})
+	}
+
+	return out
+}
+
+// clusterDescriptor overrides the options of an EnvoyDynamicConfigDescriptor
+type clusterDescriptor struct {
+	descriptor.EnvoyDynamicConfigDescriptor
+	cluster *saasv1alpha1.Cluster
+}
+
+func (d *clusterDescriptor) GetOptions() interface{} { return d.cluster }
+
+func canaryWeightedClusters(list []descriptor.EnvoyDynamicConfigDescriptor) []*saasv1alpha1.Cluster {
+	clusters := []*saasv1alpha1.Cluster{}
+	for _, desc := range list {
+		if cluster, ok := desc.GetOptions().(*saasv1alpha1.Cluster); ok &&
+			cluster.CanaryWeighted != nil && *cluster.CanaryWeighted {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters
+}
+
+const (
+	// canarySpecHashAnnotation holds the hash of the steps and canary pod spec the schedule of steps was started for
+	canarySpecHashAnnotation string = saasv1alpha1.AnnotationsDomain + "/canary-spec-hash"
+	// canaryScheduleStartAnnotation holds the time at which the schedule of steps was started
+	canaryScheduleStartAnnotation string = saasv1alpha1.AnnotationsDomain + "/canary-schedule-start"
+)
+
+// CanaryWeight returns the percentage of traffic that is currently sent to the given
+// canary, and the time remaining until the next step of its schedule. Steps are measured
+// from the last change of the steps or the canary pod spec, so a new canary image or a new
+// schedule start again from the first step. Canary Deployments created before the schedule start was
+// tracked measure the steps from their creation.
+func CanaryWeight(ctx context.Context, cl client.Client, canary WithWeightedTraffic) (int32, time.Duration, error) {
+	var elapsed time.Duration
+
+	dep := &appsv1.Deployment{}
+	if err := cl.Get(ctx, canary.GetKey(), dep); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, 0, err
+		}
+		// the canary Deployment is not yet created, so
+		// the schedule has not started
+	} else if start, ok := scheduleStart(dep); ok {
+		if dep.GetAnnotations()[canarySpecHashAnnotation] == scheduleHash(canary.TrafficWeights()) {
+			elapsed = time.Since(start)
+		}
+		// otherwise the canary spec has changed and the
+		// schedule starts over in the next reconcile
+	} else if created := dep.GetCreationTimestamp(); !created.IsZero() {
+		elapsed = time.Since(created.Time)
+	}
+
+	weight, remaining := canary.TrafficWeights().TrafficWeight(elapsed)
+	return weight, remaining, nil
+}
+
+// scheduleMutation returns a mutation that records in the canary Deployment the time at
+// which the schedule of steps started. The schedule is restarted whenever the steps or the
+// canary pod spec change. Canary Deployments created before the start was tracked keep their creation
+// time as the start of the schedule.
+func (wt *weightedTraffic) scheduleMutation() resource.TemplateMutationFunction {
+	return func(ctx context.Context, cl client.Client, o client.Object) error {
+		dep := o.(*appsv1.Deployment)
+		hash := scheduleHash(wt.canary.TrafficWeights())
+		start := time.Now()
+
+		live := &appsv1.Deployment{}
+		if err := cl.Get(ctx, wt.canary.GetKey(), live); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		} else if liveHash, ok := live.GetAnnotations()[canarySpecHashAnnotation]; !ok {
+			start = live.GetCreationTimestamp().Time
+		} else if since, ok := scheduleStart(live); ok && liveHash == hash {
+			start = since
+		}
+
+		dep.SetAnnotations(util.MergeMaps(map[string]string{}, dep.GetAnnotations(), map[string]string{
+			canarySpecHashAnnotation:      hash,
+			canaryScheduleStartAnnotation: start.Format(time.RFC3339),
+		}))
+		return nil
+	}
+}
+
+// scheduleHash returns a hash of the fields of the canary spec that define the steps
+// and the canary pod spec. Other fields, like the number of replicas, can be changed
+// without restarting the schedule.
+func scheduleHash(c *saasv1alpha1.Canary) string {
+	return util.Hash(struct {
+		ImageName *string
+		ImageTag  *string
+		Patches   []string
+		Steps     []saasv1alpha1.CanaryStep
+	}{c.ImageName, c.ImageTag, c.Patches, c.Steps})
+}
+
+func scheduleStart(dep *appsv1.Deployment) (time.Time, bool) {
+	start, err := time.Parse(time.RFC3339, dep.GetAnnotations()[canaryScheduleStartAnnotation])
+	return start, err == nil
+}
+
+// NextCanaryStep returns the time until the next step of the canary schedules of
+// the given workloads, so the reconcile can be requeued. Returns zero if there
+// are no pending steps.
+func NextCanaryStep(ctx context.Context, cl client.Client, workloads ...WithCanary) (time.Duration, error) {
+	var next time.Duration
+
+	for _, w := range workloads {
+		wt := newWeightedTraffic(w.Main, w.Canary)
+		if wt == nil {
+			continue
+		}
+		_, remaining, err := CanaryWeight(ctx, cl, wt.canary)
+		if err != nil {
+			return 0, err
+		}
+		if remaining > 0 && (next == 0 || remaining < next) {
+			next = remaining
+		}
+	}
+
+	return next, nil
+}
+
+func toWithWeightedTraffic(w DeploymentWorkload) WithWeightedTraffic {
+	if unwrapNil(w) == nil {
+		return nil
+	}
+	if wwt, ok := w.(WithWeightedTraffic); ok {
+		return wwt
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package delpoyment_workload
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/3scale-ops/basereconciler/util"
+	marin3rv1alpha1 "github.com/3scale-ops/marin3r/apis/marin3r/v1alpha1"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	descriptor "github.com/3scale-ops/saas-operator/pkg/resource_builders/envoyconfig/descriptor"
+	"github.com/go-test/deep"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testWeights() *saasv1alpha1.Canary {
+	return &saasv1alpha1.Canary{
+		Weight: util.Pointer[int32](50),
+		Steps: []saasv1alpha1.CanaryStep{
+			{Weight: 5, Duration: metav1.Duration{Duration: 10 * time.Minute}},
+			{Weight: 25, Duration: metav1.Duration{Duration: 10 * time.Minute}},
+		},
+	}
+}
+
+func testEnvoyConfigs() []descriptor.EnvoyDynamicConfigDescriptor {
+	return []descriptor.EnvoyDynamicConfigDescriptor{
+		(&saasv1alpha1.EnvoyDynamicConfig{
+			GeneratorVersion: util.Pointer("v1"),
+			Cluster: &saasv1alpha1.Cluster{
+				Host: "127.0.0.1", Port: 8080, IsHttp2: util.Pointer(false), CanaryWeighted: util.Pointer(true),
+			},
+		}).AsEnvoyDynamicConfigDescriptor("app"),
+		(&saasv1alpha1.EnvoyDynamicConfig{
+			GeneratorVersion: util.Pointer("v1"),
+			Cluster: &saasv1alpha1.Cluster{
+				Host: "127.0.0.1", Port: 9090, IsHttp2: util.Pointer(false),
+			},
+		}).AsEnvoyDynamicConfigDescriptor("other"),
+	}
+}
+
+func TestCanaryWeight(t *testing.T) {
+	tests := []struct {
+		name          string
+		objects       []client.Object
+		canary        WithWeightedTraffic
+		wantWeight    int32
+		wantRemaining time.Duration
+		wantErr       bool
+	}{
+		{
+			name:          "Canary Deployment not yet created",
+			objects:       []client.Object{},
+			canary:        &TestWorkloadGenerator{TName: "canary", TNamespace: "ns", TWeights: testWeights()},
+			wantWeight:    5,
+			wantRemaining: 10 * time.Minute,
+		},
+		{
+			name: "Canary Deployment in the second step",
+			objects: []client.Object{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: "canary", Namespace: "ns", CreationTimestamp: metav1.NewTime(time.Now().Add(-15 * time.Minute)),
+			}}},
+			canary:        &TestWorkloadGenerator{TName: "canary", TNamespace: "ns", TWeights: testWeights()},
+			wantWeight:    25,
+			wantRemaining: 5 * time.Minute,
+		},
+		{
+			name: "All steps completed",
+			objects: []client.Object{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: "canary", Namespace: "ns", CreationTimestamp: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+			}}},
+			canary:        &TestWorkloadGenerator{TName: "canary", TNamespace: "ns", TWeights: testWeights()},
+			wantWeight:    50,
+			wantRemaining: 0,
+		},
+		{
+			name: "Steps are measured from the last change of the canary spec",
+			objects: []client.Object{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: "canary", Namespace: "ns", CreationTimestamp: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				Annotations: map[string]string{
+					canarySpecHashAnnotation:      scheduleHash(testWeights()),
+					canaryScheduleStartAnnotation: time.Now().Add(-15 * time.Minute).Format(time.RFC3339),
+				},
+			}}},
+			canary:        &TestWorkloadGenerator{TName: "canary", TNamespace: "ns", TWeights: testWeights()},
+			wantWeight:    25,
+			wantRemaining: 5 * time.Minute,
+		},
+		{
+			name: "Steps are not restarted when the canary replicas change",
+			objects: []client.Object{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: "canary", Namespace: "ns", CreationTimestamp: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				Annotations: map[string]string{
+					canarySpecHashAnnotation:      scheduleHash(testWeights()),
+					canaryScheduleStartAnnotation: time.Now().Add(-15 * time.Minute).Format(time.RFC3339),
+				},
+			}}},
+			canary: &TestWorkloadGenerator{TName: "canary", TNamespace: "ns", TWeights: func() *saasv1alpha1.Canary {
+				c := testWeights()
+				c.Replicas = util.Pointer[int32](3)
+				return c
+			}()},
+			wantWeight:    25,
+			wantRemaining: 5 * time.Minute,
+		},
+		{
+			name: "Canary spec has changed",
+			objects: []client.Object{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: "canary", Namespace: "ns", CreationTimestamp: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				Annotations: map[string]string{
+					canarySpecHashAnnotation:      "outdated",
+					canaryScheduleStartAnnotation: time.Now().Add(-1 * time.Hour).Format(time.RFC3339),
+				},
+			}}},
+			canary:        &TestWorkloadGenerator{TName: "canary", TNamespace: "ns", TWeights: testWeights()},
+			wantWeight:    5,
+			wantRemaining: 10 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := scheme.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			cl := fake.NewClientBuilder().WithScheme(s).WithObjects(tt.objects...).Build()
+
+			gotWeight, gotRemaining, err := CanaryWeight(context.TODO(), cl, tt.canary)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CanaryWeight() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotWeight != tt.wantWeight {
+				t.Errorf("CanaryWeight() weight = %v, want %v", gotWeight, tt.wantWeight)
+			}
+			// allow some margin as the remaining time depends on the current time
+			if diff := tt.wantRemaining - gotRemaining; diff < 0 || diff > time.Minute {
+				t.Errorf("CanaryWeight() remaining = %v, want %v", gotRemaining, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func Test_weightedTraffic_weightedClusters(t *testing.T) {
+	wt := &weightedTraffic{
+		main:   &TestWorkloadGenerator{TName: "main", TNamespace: "ns"},
+		canary: &TestWorkloadGenerator{TName: "canary", TNamespace: "ns", TWeights: testWeights()},
+	}
+
+	got := wt.weightedClusters(testEnvoyConfigs(), 25)
+	want := []interface{}{
+		&saasv1alpha1.Cluster{
+			Host: "127.0.0.1", Port: 8080, IsHttp2: util.Pointer(false), CanaryWeighted: util.Pointer(true),
+			WeightedUpstreams: []saasv1alpha1.WeightedUpstream{
+				{Host: "main-direct", Port: 8080, Weight: 75},
+				{Host: "canary-direct", Port: 8080, Weight: 25},
+			},
+		},
+		&saasv1alpha1.Cluster{Host: "127.0.0.1", Port: 9090, IsHttp2: util.Pointer(false)},
+	}
+
+	opts := []interface{}{}
+	for _, desc := range got {
+		opts = append(opts, desc.GetOptions())
+	}
+	if diff := deep.Equal(opts, want); len(diff) > 0 {
+		t.Errorf("weightedTraffic.weightedClusters() = diff %v", diff)
+	}
+	if got[0].GetName() != "app" || got[0].GetGeneratorVersion() != "v1" {
+		t.Errorf("weightedTraffic.weightedClusters() unexpected descriptor %s_%s", got[0].GetName(), got[0].GetGeneratorVersion())
+	}
+}
+
+func Test_weightedTraffic_directServices(t *testing.T) {
+	wt := &weightedTraffic{
+		main: &TestWorkloadGenerator{
+			TName: "main", TNamespace: "ns",
+			TLabels:       map[string]string{"key": "value"},
+			TSelector:     map[string]string{"deployment": "main"},
+			TEnvoyConfigs: testEnvoyConfigs(),
+		},
+		canary: &TestWorkloadGenerator{
+			TName: "canary", TNamespace: "ns",
+			TLabels:       map[string]string{"key": "value"},
+			TSelector:     map[string]string{"deployment": "canary"},
+			TEnvoyConfigs: testEnvoyConfigs(),
+			TWeights:      testWeights(),
+		},
+	}
+
+	want := []*corev1.Service{}
+	for _, name := range []string{"main", "canary"} {
+		want = append(want, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name + "-direct", Namespace: "ns", Labels: map[string]string{"key": "value"},
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeClusterIP,
+				Ports: []corev1.ServicePort{{
+					Name: "port-8080", Port: 8080, TargetPort: intstr.FromInt(8080), Protocol: corev1.ProtocolTCP,
+				}},
+				Selector: map[string]string{"deployment": name},
+			},
+		})
+	}
+
+	got := []*corev1.Service{}
+	for _, tmpl := range wt.directServices() {
+		o, err := tmpl.Build(context.TODO(), nil, nil)
+		if err != nil {
+			t.Fatalf("weightedTraffic.directServices() error = %v", err)
+		}
+		got = append(got, o.(*corev1.Service))
+	}
+	if diff := deep.Equal(got, want); len(diff) > 0 {
+		t.Errorf("weightedTraffic.directServices() = diff %v", diff)
+	}
+}
+
+func Test_weightedTraffic_envoyConfigs(t *testing.T) {
+	main := &TestWorkloadGenerator{TName: "main", TNamespace: "ns", TEnvoyConfigs: testEnvoyConfigs()}
+	canary := &TestWorkloadGenerator{TName: "canary", TNamespace: "ns", TEnvoyConfigs: testEnvoyConfigs(), TWeights: testWeights()}
+
+	templates, err := New(main, canary)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	got := map[string]bool{}
+	for _, tmpl := range templates {
+		o, err := tmpl.Build(context.TODO(), cl, nil)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if ec, ok := o.(*marin3rv1alpha1.EnvoyConfig); ok {
+			spec, err := json.Marshal(ec.Spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got[ec.GetName()] = strings.Contains(string(spec), "canary-direct")
+		}
+	}
+
+	// only the main Deployment splits the traffic between both Deployments
+	if want := map[string]bool{"main": true, "canary": false}; !reflect.DeepEqual(got, want) {
+		t.Errorf("New() weighted EnvoyConfigs = %v, want %v", got, want)
+	}
+}
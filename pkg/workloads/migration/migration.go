@@ -0,0 +1,199 @@
+package migration_workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/3scale-ops/basereconciler/resource"
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MigrationWorkload is a workload that runs the database migrations of a component
+type MigrationWorkload interface {
+	GetKey() types.NamespacedName
+	GetLabels() map[string]string
+	DatabaseMigrationSpec() *saasv1alpha1.DatabaseMigrationSpec
+	// Job returns the Job that runs the database migrations using the given image
+	Job(image string) *batchv1.Job
+}
+
+// Gate holds the rollout of a set of Deployments to a new image until the
+// database migrations have successfully run using that image
+type Gate struct {
+	Workload MigrationWorkload
+	// Deployment is the key of the Deployment whose image the database is known
+	// to be migrated to, usually the main Deployment of the component
+	Deployment types.NamespacedName
+	// Images are the image specs of the gated Deployments. All of them must
+	// point to the same image. If the migrations have not yet succeeded,
+	// they are modified to point to the image of the live Deployment.
+	Images []*saasv1alpha1.ImageSpec
+	// Retry is part of the name of the migration Job, so a new Job is
+	// created to retry a failed migration each time its value changes
+	Retry string
+	// Hold prevents the creation of the migration Job, for example while
+	// the migration of a different image is still running. A Job that
+	// already exists is not affected.
+	Hold bool
+}
+
+// Desired returns the image that the gated Deployments are rolled out to once
+// the migrations succeed, or an empty string if the gate holds no Deployments
+func (g Gate) Desired() string {
+	if len(g.Images) == 0 {
+		return ""
+	}
+	return Image(g.Images[0])
+}
+
+// Reconcile evaluates the state of the migration of the image of the gated Deployments.
+// It returns the template of the migration Job and the status of the migration, or
+// nil if no migration is required. The template is also nil while the gate holds the
+// creation of the Job. The image specs of the gate are reverted to the image of the live
+// Deployment until the migration succeeds. If the current image is unknown, because the
+// Deployment does not exist yet, the migrations are run but the rollout is not held, as
+// there is no previous image to hold it to.
+func (g Gate) Reconcile(ctx context.Context, cl client.Client) (resource.TemplateInterface,
+	*saasv1alpha1.DatabaseMigrationStatus, error) {
+
+	if len(g.Images) == 0 || !g.Workload.DatabaseMigrationSpec().IsEnabled() {
+		return nil, nil, nil
+	}
+
+	current, err := CurrentImage(ctx, cl, g.Deployment)
+	if err != nil {
+		return nil, nil, err
+	}
+	desired := g.Desired()
+	if current == desired {
+		return nil, nil, nil
+	}
+
+	key := JobKey(g.Workload, desired, g.Retry)
+	var template resource.TemplateInterface = jobTemplate(g.Workload, desired, key)
+	status := &saasv1alpha1.DatabaseMigrationStatus{
+		Image: desired,
+		Job:   key.Name,
+		Phase: saasv1alpha1.DatabaseMigrationRunning,
+	}
+
+	live := &batchv1.Job{}
+	if err := cl.Get(ctx, key, live); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, nil, err
+		}
+		if g.Hold {
+			template = nil
+			status.Message = "waiting for a running migration to complete"
+		}
+	} else if phase, msg := jobPhase(live); phase != saasv1alpha1.DatabaseMigrationRunning {
+		status.Phase, status.Message = phase, msg
+	}
+
+	if status.Phase != saasv1alpha1.DatabaseMigrationSucceeded && current != "" {
+		for _, spec := range g.Images {
+			spec.Name, spec.Tag = parseImage(current)
+		}
+	}
+
+	return template, status, nil
+}
+
+// InFlight returns the template and the status of the given migration if its Job is
+// still running, or nil otherwise. It is used to keep the Job of a migration that is
+// no longer required, because the image of the gated Deployments changed before the
+// migration completed, as deleting the Job would interrupt the migration.
+func InFlight(ctx context.Context, cl client.Client, w MigrationWorkload,
+	migration saasv1alpha1.DatabaseMigrationStatus) (resource.TemplateInterface, *saasv1alpha1.DatabaseMigrationStatus, error) {
+
+	key := types.NamespacedName{Name: migration.Job, Namespace: w.GetKey().Namespace}
+	live := &batchv1.Job{}
+	if err := cl.Get(ctx, key, live); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if phase, _ := jobPhase(live); phase != saasv1alpha1.DatabaseMigrationRunning {
+		return nil, nil, nil
+	}
+
+	status := migration.DeepCopy()
+	status.Message = "superseded by a newer image, waiting for the migration to complete"
+	return jobTemplate(w, migration.Image, key), status, nil
+}
+
+// JobKey returns the key of the Job that runs the migrations using the given image
+// and retry. Each image gets its own Job so the migrations of an image only run once,
+// unless they are retried.
+func JobKey(w MigrationWorkload, image, retry string) types.NamespacedName {
+	id := image
+	if retry != "" {
+		id = fmt.Sprintf("%s/%s", image, retry)
+	}
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-%s", w.GetKey().Name, util.Hash(id)),
+		Namespace: w.GetKey().Namespace,
+	}
+}
+
+func jobTemplate(w MigrationWorkload, image string, key types.NamespacedName) *resource.Template[*batchv1.Job] {
+	job := w.Job(image)
+	job.ObjectMeta.Name = key.Name
+	job.ObjectMeta.Namespace = key.Namespace
+	return resource.NewTemplateFromObjectFunction(func() *batchv1.Job { return job.DeepCopy() })
+}
+
+// Image returns the image reference of the given image spec
+func Image(spec *saasv1alpha1.ImageSpec) string {
+	return fmt.Sprintf("%s:%s", *spec.Name, *spec.Tag)
+}
+
+// parseImage splits an image reference in its name and tag
+func parseImage(image string) (*string, *string) {
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return util.Pointer(image[:i]), util.Pointer(image[i+1:])
+	}
+	return util.Pointer(image), util.Pointer("latest")
+}
+
+// jobPhase returns the phase of the migration run by
+// the given Job and the reason of the failure, if any
+func jobPhase(job *batchv1.Job) (saasv1alpha1.DatabaseMigrationPhase, string) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return saasv1alpha1.DatabaseMigrationSucceeded, ""
+		case batchv1.JobFailed:
+			return saasv1alpha1.DatabaseMigrationFailed, c.Message
+		}
+	}
+	return saasv1alpha1.DatabaseMigrationRunning, ""
+}
+
+// CurrentImage returns the image run by the live Deployment with the given
+// key, or an empty string if the Deployment does not exist
+func CurrentImage(ctx context.Context, cl client.Client, key types.NamespacedName) (string, error) {
+	live := &appsv1.Deployment{}
+	if err := cl.Get(ctx, key, live); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if len(live.Spec.Template.Spec.Containers) == 0 {
+		return "", nil
+	}
+	return live.Spec.Template.Spec.Containers[0].Image, nil
+}
@@ -0,0 +1,313 @@
+package migration_workload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type testMigrationGenerator struct {
+	enabled bool
+}
+
+var _ MigrationWorkload = &testMigrationGenerator{}
+
+func (gen *testMigrationGenerator) GetKey() types.NamespacedName {
+	return types.NamespacedName{Name: "migrate", Namespace: "ns"}
+}
+func (gen *testMigrationGenerator) GetLabels() map[string]string {
+	return map[string]string{"key": "value"}
+}
+func (gen *testMigrationGenerator) DatabaseMigrationSpec() *saasv1alpha1.DatabaseMigrationSpec {
+	return &saasv1alpha1.DatabaseMigrationSpec{Enabled: &gen.enabled}
+}
+func (gen *testMigrationGenerator) Job(image string) *batchv1.Job {
+	return &batchv1.Job{
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "migrate", Image: image}}},
+			},
+		},
+	}
+}
+
+func testJob(image string, conditions ...batchv1.JobCondition) client.Object {
+	return testRetriedJob(image, "", conditions...)
+}
+
+func testRetriedJob(image, retry string, conditions ...batchv1.JobCondition) client.Object {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobKey(&testMigrationGenerator{}, image, retry).Name,
+			Namespace: "ns",
+		},
+		Status: batchv1.JobStatus{Conditions: conditions},
+	}
+}
+
+func testDeployment(image string) client.Object {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+			},
+		},
+	}
+}
+
+func testClient(t *testing.T, objects ...client.Object) client.Client {
+	s := runtime.NewScheme()
+	if err := scheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build()
+}
+
+func TestGate_Reconcile(t *testing.T) {
+	image := func(tag string) *saasv1alpha1.ImageSpec {
+		return &saasv1alpha1.ImageSpec{Name: util.Pointer("registry:5000/system"), Tag: util.Pointer(tag)}
+	}
+	tests := []struct {
+		name         string
+		enabled      bool
+		current      string
+		retry        string
+		hold         bool
+		objects      []client.Object
+		wantStatus   *saasv1alpha1.DatabaseMigrationStatus
+		wantTemplate bool
+		wantImage    string
+	}{
+		{
+			name:       "Disabled",
+			enabled:    false,
+			current:    "registry:5000/system:v1",
+			wantStatus: nil,
+			wantImage:  "registry:5000/system:v2",
+		},
+		{
+			name:    "Migrates without holding the rollout if the current image is unknown",
+			enabled: true,
+			current: "",
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image: "registry:5000/system:v2",
+				Job:   JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "").Name,
+				Phase: saasv1alpha1.DatabaseMigrationRunning,
+			},
+			wantTemplate: true,
+			wantImage:    "registry:5000/system:v2",
+		},
+		{
+			name:       "Image already rolled out",
+			enabled:    true,
+			current:    "registry:5000/system:v2",
+			wantStatus: nil,
+			wantImage:  "registry:5000/system:v2",
+		},
+		{
+			name:    "Holds the rollout until the Job is created",
+			enabled: true,
+			current: "registry:5000/system:v1",
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image: "registry:5000/system:v2",
+				Job:   JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "").Name,
+				Phase: saasv1alpha1.DatabaseMigrationRunning,
+			},
+			wantTemplate: true,
+			wantImage:    "registry:5000/system:v1",
+		},
+		{
+			name:    "Holds the rollout while the Job runs",
+			enabled: true,
+			current: "registry:5000/system:v1",
+			objects: []client.Object{testJob("registry:5000/system:v2")},
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image: "registry:5000/system:v2",
+				Job:   JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "").Name,
+				Phase: saasv1alpha1.DatabaseMigrationRunning,
+			},
+			wantTemplate: true,
+			wantImage:    "registry:5000/system:v1",
+		},
+		{
+			name:    "Rolls out the image once the Job succeeds",
+			enabled: true,
+			current: "registry:5000/system:v1",
+			objects: []client.Object{testJob("registry:5000/system:v2",
+				batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue})},
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image: "registry:5000/system:v2",
+				Job:   JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "").Name,
+				Phase: saasv1alpha1.DatabaseMigrationSucceeded,
+			},
+			wantTemplate: true,
+			wantImage:    "registry:5000/system:v2",
+		},
+		{
+			name:    "Blocks the rollout if the Job fails",
+			enabled: true,
+			current: "registry:5000/system:v1",
+			objects: []client.Object{testJob("registry:5000/system:v2",
+				batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"})},
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image:   "registry:5000/system:v2",
+				Job:     JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "").Name,
+				Phase:   saasv1alpha1.DatabaseMigrationFailed,
+				Message: "BackoffLimitExceeded",
+			},
+			wantTemplate: true,
+			wantImage:    "registry:5000/system:v1",
+		},
+		{
+			name:    "Retries a failed migration with a new Job",
+			enabled: true,
+			current: "registry:5000/system:v1",
+			retry:   "1",
+			objects: []client.Object{testJob("registry:5000/system:v2",
+				batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"})},
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image: "registry:5000/system:v2",
+				Job:   JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "1").Name,
+				Phase: saasv1alpha1.DatabaseMigrationRunning,
+			},
+			wantTemplate: true,
+			wantImage:    "registry:5000/system:v1",
+		},
+		{
+			name:    "Rolls out the image once the retried Job succeeds",
+			enabled: true,
+			current: "registry:5000/system:v1",
+			retry:   "1",
+			objects: []client.Object{testRetriedJob("registry:5000/system:v2", "1",
+				batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue})},
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image: "registry:5000/system:v2",
+				Job:   JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "1").Name,
+				Phase: saasv1alpha1.DatabaseMigrationSucceeded,
+			},
+			wantTemplate: true,
+			wantImage:    "registry:5000/system:v2",
+		},
+		{
+			name:    "Does not create the Job while held",
+			enabled: true,
+			current: "registry:5000/system:v1",
+			hold:    true,
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image:   "registry:5000/system:v2",
+				Job:     JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "").Name,
+				Phase:   saasv1alpha1.DatabaseMigrationRunning,
+				Message: "waiting for a running migration to complete",
+			},
+			wantTemplate: false,
+			wantImage:    "registry:5000/system:v1",
+		},
+		{
+			name:    "Keeps an existing Job while held",
+			enabled: true,
+			current: "registry:5000/system:v1",
+			hold:    true,
+			objects: []client.Object{testJob("registry:5000/system:v2")},
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image: "registry:5000/system:v2",
+				Job:   JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "").Name,
+				Phase: saasv1alpha1.DatabaseMigrationRunning,
+			},
+			wantTemplate: true,
+			wantImage:    "registry:5000/system:v1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			main, canary := image("v2"), image("v2")
+			gate := Gate{
+				Workload:   &testMigrationGenerator{enabled: tt.enabled},
+				Deployment: types.NamespacedName{Name: "app", Namespace: "ns"},
+				Images:     []*saasv1alpha1.ImageSpec{main, canary},
+				Retry:      tt.retry,
+				Hold:       tt.hold,
+			}
+			objects := tt.objects
+			if tt.current != "" {
+				objects = append(objects, testDeployment(tt.current))
+			}
+			template, status, err := gate.Reconcile(context.TODO(), testClient(t, objects...))
+			if err != nil {
+				t.Fatalf("Gate.Reconcile() error = %v", err)
+			}
+			if diff := deep.Equal(status, tt.wantStatus); len(diff) > 0 {
+				t.Errorf("Gate.Reconcile() status diff = %v", diff)
+			}
+			if (template != nil) != tt.wantTemplate {
+				t.Errorf("Gate.Reconcile() template = %v, want template %v", template, tt.wantTemplate)
+			}
+			for _, spec := range []*saasv1alpha1.ImageSpec{main, canary} {
+				if got := Image(spec); got != tt.wantImage {
+					t.Errorf("Gate.Reconcile() image = %v, want %v", got, tt.wantImage)
+				}
+			}
+		})
+	}
+}
+
+func TestInFlight(t *testing.T) {
+	migration := saasv1alpha1.DatabaseMigrationStatus{
+		Image: "registry:5000/system:v2",
+		Job:   JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "").Name,
+		Phase: saasv1alpha1.DatabaseMigrationRunning,
+	}
+	tests := []struct {
+		name       string
+		objects    []client.Object
+		wantStatus *saasv1alpha1.DatabaseMigrationStatus
+	}{
+		{
+			name:       "Job not found",
+			wantStatus: nil,
+		},
+		{
+			name:    "Keeps the Job while it runs",
+			objects: []client.Object{testJob("registry:5000/system:v2")},
+			wantStatus: &saasv1alpha1.DatabaseMigrationStatus{
+				Image:   "registry:5000/system:v2",
+				Job:     JobKey(&testMigrationGenerator{}, "registry:5000/system:v2", "").Name,
+				Phase:   saasv1alpha1.DatabaseMigrationRunning,
+				Message: "superseded by a newer image, waiting for the migration to complete",
+			},
+		},
+		{
+			name: "Releases the Job once it completes",
+			objects: []client.Object{testJob("registry:5000/system:v2",
+				batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue})},
+			wantStatus: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, status, err := InFlight(context.TODO(), testClient(t, tt.objects...),
+				&testMigrationGenerator{enabled: true}, migration)
+			if err != nil {
+				t.Fatalf("InFlight() error = %v", err)
+			}
+			if diff := deep.Equal(status, tt.wantStatus); len(diff) > 0 {
+				t.Errorf("InFlight() status diff = %v", diff)
+			}
+			if (template != nil) != (tt.wantStatus != nil) {
+				t.Errorf("InFlight() template = %v, want template %v", template, tt.wantStatus != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package statefulset_workload
+
+import (
+	"github.com/3scale-ops/basereconciler/resource"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	deployment_workload "github.com/3scale-ops/saas-operator/pkg/workloads/deployment"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+type WithPartitionedRollout interface {
+	RolloutSpec() *saasv1alpha1.StatefulSetRolloutSpec
+}
+
+type StatefulSetWorkload interface {
+	deployment_workload.WithWorkloadMeta
+	WithPartitionedRollout
+	StatefulSet() *resource.Template[*appsv1.StatefulSet]
+}
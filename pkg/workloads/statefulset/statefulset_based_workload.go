@@ -0,0 +1,55 @@
+package statefulset_workload
+
+import (
+	"github.com/3scale-ops/basereconciler/resource"
+	"github.com/3scale-ops/basereconciler/util"
+	deployment_workload "github.com/3scale-ops/saas-operator/pkg/workloads/deployment"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// New returns the templates for a StatefulSet based workload. Changes to the
+// StatefulSet are rolled out using partitioned rolling updates, so only the pods
+// with an ordinal equal or greater than the partition of the workload get updated.
+func New(w StatefulSetWorkload) []resource.TemplateInterface {
+	return []resource.TemplateInterface{
+		w.StatefulSet().
+			Apply(meta(w)).
+			Apply(selector(w)).
+			Apply(partition(w)),
+	}
+}
+
+func meta(w deployment_workload.WithWorkloadMeta) resource.TemplateBuilderFunction[*appsv1.StatefulSet] {
+	return func(o client.Object) (*appsv1.StatefulSet, error) {
+		sts := o.(*appsv1.StatefulSet)
+		sts.SetName(w.GetKey().Name)
+		sts.SetNamespace(w.GetKey().Namespace)
+		sts.SetLabels(util.MergeMaps(map[string]string{}, sts.GetLabels(), w.GetLabels()))
+		return sts, nil
+	}
+}
+
+func selector(w deployment_workload.WithWorkloadMeta) resource.TemplateBuilderFunction[*appsv1.StatefulSet] {
+	return func(o client.Object) (*appsv1.StatefulSet, error) {
+		sts := o.(*appsv1.StatefulSet)
+		sts.Spec.Selector = &metav1.LabelSelector{MatchLabels: w.GetSelector()}
+		sts.Spec.Template.ObjectMeta.Labels = util.MergeMaps(map[string]string{},
+			sts.Spec.Template.ObjectMeta.Labels, w.GetLabels(), w.GetSelector())
+		return sts, nil
+	}
+}
+
+func partition(w WithPartitionedRollout) resource.TemplateBuilderFunction[*appsv1.StatefulSet] {
+	return func(o client.Object) (*appsv1.StatefulSet, error) {
+		sts := o.(*appsv1.StatefulSet)
+		sts.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: util.Pointer(w.RolloutSpec().GetPartition()),
+			},
+		}
+		return sts, nil
+	}
+}
@@ -0,0 +1,146 @@
+package statefulset_workload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/3scale-ops/basereconciler/resource"
+	"github.com/3scale-ops/basereconciler/util"
+	saasv1alpha1 "github.com/3scale-ops/saas-operator/api/v1alpha1"
+	"github.com/go-test/deep"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TEST GENERATORS
+type TestWorkloadGenerator struct {
+	TName      string
+	TNamespace string
+	TLabels    map[string]string
+	TSelector  map[string]string
+	TRollout   *saasv1alpha1.StatefulSetRolloutSpec
+}
+
+var _ StatefulSetWorkload = &TestWorkloadGenerator{}
+
+func (gen *TestWorkloadGenerator) StatefulSet() *resource.Template[*appsv1.StatefulSet] {
+	return resource.NewTemplate(func(client.Object) (*appsv1.StatefulSet, error) {
+		return &appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: util.Pointer[int32](3),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"pod": "label"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "container", Image: "example.com:latest"}},
+					},
+				},
+			},
+		}, nil
+	})
+}
+func (gen *TestWorkloadGenerator) GetKey() types.NamespacedName {
+	return types.NamespacedName{Name: gen.TName, Namespace: gen.TNamespace}
+}
+func (gen *TestWorkloadGenerator) GetLabels() map[string]string   { return gen.TLabels }
+func (gen *TestWorkloadGenerator) GetSelector() map[string]string { return gen.TSelector }
+func (gen *TestWorkloadGenerator) RolloutSpec() *saasv1alpha1.StatefulSetRolloutSpec {
+	return gen.TRollout
+}
+
+// // TESTS START HERE
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		w    StatefulSetWorkload
+		want *appsv1.StatefulSet
+	}{
+		{
+			name: "Updates all the pods by default",
+			w: &TestWorkloadGenerator{
+				TName:      "sts",
+				TNamespace: "ns",
+				TLabels:    map[string]string{"key": "value"},
+				TSelector:  map[string]string{"selector": "sts"},
+			},
+			want: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sts",
+					Namespace: "ns",
+					Labels:    map[string]string{"key": "value"},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: util.Pointer[int32](3),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"selector": "sts"}},
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						Type: appsv1.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+							Partition: util.Pointer[int32](0),
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"pod": "label", "key": "value", "selector": "sts"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "container", Image: "example.com:latest"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Holds the pods below the partition",
+			w: &TestWorkloadGenerator{
+				TName:      "sts",
+				TNamespace: "ns",
+				TLabels:    map[string]string{"key": "value"},
+				TSelector:  map[string]string{"selector": "sts"},
+				TRollout:   &saasv1alpha1.StatefulSetRolloutSpec{Partition: util.Pointer[int32](2)},
+			},
+			want: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sts",
+					Namespace: "ns",
+					Labels:    map[string]string{"key": "value"},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: util.Pointer[int32](3),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"selector": "sts"}},
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						Type: appsv1.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+							Partition: util.Pointer[int32](2),
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"pod": "label", "key": "value", "selector": "sts"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "container", Image: "example.com:latest"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates := New(tt.w)
+			if len(templates) != 1 {
+				t.Fatalf("New() returned %d templates, want 1", len(templates))
+			}
+			got, err := templates[0].Build(context.TODO(), nil, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Errorf("New() = diff %v", diff)
+			}
+		})
+	}
+}